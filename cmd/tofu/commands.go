@@ -366,6 +366,22 @@ func initCommands(
 			return &command.StateCommand{}, nil
 		},
 
+		"state encryption": func() (cli.Command, error) {
+			return &command.StateEncryptionCommand{}, nil
+		},
+
+		"state encryption status": func() (cli.Command, error) {
+			return &command.StateEncryptionStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state history": func() (cli.Command, error) {
+			return &command.StateHistoryCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state list": func() (cli.Command, error) {
 			return &command.StateListCommand{
 				Meta: meta,
@@ -428,6 +444,24 @@ func initCommands(
 			}, nil
 		},
 
+		"state rekey": func() (cli.Command, error) {
+			return &command.StateRekeyCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state restore": func() (cli.Command, error) {
+			return &command.StateRestoreCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state rollback": func() (cli.Command, error) {
+			return &command.StateRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state show": func() (cli.Command, error) {
 			return &command.StateShowCommand{
 				Meta: meta,
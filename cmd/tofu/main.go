@@ -212,8 +212,10 @@ func realMain() int {
 		return 1
 	}
 
-	// Initialize the backends.
+	// Initialize the backends. Backend plugins are installed in the same
+	// way as providers.
 	backendInit.Init(services)
+	backendInit.SetPluginSource(providerSrc)
 
 	// Get the command line args.
 	binName := filepath.Base(os.Args[0])
@@ -39,11 +39,15 @@ type Function struct {
 const (
 	FunctionNamespaceProvider = "provider"
 	FunctionNamespaceCore     = "core"
+	FunctionNamespaceModule   = "module"
+	FunctionNamespaceTofu     = "tofu"
 )
 
 var FunctionNamespaces = []string{
 	FunctionNamespaceProvider,
 	FunctionNamespaceCore,
+	FunctionNamespaceModule,
+	FunctionNamespaceTofu,
 }
 
 func ParseFunction(input string) Function {
@@ -71,6 +71,17 @@ func ParseRemoveEndpoint(traversal hcl.Traversal) (*RemoveEndpoint, tfdiags.Diag
 		return nil, diags
 	}
 
+	if riAddr.Resource.Mode == EphemeralResourceMode {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Ephemeral resource address is not allowed",
+			Detail:   "Ephemeral resources are never recorded in the state, and therefore, 'removed' blocks are not allowed to target them.",
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+
+		return nil, diags
+	}
+
 	return &RemoveEndpoint{
 		RelSubject:  riAddr,
 		SourceRange: rng,
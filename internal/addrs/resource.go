@@ -29,6 +29,8 @@ func (r Resource) String() string {
 		return fmt.Sprintf("%s.%s", r.Type, r.Name)
 	case DataResourceMode:
 		return fmt.Sprintf("data.%s.%s", r.Type, r.Name)
+	case EphemeralResourceMode:
+		return fmt.Sprintf("ephemeral.%s.%s", r.Type, r.Name)
 	default:
 		// Should never happen, but we'll return a string here rather than
 		// crashing just in case it does.
@@ -43,7 +45,7 @@ func (r Resource) Equal(o Resource) bool {
 func (r Resource) Less(o Resource) bool {
 	switch {
 	case r.Mode != o.Mode:
-		return r.Mode == DataResourceMode
+		return r.Mode.sortOrder() < o.Mode.sortOrder()
 
 	case r.Type != o.Type:
 		return r.Type < o.Type
@@ -511,4 +513,23 @@ const (
 	// DataResourceMode indicates a data resource, as defined by
 	// "data" blocks in configuration.
 	DataResourceMode ResourceMode = 'D'
+
+	// EphemeralResourceMode indicates an ephemeral resource, as defined by
+	// "ephemeral" blocks in configuration. Ephemeral resources are opened
+	// anew during each graph walk and are never recorded in the state.
+	EphemeralResourceMode ResourceMode = 'E'
 )
+
+// sortOrder returns a number that places data resources before ephemeral
+// resources, and both of those before managed resources, for use in
+// Resource.Less.
+func (m ResourceMode) sortOrder() int {
+	switch m {
+	case DataResourceMode:
+		return 0
+	case EphemeralResourceMode:
+		return 1
+	default:
+		return 2
+	}
+}
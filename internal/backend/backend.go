@@ -172,6 +172,20 @@ type Local interface {
 	LocalRun(context.Context, *Operation) (*LocalRun, statemgr.Full, tfdiags.Diagnostics)
 }
 
+// PluginInstaller is implemented by backends that install a plugin when
+// they are configured, which is recorded in the dependency lock file in the
+// same way as a provider.
+type PluginInstaller interface {
+	// SetDependencyLocks must be called before Configure with the locks from
+	// the dependency lock file of the working directory.
+	//
+	// If install is true, Configure may install a plugin that is not in the
+	// locks yet, and records its selected version and hashes in the given
+	// locks. Otherwise, Configure only uses the plugin that is already
+	// installed and recorded in the locks, and fails if there is none.
+	SetDependencyLocks(locks *depsfile.Locks, install bool)
+}
+
 // LocalRun represents the assortment of objects that we can collect or
 // calculate from an Operation object, which we can then use for local
 // operations.
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
 
@@ -21,13 +22,16 @@ import (
 	backendAzure "github.com/opentofu/opentofu/internal/backend/remote-state/azure"
 	backendConsul "github.com/opentofu/opentofu/internal/backend/remote-state/consul"
 	backendCos "github.com/opentofu/opentofu/internal/backend/remote-state/cos"
+	backendEtcdv3 "github.com/opentofu/opentofu/internal/backend/remote-state/etcdv3"
 	backendGCS "github.com/opentofu/opentofu/internal/backend/remote-state/gcs"
 	backendHTTP "github.com/opentofu/opentofu/internal/backend/remote-state/http"
 	backendInmem "github.com/opentofu/opentofu/internal/backend/remote-state/inmem"
 	backendKubernetes "github.com/opentofu/opentofu/internal/backend/remote-state/kubernetes"
 	backendOSS "github.com/opentofu/opentofu/internal/backend/remote-state/oss"
 	backendPg "github.com/opentofu/opentofu/internal/backend/remote-state/pg"
+	backendPlugin "github.com/opentofu/opentofu/internal/backend/remote-state/plugin"
 	backendS3 "github.com/opentofu/opentofu/internal/backend/remote-state/s3"
+	backendSQLite "github.com/opentofu/opentofu/internal/backend/remote-state/sqlite"
 	backendCloud "github.com/opentofu/opentofu/internal/cloud"
 )
 
@@ -39,12 +43,15 @@ import (
 // safe concurrent read access to the list of built-in backends.
 //
 // Backends are hardcoded into OpenTofu because the API for backends uses
-// complex structures and supporting that over the plugin system is currently
-// prohibitively difficult. For those wanting to implement a custom backend,
-// they can do so with recompilation.
+// complex structures. Custom backends can instead implement the backend
+// plugin protocol, and be selected with the "plugin" backend.
 var backends map[string]backend.InitFn
 var backendsLock sync.Mutex
 
+// pluginSource is the source that the "plugin" backend installs backend
+// plugins from.
+var pluginSource getproviders.Source
+
 // RemovedBackends is a record of previously supported backends which have
 // since been deprecated and removed.
 var RemovedBackends map[string]string
@@ -54,6 +61,10 @@ func Init(services *disco.Disco) {
 	backendsLock.Lock()
 	defer backendsLock.Unlock()
 
+	// Backend plugins are installed from the registry, unless the CLI
+	// configuration sets another source with SetPluginSource.
+	pluginSource = getproviders.NewRegistrySource(services)
+
 	// NOTE: Underscore-prefixed named are reserved for unit testing use via
 	// the RegisterTemp function. Do not add any underscore-prefixed names
 	// to the following table.
@@ -66,13 +77,16 @@ func Init(services *disco.Disco) {
 		"azurerm":    func(enc encryption.StateEncryption) backend.Backend { return backendAzure.New(enc) },
 		"consul":     func(enc encryption.StateEncryption) backend.Backend { return backendConsul.New(enc) },
 		"cos":        func(enc encryption.StateEncryption) backend.Backend { return backendCos.New(enc) },
+		"etcdv3":     func(enc encryption.StateEncryption) backend.Backend { return backendEtcdv3.New(enc) },
 		"gcs":        func(enc encryption.StateEncryption) backend.Backend { return backendGCS.New(enc) },
 		"http":       func(enc encryption.StateEncryption) backend.Backend { return backendHTTP.New(enc) },
 		"inmem":      func(enc encryption.StateEncryption) backend.Backend { return backendInmem.New(enc) },
 		"kubernetes": func(enc encryption.StateEncryption) backend.Backend { return backendKubernetes.New(enc) },
 		"oss":        func(enc encryption.StateEncryption) backend.Backend { return backendOSS.New(enc) },
 		"pg":         func(enc encryption.StateEncryption) backend.Backend { return backendPg.New(enc) },
+		"plugin":     func(enc encryption.StateEncryption) backend.Backend { return backendPlugin.New(PluginSource(), enc) },
 		"s3":         func(enc encryption.StateEncryption) backend.Backend { return backendS3.New(enc) },
+		"sqlite":     func(enc encryption.StateEncryption) backend.Backend { return backendSQLite.New(enc) },
 
 		// Terraform Cloud 'backend'
 		// This is an implementation detail only, used for the cloud package
@@ -83,7 +97,6 @@ func Init(services *disco.Disco) {
 		"artifactory": `The "artifactory" backend is not supported in OpenTofu v1.3 or later.`,
 		"azure":       `The "azure" backend name has been removed, please use "azurerm".`,
 		"etcd":        `The "etcd" backend is not supported in OpenTofu v1.3 or later.`,
-		"etcdv3":      `The "etcdv3" backend is not supported in OpenTofu v1.3 or later.`,
 		"manta":       `The "manta" backend is not supported in OpenTofu v1.3 or later.`,
 		"swift":       `The "swift" backend is not supported in OpenTofu v1.3 or later.`,
 	}
@@ -97,6 +110,23 @@ func Backend(name string) backend.InitFn {
 	return backends[name]
 }
 
+// SetPluginSource sets the source that the "plugin" backend installs backend
+// plugins from. It must be called after Init, and before OpenTofu is
+// executing.
+func SetPluginSource(source getproviders.Source) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+	pluginSource = source
+}
+
+// PluginSource returns the source that the "plugin" backend installs backend
+// plugins from.
+func PluginSource() getproviders.Source {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+	return pluginSource
+}
+
 // Set sets a new backend in the list of backends. If f is nil then the
 // backend will be removed from the map. If this backend already exists
 // then it will be overwritten.
@@ -25,10 +25,13 @@ func TestInit_backend(t *testing.T) {
 		{"azurerm", "*azure.Backend"},
 		{"consul", "*consul.Backend"},
 		{"cos", "*cos.Backend"},
+		{"etcdv3", "*etcdv3.Backend"},
 		{"gcs", "*gcs.Backend"},
 		{"inmem", "*inmem.Backend"},
 		{"pg", "*pg.Backend"},
+		{"plugin", "*plugin.Backend"},
 		{"s3", "*s3.Backend"},
+		{"sqlite", "*sqlite.Backend"},
 	}
 
 	// Make sure we get the requested backend
@@ -24,6 +24,7 @@ import (
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 const (
@@ -56,6 +57,11 @@ type Local struct {
 	StateBackupPath   string
 	StateWorkspaceDir string
 
+	// StateHistoryLimit is the number of earlier state snapshots to keep for
+	// each workspace, next to its state file. History is disabled when it is
+	// zero.
+	StateHistoryLimit int
+
 	// The OverrideState* paths are set based on per-operation CLI arguments
 	// and will override what'd be built from the State* fields if non-empty.
 	// While the interpretation of the State* fields depends on the active
@@ -126,6 +132,10 @@ func (b *Local) ConfigSchema() *configschema.Block {
 				Type:     cty.String,
 				Optional: true,
 			},
+			"history_limit": {
+				Type:     cty.Number,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -161,6 +171,18 @@ func (b *Local) PrepareConfig(obj cty.Value) (cty.Value, tfdiags.Diagnostics) {
 		}
 	}
 
+	if val := obj.GetAttr("history_limit"); !val.IsNull() {
+		var n int
+		if err := gocty.FromCtyValue(val, &n); err != nil || n < 0 {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid state history limit",
+				`The "history_limit" attribute value must be a whole number that is not negative.`,
+				cty.Path{cty.GetAttrStep{Name: "history_limit"}},
+			))
+		}
+	}
+
 	return obj, diags
 }
 
@@ -187,6 +209,14 @@ func (b *Local) Configure(obj cty.Value) tfdiags.Diagnostics {
 		b.StateWorkspaceDir = DefaultWorkspaceDir
 	}
 
+	if val := obj.GetAttr("history_limit"); !val.IsNull() {
+		if err := gocty.FromCtyValue(val, &b.StateHistoryLimit); err != nil {
+			diags = diags.Append(err)
+		}
+	} else {
+		b.StateHistoryLimit = 0
+	}
+
 	return diags
 }
 
@@ -267,6 +297,9 @@ func (b *Local) StateMgr(name string) (statemgr.Full, error) {
 	if backupPath != "" {
 		s.SetBackupPath(backupPath)
 	}
+	if b.StateHistoryLimit > 0 {
+		s.SetHistoryLimit(b.StateHistoryLimit)
+	}
 
 	if b.states == nil {
 		b.states = map[string]statemgr.Full{}
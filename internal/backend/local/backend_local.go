@@ -18,6 +18,7 @@ import (
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -266,10 +267,28 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	// we need to apply the plan.
 	run.Plan = plan
 
+	applyTimeVars := make(map[string]bool, len(plan.ApplyTimeVariables))
+	for _, name := range plan.ApplyTimeVariables {
+		applyTimeVars[name] = true
+	}
+
 	subCall := op.RootCall.WithVariables(func(variable *configs.Variable) (cty.Value, hcl.Diagnostics) {
 		var diags hcl.Diagnostics
 
 		name := variable.Name
+		if applyTimeVars[name] {
+			// Values for ephemeral variables are not saved in the plan, so
+			// they must be provided again for this apply.
+			raw, ok := op.Variables[name]
+			if !ok {
+				return cty.DynamicVal, diags.Append(missingApplyTimeVariableDiag(variable))
+			}
+			iv, ivDiags := raw.ParseVariableValue(variable.ParsingMode)
+			if ivDiags.HasErrors() {
+				return cty.DynamicVal, diags.Extend(ivDiags.ToHCL())
+			}
+			return iv.Value, diags
+		}
 		v, ok := plan.VariableValues[name]
 		if !ok {
 			if variable.Required() {
@@ -300,6 +319,14 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	}
 	run.Config = config
 
+	if len(applyTimeVars) > 0 {
+		moreDiags := b.applyTimeVariableValues(op, config, plan, applyTimeVars)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, snap, diags
+		}
+	}
+
 	// NOTE: We're intentionally comparing the current locks with the
 	// configuration snapshot, rather than the lock snapshot in the plan file,
 	// because it's the current locks which dictate our plugin selections
@@ -395,6 +422,91 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	return run, snap, diags
 }
 
+// applyTimeVariableValues gathers the values of the ephemeral root module
+// input variables whose names are given in applyTimeVars, which are not saved
+// in plan files and so must be provided again when applying.
+//
+// The gathered values are added to the in-memory plan's VariableValues, so
+// that OpenTofu Core can use them in the same way as the values that were
+// saved when the plan was created. Any other variables set explicitly on the
+// command line are rejected, because their values were already decided in
+// the plan.
+func (b *Local) applyTimeVariableValues(op *backend.Operation, config *configs.Config, plan *plans.Plan, applyTimeVars map[string]bool) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	rawVariables := make(map[string]backend.UnparsedVariableValue, len(applyTimeVars))
+	decls := make(map[string]*configs.Variable, len(applyTimeVars))
+	for name, raw := range op.Variables {
+		if applyTimeVars[name] {
+			rawVariables[name] = raw
+			continue
+		}
+		vc, declared := config.Module.Variables[name]
+		if !declared {
+			continue
+		}
+		val, valDiags := raw.ParseVariableValue(vc.ParsingMode)
+		if valDiags.HasErrors() {
+			continue
+		}
+		switch val.SourceType {
+		case tofu.ValueFromCLIArg, tofu.ValueFromNamedFile:
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Can't set variables when applying a saved plan",
+				fmt.Sprintf("The variable %q cannot be set when applying a saved plan file, because a saved plan includes the variable values that were set when it was created. Only ephemeral variables can be set when applying a saved plan.", name),
+			))
+		}
+	}
+	for name := range applyTimeVars {
+		if vc, ok := config.Module.Variables[name]; ok {
+			decls[name] = vc
+		}
+	}
+
+	rawVariables = b.interactiveCollectVariables(context.TODO(), rawVariables, decls, op.UIIn)
+	for name, vc := range decls {
+		if _, ok := rawVariables[name]; !ok && vc.Required() {
+			diags = diags.Append(missingApplyTimeVariableDiag(vc))
+		}
+	}
+	if diags.HasErrors() {
+		return diags
+	}
+
+	variables, varDiags := backend.ParseVariableValues(rawVariables, decls)
+	diags = diags.Append(varDiags)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for name, iv := range variables {
+		if iv.Value == cty.NilVal {
+			continue // Let OpenTofu Core use the default value, if any.
+		}
+		dv, err := plans.NewDynamicValue(iv.Value, cty.DynamicPseudoType)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid value for input variable",
+				fmt.Sprintf("The value for ephemeral variable %q could not be prepared for applying: %s.", name, err),
+			))
+			continue
+		}
+		plan.VariableValues[name] = dv
+	}
+	return diags
+}
+
+func missingApplyTimeVariableDiag(vc *configs.Variable) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "No value for required variable",
+		Detail:   fmt.Sprintf("The root module input variable %q is ephemeral, so its value was not saved in the plan file. Use a -var or -var-file command line argument to provide a value for this variable.", vc.Name),
+		Subject:  vc.DeclRange.Ptr(),
+	}
+}
+
 // interactiveCollectVariables attempts to complete the given existing
 // map of variables by interactively prompting for any variables that are
 // declared as required but not yet present.
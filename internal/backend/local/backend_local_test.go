@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/zclconf/go-cty/cty"
@@ -18,8 +19,10 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/initwd"
 	"github.com/opentofu/opentofu/internal/plans"
@@ -211,6 +214,143 @@ func TestLocalRun_stalePlan(t *testing.T) {
 	assertBackendStateUnlocked(t, b)
 }
 
+func TestLocalRun_planWithApplyTimeVariables(t *testing.T) {
+	configDir := "./testdata/apply-ephemeral"
+
+	_, configLoader, configCleanup := initwd.MustLoadConfigForTests(t, configDir, "tests")
+	defer configCleanup()
+
+	_, snap, configDiags := configLoader.LoadConfigWithSnapshot(configDir, configs.RootModuleCallForTesting())
+	if configDiags.HasErrors() {
+		t.Fatal(configDiags.Error())
+	}
+
+	backendConfig := cty.ObjectVal(map[string]cty.Value{
+		"path":          cty.NullVal(cty.String),
+		"workspace_dir": cty.NullVal(cty.String),
+	})
+	backendConfigRaw, err := plans.NewDynamicValue(backendConfig, backendConfig.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := &plans.Plan{
+		UIMode:             plans.NormalMode,
+		Changes:            plans.NewChanges(),
+		VariableValues:     map[string]plans.DynamicValue{},
+		ApplyTimeVariables: []string{"token"},
+		Backend: plans.Backend{
+			Type:   "local",
+			Config: backendConfigRaw,
+		},
+		PrevRunState: states.NewState(),
+		PriorState:   states.NewState(),
+	}
+
+	outDir := t.TempDir()
+	planPath := filepath.Join(outDir, "plan.tfplan")
+	planfileArgs := planfile.CreateArgs{
+		ConfigSnapshot:       snap,
+		PreviousRunStateFile: statefile.New(plan.PrevRunState, "", 0),
+		StateFile:            statefile.New(plan.PriorState, "", 0),
+		Plan:                 plan,
+		DependencyLocks:      depsfile.NewLocks(),
+	}
+	if err := planfile.Create(planPath, planfileArgs, encryption.PlanEncryptionDisabled()); err != nil {
+		t.Fatalf("unexpected error writing planfile: %s", err)
+	}
+
+	tests := map[string]struct {
+		vars    map[string]backend.UnparsedVariableValue
+		wantErr string
+	}{
+		"ephemeral variable set": {
+			vars: map[string]backend.UnparsedVariableValue{
+				"token": testUnparsedVariableValue{val: "secret", source: tofu.ValueFromCLIArg},
+			},
+		},
+		"ephemeral variable not set": {
+			vars:    map[string]backend.UnparsedVariableValue{},
+			wantErr: `The root module input variable "token" is ephemeral`,
+		},
+		"non-ephemeral variable set": {
+			vars: map[string]backend.UnparsedVariableValue{
+				"token": testUnparsedVariableValue{val: "secret", source: tofu.ValueFromCLIArg},
+				"name":  testUnparsedVariableValue{val: "bar", source: tofu.ValueFromCLIArg},
+			},
+			wantErr: "Can't set variables when applying a saved plan",
+		},
+		"non-ephemeral variable set from environment": {
+			vars: map[string]backend.UnparsedVariableValue{
+				"token": testUnparsedVariableValue{val: "secret", source: tofu.ValueFromCLIArg},
+				"name":  testUnparsedVariableValue{val: "bar", source: tofu.ValueFromEnvVar},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := TestLocal(t)
+			planFile, err := planfile.OpenWrapped(planPath, encryption.PlanEncryptionDisabled())
+			if err != nil {
+				t.Fatalf("unexpected error reading planfile: %s", err)
+			}
+
+			streams, _ := terminal.StreamsForTesting(t)
+			view := views.NewView(streams)
+			stateLocker := clistate.NewLocker(0, views.NewStateLocker(arguments.ViewHuman, view))
+
+			op := &backend.Operation{
+				ConfigDir:       configDir,
+				ConfigLoader:    configLoader,
+				PlanFile:        planFile,
+				Workspace:       backend.DefaultStateName,
+				StateLocker:     stateLocker,
+				Variables:       test.vars,
+				DependencyLocks: depsfile.NewLocks(),
+			}
+
+			lr, _, diags := b.LocalRun(context.Background(), op)
+			if test.wantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatal("unexpected success")
+				}
+				if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, test.wantErr)
+				}
+				assertBackendStateUnlocked(t, b)
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected error: %s", diags.Err())
+			}
+
+			raw, ok := lr.Plan.VariableValues["token"]
+			if !ok {
+				t.Fatal("no value for ephemeral variable in plan")
+			}
+			got, err := raw.Decode(cty.DynamicPseudoType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := cty.StringVal("secret"); !got.RawEquals(want) {
+				t.Fatalf("wrong value for ephemeral variable\ngot:  %#v\nwant: %#v", got, want)
+			}
+		})
+	}
+}
+
+type testUnparsedVariableValue struct {
+	val    string
+	source tofu.ValueSourceType
+}
+
+func (v testUnparsedVariableValue) ParseVariableValue(mode configs.VariableParsingMode) (*tofu.InputValue, tfdiags.Diagnostics) {
+	return &tofu.InputValue{
+		Value:      cty.StringVal(v.val),
+		SourceType: v.source,
+	}, nil
+}
+
 type backendWithStateStorageThatFailsRefresh struct {
 }
 
@@ -13,8 +13,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 )
@@ -32,6 +35,50 @@ func TestLocal_backend(t *testing.T) {
 	backend.TestBackendStateLocks(t, b, b)
 }
 
+func TestLocal_stateHistory(t *testing.T) {
+	testTmpDir(t)
+	config := backend.TestWrapConfig(map[string]interface{}{
+		"history_limit": 2,
+	})
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Local)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"one", "two", "three"} {
+		state := states.NewState()
+		state.RootModule().SetOutputValue("value", cty.StringVal(value), false)
+		if err := statemgr.WriteAndPersist(s, state, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	historian := s.(statemgr.Historian)
+	versions, err := historian.ListStateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Fatalf("expected only the newest version to be current: %#v", versions)
+	}
+
+	f, err := historian.GetStateVersion(versions[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.State.RootModule().OutputValues["value"].Value; !got.RawEquals(cty.StringVal("two")) {
+		t.Fatalf("unexpected value in version %s: %#v", versions[1].ID, got)
+	}
+
+	if _, err := os.Stat(DefaultStateFilename + ".history"); err != nil {
+		t.Fatalf("expected a history directory next to the state file: %s", err)
+	}
+}
+
 func checkState(t *testing.T, path, expected string) {
 	t.Helper()
 	// Read the state
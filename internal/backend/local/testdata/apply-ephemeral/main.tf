@@ -0,0 +1,9 @@
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+variable "name" {
+  type    = string
+  default = "foo"
+}
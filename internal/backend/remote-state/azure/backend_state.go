@@ -89,13 +89,18 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 	if err != nil {
 		return nil, err
 	}
+	containersClient, err := b.armClient.getContainersClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	client := &RemoteClient{
-		giovanniBlobClient: *blobClient,
-		containerName:      b.containerName,
-		keyName:            b.path(name),
-		accountName:        b.accountName,
-		snapshot:           b.snapshot,
+		giovanniBlobClient:       *blobClient,
+		giovanniContainersClient: *containersClient,
+		containerName:            b.containerName,
+		keyName:                  b.path(name),
+		accountName:              b.accountName,
+		snapshot:                 b.snapshot,
 	}
 
 	stateMgr := remote.NewState(client, b.encryption)
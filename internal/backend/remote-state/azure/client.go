@@ -12,27 +12,34 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-uuid"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/containers"
 )
 
 const (
 	leaseHeader = "x-ms-lease-id"
 	// Must be lower case
 	lockInfoMetaKey = "terraformlockid"
+	// currentVersionID is the state version ID of the blob itself, as
+	// opposed to its snapshots.
+	currentVersionID = "current"
 )
 
 type RemoteClient struct {
-	giovanniBlobClient blobs.Client
-	accountName        string
-	containerName      string
-	keyName            string
-	leaseID            string
-	snapshot           bool
+	giovanniBlobClient       blobs.Client
+	giovanniContainersClient containers.Client
+	accountName              string
+	containerName            string
+	keyName                  string
+	leaseID                  string
+	snapshot                 bool
 }
 
 func (c *RemoteClient) Get() (*remote.Payload, error) {
@@ -121,6 +128,101 @@ func (c *RemoteClient) Delete() error {
 	return nil
 }
 
+// ListVersions returns the blob and its snapshots, newest first. Snapshots
+// are only taken when the "snapshot" option is enabled, so they are the
+// earlier versions of the state. The blob versioning feature of storage
+// accounts is not supported by the storage API version used here.
+func (c *RemoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	ctx := context.TODO()
+	input := containers.ListBlobsInput{
+		Prefix:  &c.keyName,
+		Include: &[]containers.Dataset{containers.Snapshots},
+	}
+
+	var versions []statemgr.StateVersion
+	for {
+		resp, err := c.giovanniContainersClient.ListBlobs(ctx, c.accountName, c.containerName, input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing snapshots of Blob %q (Container %q / Account %q): %w", c.keyName, c.containerName, c.accountName, err)
+		}
+		for _, blob := range resp.Blobs.Blobs {
+			// The prefix also matches the state of other workspaces
+			if blob.Name != c.keyName || blob.Deleted {
+				continue
+			}
+			versions = append(versions, blobStateVersion(blob))
+		}
+		if resp.NextMarker == nil || *resp.NextMarker == "" {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Current != versions[j].Current {
+			return versions[i].Current
+		}
+		return versions[i].Created.After(versions[j].Created)
+	})
+	return versions, nil
+}
+
+func blobStateVersion(blob containers.BlobDetails) statemgr.StateVersion {
+	version := statemgr.StateVersion{ID: currentVersionID, Current: true}
+	if blob.Snapshot != nil {
+		// Snapshot IDs are the time the snapshot was taken
+		version = statemgr.StateVersion{ID: *blob.Snapshot}
+		version.Created, _ = time.Parse(time.RFC3339Nano, *blob.Snapshot)
+	}
+	if props := blob.Properties; props != nil {
+		if props.ContentLength != nil {
+			version.Size = *props.ContentLength
+		}
+		if version.Current && props.LastModified != nil {
+			version.Created, _ = time.Parse(time.RFC1123, *props.LastModified)
+		}
+	}
+	return version
+}
+
+// GetVersion reads the blob snapshot with the given ID. It returns nil if the
+// snapshot doesn't exist.
+func (c *RemoteClient) GetVersion(id string) (*remote.Payload, error) {
+	if id == currentVersionID {
+		return c.Get()
+	}
+
+	ctx := context.TODO()
+	req, err := c.giovanniBlobClient.GetPreparer(ctx, c.accountName, c.containerName, c.keyName, blobs.GetInput{})
+	if err != nil {
+		return nil, err
+	}
+	// The client doesn't support reading snapshots, so we add the snapshot
+	// to the request ourselves.
+	query := req.URL.Query()
+	query.Set("snapshot", id)
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := c.giovanniBlobClient.GetSender(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot %q of Blob %q (Container %q / Account %q): %w", id, c.keyName, c.containerName, c.accountName, err)
+	}
+	blob, err := c.giovanniBlobClient.GetResponder(resp)
+	if err != nil {
+		if blob.Response.IsHTTPStatus(http.StatusNotFound) || blob.Response.IsHTTPStatus(http.StatusBadRequest) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading snapshot %q of Blob %q (Container %q / Account %q): %w", id, c.keyName, c.containerName, c.accountName, err)
+	}
+	if len(blob.Contents) == 0 {
+		return nil, nil
+	}
+
+	return &remote.Payload{
+		Data: blob.Contents,
+	}, nil
+}
+
 func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 	stateName := fmt.Sprintf("%s/%s", c.containerName, c.keyName)
 	info.Path = stateName
@@ -0,0 +1,191 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package etcdv3
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	etcdv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
+)
+
+const (
+	// defaultMaxRequestBytes is the default limit of the size of requests
+	// of etcd servers.
+	defaultMaxRequestBytes = 1572864
+
+	// dialTimeout is how long to wait for a connection to the cluster
+	// when the backend is configured.
+	dialTimeout = 5 * time.Second
+)
+
+// New creates a new backend for etcd v3 remote state.
+func New(enc encryption.StateEncryption) backend.Backend {
+	s := &schema.Backend{
+		Schema: map[string]*schema.Schema{
+			"endpoints": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Required:    true,
+				Description: "Endpoints for the etcd cluster.",
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username used to connect to the etcd cluster.",
+				DefaultFunc: schema.EnvDefaultFunc("ETCDV3_USERNAME", ""),
+			},
+
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Password used to connect to the etcd cluster.",
+				DefaultFunc: schema.EnvDefaultFunc("ETCDV3_PASSWORD", ""),
+			},
+
+			"prefix": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The prefix of the keys that store the state of each workspace.",
+			},
+
+			"lock": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to lock state access.",
+				Default:     true,
+			},
+
+			"lock_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The TTL in seconds of the lease of a lock, after which the lock is released if the process holding it stopped renewing it.",
+				Default:     60,
+			},
+
+			"gzip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Compress the state data using gzip. Large states are always compressed.",
+				Default:     false,
+			},
+
+			"max_request_bytes": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum size of a request to the etcd cluster, which must not be larger than the limit of the servers. States that don't fit are compressed.",
+				Default:     defaultMaxRequestBytes,
+			},
+
+			"cacert_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM-encoded CA bundle with which to verify certificates of TLS-enabled etcd servers.",
+				Default:     "",
+			},
+
+			"cert_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM-encoded certificate to provide to etcd for secure client identification.",
+				Default:     "",
+			},
+
+			"key_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM-encoded key to provide to etcd for secure client identification.",
+				Default:     "",
+			},
+		},
+	}
+
+	result := &Backend{Backend: s, encryption: enc}
+	result.Backend.ConfigureFunc = result.configure
+	return result
+}
+
+type Backend struct {
+	*schema.Backend
+	encryption encryption.StateEncryption
+
+	// The fields below are set from configure
+	client          *etcdv3.Client
+	data            *schema.ResourceData
+	lock            bool
+	lockTTL         int
+	prefix          string
+	gzip            bool
+	maxRequestBytes int
+}
+
+func (b *Backend) configure(ctx context.Context) error {
+	// Grab the resource data
+	b.data = schema.FromContextBackendConfig(ctx)
+	data := b.data
+
+	// Store the lock information
+	b.lock = data.Get("lock").(bool)
+	b.lockTTL = data.Get("lock_ttl").(int)
+	if b.lockTTL <= 0 {
+		return fmt.Errorf("lock_ttl must be a positive number of seconds")
+	}
+
+	b.prefix = data.Get("prefix").(string)
+	if b.prefix == "" {
+		return fmt.Errorf("prefix must not be empty")
+	}
+	b.gzip = data.Get("gzip").(bool)
+	b.maxRequestBytes = data.Get("max_request_bytes").(int)
+	if b.maxRequestBytes <= requestOverhead {
+		return fmt.Errorf("max_request_bytes must be larger than %d", requestOverhead)
+	}
+
+	// Initialize a client to test config
+	var err error
+	b.client, err = b.rawClient()
+	return err
+}
+
+func (b *Backend) rawClient() (*etcdv3.Client, error) {
+	config := etcdv3.Config{
+		DialTimeout:        dialTimeout,
+		MaxCallSendMsgSize: b.maxRequestBytes,
+	}
+
+	for _, endpoint := range b.data.Get("endpoints").([]interface{}) {
+		config.Endpoints = append(config.Endpoints, endpoint.(string))
+	}
+	config.Username = b.data.Get("username").(string)
+	config.Password = b.data.Get("password").(string)
+
+	tlsInfo := transport.TLSInfo{
+		TrustedCAFile: b.data.Get("cacert_path").(string),
+		CertFile:      b.data.Get("cert_path").(string),
+		KeyFile:       b.data.Get("key_path").(string),
+	}
+	if !tlsInfo.Empty() || tlsInfo.TrustedCAFile != "" {
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the etcd TLS configuration: %w", err)
+		}
+		config.TLS = tlsConfig
+	}
+
+	client, err := etcdv3.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to etcd: %w", err)
+	}
+	return client, nil
+}
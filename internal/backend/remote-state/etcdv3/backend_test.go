@@ -0,0 +1,118 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package etcdv3
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+// testEtcdServer starts an embedded single node etcd cluster that is
+// stopped when the test finishes, and returns its client endpoints.
+func testEtcdServer(t *testing.T) []interface{} {
+	t.Helper()
+
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, peerURL := testFreeURL(t), testFreeURL(t)
+	cfg.ListenClientUrls = []url.URL{clientURL}
+	cfg.AdvertiseClientUrls = []url.URL{clientURL}
+	cfg.ListenPeerUrls = []url.URL{peerURL}
+	cfg.AdvertisePeerUrls = []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("failed to start etcd: %s", err)
+	}
+	t.Cleanup(server.Close)
+
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		t.Fatal("etcd did not become ready")
+	}
+
+	return []interface{}{clientURL.String()}
+}
+
+func testFreeURL(t *testing.T) url.URL {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return url.URL{Scheme: "http", Host: l.Addr().String()}
+}
+
+func testBackendConfig(endpoints []interface{}, prefix string) map[string]interface{} {
+	return map[string]interface{}{
+		"endpoints": endpoints,
+		"prefix":    prefix,
+	}
+}
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackend(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix)))
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix)))
+
+	// Test
+	backend.TestBackendStates(t, b1)
+	backend.TestBackendStateLocks(t, b1, b2)
+	backend.TestBackendStateForceUnlock(t, b1, b2)
+}
+
+func TestBackend_lockDisabled(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	config := testBackendConfig(endpoints, prefix)
+	config["lock"] = false
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
+
+	// Test
+	backend.TestBackendStates(t, b1)
+	backend.TestBackendStateLocks(t, b1, b2)
+}
+
+func TestBackend_prefixes(t *testing.T) {
+	endpoints := testEtcdServer(t)
+
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, "tofu-test/one/")))
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, "tofu-test/two/")))
+
+	if _, err := b1.StateMgr("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Workspaces are only listed by the backend with the same prefix
+	workspaces, err := b2.Workspaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workspaces) != 1 || workspaces[0] != backend.DefaultStateName {
+		t.Fatalf("expected only the default workspace, got %v", workspaces)
+	}
+}
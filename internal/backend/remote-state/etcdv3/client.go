@@ -0,0 +1,274 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package etcdv3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+
+	etcdv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+const (
+	lockSuffix = "/.lock"
+
+	// requestOverhead is the room left in a request for the key and the
+	// framing of the request, when deciding whether a state fits.
+	requestOverhead = 4096
+)
+
+// RemoteClient is a remote client that stores data in etcd.
+type RemoteClient struct {
+	Client          *etcdv3.Client
+	DoLock          bool
+	Key             string
+	GZip            bool
+	LockTTL         int64
+	MaxRequestBytes int
+
+	// The fields below are set while this client holds the lock
+	mu            sync.Mutex
+	lockID        string
+	leaseID       etcdv3.LeaseID
+	stopKeepAlive context.CancelFunc
+}
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	res, err := c.Client.Get(context.TODO(), c.Key)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Kvs) == 0 {
+		return nil, nil
+	}
+
+	payload := res.Kvs[0].Value
+	// If the payload starts with 0x1f, it's gzip, not json
+	if len(payload) >= 1 && payload[0] == '\x1f' {
+		payload, err = uncompressState(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	md5 := md5.Sum(payload)
+	return &remote.Payload{
+		Data: payload,
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	// The state is compressed when the user asked for it, or when it
+	// doesn't fit in a single request otherwise.
+	payload := data
+	limit := c.MaxRequestBytes - requestOverhead
+	if c.GZip || len(data) > limit {
+		compressed, err := compressState(data)
+		if err != nil {
+			return err
+		}
+		payload = compressed
+	}
+	if len(payload) > limit {
+		return fmt.Errorf("the state is %d bytes after compression, which is larger than the %d bytes that fit in a request with max_request_bytes = %d", len(payload), limit, c.MaxRequestBytes)
+	}
+
+	put := etcdv3.OpPut(c.Key, string(payload))
+
+	c.mu.Lock()
+	leaseID := c.leaseID
+	c.mu.Unlock()
+	if leaseID == etcdv3.NoLease {
+		_, err := c.Client.Do(context.TODO(), put)
+		return err
+	}
+
+	// We only write the state if we still hold the lock, which we could
+	// have lost if the lease expired while we weren't able to renew it.
+	res, err := c.Client.Txn(context.TODO()).
+		If(etcdv3.Compare(etcdv3.LeaseValue(c.lockKey()), "=", leaseID)).
+		Then(put).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !res.Succeeded {
+		return fmt.Errorf("the lock on %s was lost, the state may have been locked by someone else", c.Key)
+	}
+	return nil
+}
+
+func (c *RemoteClient) Delete() error {
+	_, err := c.Client.Delete(context.TODO(), c.Key)
+	return err
+}
+
+// Lock puts the lock info in the lock key if it doesn't exist, in a
+// transaction. The lock key is attached to a lease that is kept alive while
+// the lock is held, so the lock is released when the lease expires if this
+// process stops without unlocking.
+func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
+	if !c.DoLock {
+		return "", nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info.Path = c.Key
+
+	lease, err := c.Client.Grant(context.TODO(), c.LockTTL)
+	if err != nil {
+		return "", &statemgr.LockError{Info: info, Err: fmt.Errorf("failed to create a lease for the lock: %w", err)}
+	}
+
+	res, err := c.Client.Txn(context.TODO()).
+		If(etcdv3.Compare(etcdv3.CreateRevision(c.lockKey()), "=", 0)).
+		Then(etcdv3.OpPut(c.lockKey(), string(info.Marshal()), etcdv3.WithLease(lease.ID))).
+		Else(etcdv3.OpGet(c.lockKey())).
+		Commit()
+	if err != nil {
+		c.revokeLease(lease.ID)
+		return "", &statemgr.LockError{Info: info, Err: err}
+	}
+	if !res.Succeeded {
+		c.revokeLease(lease.ID)
+		lockErr := &statemgr.LockError{Err: fmt.Errorf("state is already locked: %s", c.Key)}
+		if kvs := res.Responses[0].GetResponseRange().GetKvs(); len(kvs) > 0 {
+			lockErr.Info, _ = unmarshalLockInfo(kvs[0].Value)
+		}
+		return "", lockErr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	keepAlive, err := c.Client.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		cancel()
+		c.revokeLease(lease.ID)
+		return "", &statemgr.LockError{Info: info, Err: fmt.Errorf("failed to keep the lease of the lock alive: %w", err)}
+	}
+	go func() {
+		// The responses must be consumed. The channel is closed when the
+		// keep alive stops.
+		for range keepAlive {
+		}
+	}()
+
+	c.lockID = info.ID
+	c.leaseID = lease.ID
+	c.stopKeepAlive = cancel
+	return info.ID, nil
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	if !c.DoLock {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lockErr := &statemgr.LockError{}
+
+	res, err := c.Client.Get(context.TODO(), c.lockKey())
+	if err != nil {
+		lockErr.Err = err
+		return lockErr
+	}
+	if len(res.Kvs) == 0 {
+		lockErr.Err = fmt.Errorf("state is not locked: %s", c.Key)
+		return lockErr
+	}
+	kv := res.Kvs[0]
+
+	lockErr.Info, err = unmarshalLockInfo(kv.Value)
+	if err != nil {
+		lockErr.Err = err
+		return lockErr
+	}
+	if lockErr.Info.ID != id {
+		lockErr.Err = fmt.Errorf("lock id %q does not match existing lock", id)
+		return lockErr
+	}
+
+	txn, err := c.Client.Txn(context.TODO()).
+		If(etcdv3.Compare(etcdv3.ModRevision(c.lockKey()), "=", kv.ModRevision)).
+		Then(etcdv3.OpDelete(c.lockKey())).
+		Commit()
+	if err != nil {
+		lockErr.Err = err
+		return lockErr
+	}
+	if !txn.Succeeded {
+		lockErr.Err = fmt.Errorf("the lock changed while unlocking")
+		return lockErr
+	}
+
+	// The lease isn't needed anymore, whether it was ours or it belongs to
+	// the process that we are force-unlocking.
+	if c.lockID == id {
+		c.stopKeepAlive()
+		c.lockID, c.leaseID, c.stopKeepAlive = "", etcdv3.NoLease, nil
+	}
+	c.revokeLease(etcdv3.LeaseID(kv.Lease))
+
+	return nil
+}
+
+func (c *RemoteClient) lockKey() string {
+	return c.Key + lockSuffix
+}
+
+// revokeLease revokes the given lease, which deletes the keys attached to it.
+// Failing to do so isn't an error, since the lease expires anyway.
+func (c *RemoteClient) revokeLease(id etcdv3.LeaseID) {
+	if id == etcdv3.NoLease {
+		return
+	}
+	if _, err := c.Client.Revoke(context.TODO(), id); err != nil {
+		log.Printf("[WARN] failed to revoke etcd lease %x: %s", id, err)
+	}
+}
+
+func unmarshalLockInfo(data []byte) (*statemgr.LockInfo, error) {
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("failed to read the lock info: %w", err)
+	}
+	return info, nil
+}
+
+func compressState(data []byte) ([]byte, error) {
+	b := new(bytes.Buffer)
+	gz := gzip.NewWriter(b)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func uncompressState(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package etcdv3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestRemoteClient_impl(t *testing.T) {
+	var _ remote.Client = new(RemoteClient)
+	var _ remote.ClientLocker = new(RemoteClient)
+}
+
+func TestRemoteClient(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	// Get the backend
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix)))
+
+	// Grab the client
+	state, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("Error: %s.", err)
+	}
+
+	// Test
+	remote.TestClient(t, state.(*remote.State).Client)
+}
+
+func TestRemoteClient_gzip(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	config := testBackendConfig(endpoints, prefix)
+	config["gzip"] = true
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
+
+	state, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("Error: %s.", err)
+	}
+
+	// Test
+	remote.TestClient(t, state.(*remote.State).Client)
+}
+
+func TestRemoteClient_largeState(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix))).(*Backend)
+
+	state, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("Error: %s.", err)
+	}
+	client := state.(*remote.State).Client.(*RemoteClient)
+
+	// A state larger than a request is compressed, even though gzip is off
+	data := []byte(fmt.Sprintf(`{"padding": %q}`, strings.Repeat("tofu", defaultMaxRequestBytes)))
+	if err := client.Put(data); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := b.client.Get(context.Background(), client.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored := res.Kvs[0].Value; len(stored) >= len(data) || stored[0] != '\x1f' {
+		t.Fatalf("expected the state to be stored compressed, got %d bytes", len(stored))
+	}
+
+	payload, err := client.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(payload.Data, data) {
+		t.Fatal("the state read back doesn't match the state written")
+	}
+}
+
+func TestRemoteClient_stateLock(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix)))
+	s1, err := b1.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(endpoints, prefix)))
+	s2, err := b2.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote.TestRemoteLocks(t, s1.(*remote.State).Client, s2.(*remote.State).Client)
+}
+
+func TestRemoteClient_lockExpires(t *testing.T) {
+	endpoints := testEtcdServer(t)
+	prefix := fmt.Sprintf("%s/%s/", "tofu-test", t.Name())
+
+	config := testBackendConfig(endpoints, prefix)
+	config["lock_ttl"] = 2
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
+	s1, err := b1.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1 := s1.(*remote.State).Client.(*RemoteClient)
+
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
+	s2, err := b2.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2 := s2.(*remote.State).Client.(*RemoteClient)
+
+	info := statemgr.NewLockInfo()
+	info.Operation = "test"
+	if _, err := c1.Lock(info); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lease is kept alive while the lock is held
+	time.Sleep(3 * time.Second)
+	if _, err := c2.Lock(statemgr.NewLockInfo()); err == nil {
+		t.Fatal("expected the lock to still be held")
+	}
+	if err := c1.Put([]byte("still locked")); err != nil {
+		t.Fatalf("unexpected error writing while holding the lock: %s", err)
+	}
+
+	// Simulate the first process being killed, so the lease expires
+	c1.stopKeepAlive()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err := c2.Lock(statemgr.NewLockInfo())
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the lock did not expire: %s", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	// The first process lost the lock, so it must not write the state
+	if err := c1.Put([]byte("lost lock")); err == nil || !strings.Contains(err.Error(), "lock on") {
+		t.Fatalf("expected an error writing after losing the lock, got %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 
 	"cloud.google.com/go/storage"
@@ -17,11 +18,12 @@ import (
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"golang.org/x/net/context"
+	"google.golang.org/api/iterator"
 )
 
 // remoteClient is used by "state/remote".State to read and write
 // blobs representing state.
-// Implements "state/remote".ClientLocker
+// Implements "state/remote".ClientLocker and "state/remote".ClientHistorian
 type remoteClient struct {
 	storageContext context.Context
 	storageClient  *storage.Client
@@ -87,6 +89,89 @@ func (c *remoteClient) Delete() error {
 	return nil
 }
 
+// ListVersions returns the generations of the state file that are kept in
+// the bucket, newest first. Earlier generations are only kept when object
+// versioning is enabled on the bucket.
+func (c *remoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	query := &storage.Query{Prefix: c.stateFilePath, Versions: true}
+	if err := query.SetAttrSelection([]string{"Name", "Generation", "Created", "Size", "Deleted"}); err != nil {
+		return nil, err
+	}
+
+	type generation struct {
+		number  int64
+		version statemgr.StateVersion
+	}
+	var generations []generation
+	it := c.storageClient.Bucket(c.bucketName).Objects(c.storageContext, query)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list generations of %v: %w", c.stateFileURL(), err)
+		}
+		// The prefix also matches the state of other workspaces
+		if attrs.Name != c.stateFilePath {
+			continue
+		}
+		generations = append(generations, generation{
+			number: attrs.Generation,
+			version: statemgr.StateVersion{
+				ID:      strconv.FormatInt(attrs.Generation, 10),
+				Created: attrs.Created,
+				Size:    attrs.Size,
+				// Noncurrent generations have the time they were replaced
+				Current: attrs.Deleted.IsZero(),
+			},
+		})
+	}
+
+	sort.Slice(generations, func(i, j int) bool {
+		return generations[i].number > generations[j].number
+	})
+	versions := make([]statemgr.StateVersion, len(generations))
+	for i, g := range generations {
+		versions[i] = g.version
+	}
+	return versions, nil
+}
+
+// GetVersion reads the given generation of the state file. It returns nil
+// if the generation doesn't exist.
+func (c *remoteClient) GetVersion(id string) (*remote.Payload, error) {
+	gen, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("State version ID should be a generation number, got %q", id)
+	}
+
+	obj := c.stateFile().Generation(gen)
+	r, err := obj.NewReader(c.storageContext)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to open generation %d of state file at %v: %w", gen, c.stateFileURL(), err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read generation %d of state file from %v: %w", gen, c.stateFileURL(), err)
+	}
+
+	attrs, err := obj.Attrs(c.storageContext)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read generation %d of state file attrs from %v: %w", gen, c.stateFileURL(), err)
+	}
+
+	return &remote.Payload{
+		Data: data,
+		MD5:  attrs.MD5,
+	}, nil
+}
+
 // Lock writes to a lock file, ensuring file creation. Returns the generation
 // number, which must be passed to Unlock().
 func (c *remoteClient) Lock(info *statemgr.LockInfo) (string, error) {
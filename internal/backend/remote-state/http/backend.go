@@ -55,6 +55,12 @@ func New(enc encryption.StateEncryption) backend.Backend {
 				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_UNLOCK_ADDRESS", nil),
 				Description: "The address of the unlock REST endpoint",
 			},
+			"history_address": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_HISTORY_ADDRESS", nil),
+				Description: "The address of the REST endpoint that lists and returns earlier versions of the state",
+			},
 			"lock_method": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -248,6 +254,18 @@ func (b *Backend) configure(ctx context.Context) error {
 
 	unlockMethod := data.Get("unlock_method").(string)
 
+	var historyURL *url.URL
+	if v, ok := data.GetOk("history_address"); ok && v.(string) != "" {
+		var err error
+		historyURL, err = url.Parse(v.(string))
+		if err != nil {
+			return fmt.Errorf("failed to parse historyAddress URL: %w", err)
+		}
+		if historyURL.Scheme != "http" && historyURL.Scheme != "https" {
+			return fmt.Errorf("historyAddress must be HTTP or HTTPS")
+		}
+	}
+
 	username := data.Get("username").(string)
 	password := data.Get("password").(string)
 
@@ -293,6 +311,8 @@ func (b *Backend) configure(ctx context.Context) error {
 		UnlockURL:    unlockURL,
 		UnlockMethod: unlockMethod,
 
+		HistoryURL: historyURL,
+
 		Headers:  headers,
 		Username: username,
 		Password: password,
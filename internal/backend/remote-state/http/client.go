@@ -15,6 +15,9 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/opentofu/opentofu/internal/states/remote"
@@ -33,6 +36,9 @@ type httpClient struct {
 	UnlockURL    *url.URL
 	UnlockMethod string
 
+	// History
+	HistoryURL *url.URL
+
 	// HTTP
 	Client   *retryablehttp.Client
 	Headers  map[string]string
@@ -41,6 +47,13 @@ type httpClient struct {
 
 	lockID       string
 	jsonLockInfo []byte
+
+	// Lock lease, when the lock endpoint grants one
+	leaseMu      sync.Mutex
+	leaseExpires time.Time
+	leaseErr     error
+	leaseStop    chan struct{}
+	leaseDone    chan struct{}
 }
 
 func (c *httpClient) httpRequest(method string, url *url.URL, data []byte, what string) (*http.Response, error) {
@@ -93,6 +106,7 @@ func (c *httpClient) Lock(info *statemgr.LockInfo) (string, error) {
 	if c.LockURL == nil {
 		return "", nil
 	}
+	c.stopLeaseRenewal()
 	c.lockID = ""
 
 	jsonLockInfo := info.Marshal()
@@ -106,6 +120,11 @@ func (c *httpClient) Lock(info *statemgr.LockInfo) (string, error) {
 	case http.StatusOK:
 		c.lockID = info.ID
 		c.jsonLockInfo = jsonLockInfo
+		if ttl := parseLeaseTTL(resp); ttl > 0 {
+			expires := c.startLeaseRenewal(ttl)
+			info.LeaseTTL = ttl
+			info.LeaseExpires = &expires
+		}
 		return info.ID, nil
 	case http.StatusUnauthorized:
 		log.Printf("[DEBUG] LOCK, Unauthorized: %s", parseResponseBodyForLog(resp))
@@ -140,6 +159,7 @@ func (c *httpClient) Lock(info *statemgr.LockInfo) (string, error) {
 }
 
 func (c *httpClient) Unlock(id string) error {
+	c.stopLeaseRenewal()
 	if c.UnlockURL == nil {
 		return nil
 	}
@@ -159,6 +179,118 @@ func (c *httpClient) Unlock(id string) error {
 	}
 }
 
+// parseLeaseTTL returns the lease TTL in seconds that the lock endpoint
+// granted in the body of a successful lock response, or zero if the lock
+// has no lease. The body is the lock info with the LeaseTTL field set, but
+// other bodies are accepted, because the lock endpoint could always return
+// anything on success.
+func parseLeaseTTL(resp *http.Response) int {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return 0
+	}
+	var granted statemgr.LockInfo
+	if err := json.Unmarshal(body, &granted); err != nil {
+		log.Printf("[DEBUG] LOCK, ignoring response body that is not lock info: %s", err)
+		return 0
+	}
+	return max(granted.LeaseTTL, 0)
+}
+
+// startLeaseRenewal starts renewing the lease on the lock in the background,
+// and returns the time the lease expires if it isn't renewed.
+func (c *httpClient) startLeaseRenewal(ttl int) time.Time {
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+
+	c.leaseExpires = time.Now().Add(time.Duration(ttl) * time.Second)
+	c.leaseErr = nil
+	c.leaseStop = make(chan struct{})
+	c.leaseDone = make(chan struct{})
+	go c.renewLease(ttl, c.leaseStop, c.leaseDone)
+	return c.leaseExpires
+}
+
+// stopLeaseRenewal stops the background renewal of the lease, if it is
+// running, and waits for it to finish.
+func (c *httpClient) stopLeaseRenewal() {
+	c.leaseMu.Lock()
+	stop, done := c.leaseStop, c.leaseDone
+	c.leaseStop, c.leaseDone = nil, nil
+	c.leaseExpires = time.Time{}
+	c.leaseErr = nil
+	c.leaseMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// renewLease sends the lock request again at half of the lease TTL, which
+// the lock endpoint takes as a renewal because the lock ID is the same,
+// until stop is closed.
+func (c *httpClient) renewLease(ttl int, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		timer := time.NewTimer(leaseRenewInterval(ttl))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		resp, err := c.httpRequest(c.LockMethod, c.LockURL, c.jsonLockInfo, "renew lock")
+		if err != nil {
+			log.Printf("[WARN] Failed to renew the HTTP remote state lock lease: %s", err)
+			continue
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			if renewed := parseLeaseTTL(resp); renewed > 0 {
+				ttl = renewed
+			}
+			c.leaseMu.Lock()
+			c.leaseExpires = time.Now().Add(time.Duration(ttl) * time.Second)
+			c.leaseMu.Unlock()
+		case http.StatusConflict, http.StatusLocked:
+			log.Printf("[ERROR] HTTP remote state lock lease was lost: %s", parseResponseBodyForLog(resp))
+			c.leaseMu.Lock()
+			c.leaseErr = fmt.Errorf("HTTP remote state lock lease was lost, the state may have been locked by someone else")
+			c.leaseMu.Unlock()
+			resp.Body.Close()
+			return
+		default:
+			log.Printf("[WARN] RENEW LOCK, %d: %s", resp.StatusCode, parseResponseBodyForLog(resp))
+		}
+		resp.Body.Close()
+	}
+}
+
+// leaseRenewInterval returns how long to wait before renewing a lease with
+// the given TTL in seconds.
+func leaseRenewInterval(ttl int) time.Duration {
+	return time.Duration(ttl) * time.Second / 2
+}
+
+// leaseError returns an error if the lock has a lease that was lost or has
+// expired.
+func (c *httpClient) leaseError() error {
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+
+	if c.leaseErr != nil {
+		return c.leaseErr
+	}
+	if !c.leaseExpires.IsZero() && time.Now().After(c.leaseExpires) {
+		return fmt.Errorf("HTTP remote state lock lease expired at %s, the state may have been locked by someone else", c.leaseExpires.Format(time.RFC3339))
+	}
+	return nil
+}
+
 func (c *httpClient) Get() (*remote.Payload, error) {
 	resp, err := c.httpRequest(http.MethodGet, c.URL, nil, "get state")
 	if err != nil {
@@ -223,6 +355,12 @@ func (c *httpClient) Get() (*remote.Payload, error) {
 }
 
 func (c *httpClient) Put(data []byte) error {
+	// Don't overwrite the state if our lock may have been given to someone
+	// else in the meantime.
+	if err := c.leaseError(); err != nil {
+		return err
+	}
+
 	// Copy the target URL
 	base := *c.URL
 
@@ -279,6 +417,106 @@ func (c *httpClient) Delete() error {
 	}
 }
 
+// historyVersion is an entry of the list returned by the history endpoint.
+type historyVersion struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+	Size    int64     `json:"size"`
+	Current bool      `json:"current"`
+}
+
+// ListVersions returns the versions of the state listed by the history
+// endpoint, newest first.
+func (c *httpClient) ListVersions() ([]statemgr.StateVersion, error) {
+	if c.HistoryURL == nil {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	resp, err := c.httpRequest(http.MethodGet, c.HistoryURL, nil, "list state versions")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Handled after
+	case http.StatusUnauthorized:
+		log.Printf("[DEBUG] LIST STATE VERSIONS, Unauthorized: %s", parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		log.Printf("[DEBUG] LIST STATE VERSIONS, Forbidden: %s", parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("HTTP remote state endpoint invalid auth")
+	default:
+		log.Printf("[DEBUG] LIST STATE VERSIONS, %d: %s", resp.StatusCode, parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+
+	var list []historyVersion
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("Failed to decode state versions: %w", err)
+	}
+
+	versions := make([]statemgr.StateVersion, len(list))
+	for i, v := range list {
+		versions[i] = statemgr.StateVersion(v)
+	}
+	return versions, nil
+}
+
+// GetVersion reads the version of the state with the given ID from the
+// history endpoint. It returns nil if the version doesn't exist.
+func (c *httpClient) GetVersion(id string) (*remote.Payload, error) {
+	if c.HistoryURL == nil {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	resp, err := c.httpRequest(http.MethodGet, c.historyVersionURL(id), nil, "get state version")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Handled after
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusUnauthorized:
+		log.Printf("[DEBUG] GET STATE VERSION, Unauthorized: %s", parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		log.Printf("[DEBUG] GET STATE VERSION, Forbidden: %s", parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("HTTP remote state endpoint invalid auth")
+	default:
+		log.Printf("[DEBUG] GET STATE VERSION, %d: %s", resp.StatusCode, parseResponseBodyForLog(resp))
+		return nil, fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read state version: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+// historyVersionURL returns the address of the given version of the state,
+// which is the history address with the escaped version ID appended.
+func (c *httpClient) historyVersionURL(id string) *url.URL {
+	u := *c.HistoryURL
+	u.RawPath = strings.TrimSuffix(c.HistoryURL.EscapedPath(), "/") + "/" + url.PathEscape(id)
+	u.Path = strings.TrimSuffix(c.HistoryURL.Path, "/") + "/" + id
+	return &u
+}
+
 func (c *httpClient) IsLockingEnabled() bool {
 	return c.UnlockURL != nil
 }
@@ -13,6 +13,8 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 func TestHTTPClient_impl(t *testing.T) {
 	var _ remote.Client = new(httpClient)
 	var _ remote.ClientLocker = new(httpClient)
+	var _ remote.ClientHistorian = new(httpClient)
 }
 
 func TestHTTPClient(t *testing.T) {
@@ -357,3 +360,130 @@ func TestHttpClient_lock(t *testing.T) {
 		})
 	}
 }
+
+// Tests that the state is not written after the lease on the lock is lost.
+func TestHttpClient_lockLeaseLost(t *testing.T) {
+	var locks atomic.Int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "LOCK":
+			if locks.Add(1) > 1 {
+				// Someone else took the lock after our lease expired
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			info := statemgr.LockInfo{ID: "lease-lock-id", LeaseTTL: 1}
+			_, _ = w.Write(info.Marshal())
+		case http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		case "UNLOCK":
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &httpClient{
+		URL:          u,
+		LockURL:      u,
+		LockMethod:   "LOCK",
+		UnlockURL:    u,
+		UnlockMethod: "UNLOCK",
+		Client:       retryablehttp.NewClient(),
+	}
+
+	info := &statemgr.LockInfo{ID: "lease-lock-id"}
+	id, err := client.Lock(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.LeaseTTL != 1 || info.LeaseExpires == nil {
+		t.Fatalf("expected the lease to be set on the lock info: %#v", info)
+	}
+	if err := client.Put([]byte("state")); err != nil {
+		t.Fatalf("unexpected error writing with a valid lease: %s", err)
+	}
+
+	// Wait for the renewal to fail
+	time.Sleep(leaseRenewInterval(1) + 200*time.Millisecond)
+	if err := client.Put([]byte("state")); err == nil {
+		t.Fatal("expected an error writing after the lease was lost")
+	} else if !strings.Contains(err.Error(), "lease was lost") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := client.Unlock(id); err != nil {
+		t.Fatal(err)
+	}
+	// The lease is cleared when unlocking
+	if err := client.Put([]byte("state")); err != nil {
+		t.Fatalf("unexpected error writing after unlock: %s", err)
+	}
+}
+
+// Tests listing and reading earlier versions of the state from the history
+// endpoint.
+func TestHttpClient_history(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/history", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": "v/2", "created": "2024-01-02T00:00:00Z", "size": 3, "current": true},
+			{"id": "v/1", "created": "2024-01-01T00:00:00Z", "size": 3}
+		]`))
+	})
+	mux.HandleFunc("/history/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/history/v%2F1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("one"))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	historyURL, err := url.Parse(ts.URL + "/history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &httpClient{
+		HistoryURL: historyURL,
+		Client:     retryablehttp.NewClient(),
+	}
+
+	versions, err := client.ListVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []statemgr.StateVersion{
+		{ID: "v/2", Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Size: 3, Current: true},
+		{ID: "v/1", Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Size: 3},
+	}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("unexpected versions\ngot:  %#v\nwant: %#v", versions, expected)
+	}
+
+	payload, err := client.GetVersion("v/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload == nil || string(payload.Data) != "one" {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+
+	payload, err = client.GetVersion("v/3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != nil {
+		t.Fatalf("expected no payload for a missing version, got %#v", payload)
+	}
+
+	client.HistoryURL = nil
+	if _, err := client.ListVersions(); err != statemgr.ErrHistoryUnsupported {
+		t.Fatalf("expected ErrHistoryUnsupported without a history address, got %v", err)
+	}
+}
@@ -24,8 +24,10 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -33,6 +35,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -64,6 +67,10 @@ type (
 		locks map[string]string
 		lock  sync.RWMutex
 
+		// leaseTTL is the lease in seconds granted on locks, if not zero
+		leaseTTL int
+		leases   map[string]time.Time
+
 		httpServerCallback HttpServerCallback
 	}
 	httpServerOpt func(*httpServer)
@@ -75,12 +82,22 @@ func withHttpServerCallback(callback HttpServerCallback) httpServerOpt {
 	}
 }
 
+// withLockLease makes the server grant leases with the given TTL in seconds
+// on locks, which expire unless they are renewed by locking again with the
+// same lock ID.
+func withLockLease(ttl int) httpServerOpt {
+	return func(s *httpServer) {
+		s.leaseTTL = ttl
+	}
+}
+
 func newHttpServer(opts ...httpServerOpt) *httpServer {
 	r := http.NewServeMux()
 	s := &httpServer{
-		r:     r,
-		data:  make(map[string]string),
-		locks: make(map[string]string),
+		r:      r,
+		data:   make(map[string]string),
+		locks:  make(map[string]string),
+		leases: make(map[string]time.Time),
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -179,13 +196,42 @@ func (h *httpServer) handleStateLOCK(writer http.ResponseWriter, req *http.Reque
 	h.lock.Lock()
 	defer h.lock.Unlock()
 
-	if existingLock, ok := h.locks[resource]; ok {
+	if expires, ok := h.leases[resource]; ok && time.Now().After(expires) {
+		delete(h.locks, resource)
+		delete(h.leases, resource)
+	}
+
+	existingLock, ok := h.locks[resource]
+	if ok && (h.leaseTTL == 0 || lockID(existingLock) != lockID(string(data))) {
 		writer.WriteHeader(http.StatusLocked)
 		_, _ = io.WriteString(writer, existingLock)
-	} else {
+		return
+	}
+	if h.leaseTTL == 0 {
 		h.locks[resource] = string(data)
 		_, _ = io.WriteString(writer, existingLock)
+		return
+	}
+
+	// Grant a new lease, or renew the lease of our own lock
+	var lockInfo statemgr.LockInfo
+	if err := json.Unmarshal(data, &lockInfo); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
 	}
+	expires := time.Now().Add(time.Duration(h.leaseTTL) * time.Second)
+	lockInfo.LeaseTTL = h.leaseTTL
+	lockInfo.LeaseExpires = &expires
+	h.locks[resource] = string(lockInfo.Marshal())
+	h.leases[resource] = expires
+	_, _ = writer.Write(lockInfo.Marshal())
+}
+
+// lockID returns the ID of the given JSON lock info.
+func lockID(lockInfo string) string {
+	var info statemgr.LockInfo
+	_ = json.Unmarshal([]byte(lockInfo), &info)
+	return info.ID
 }
 
 func (h *httpServer) handleStateUNLOCK(writer http.ResponseWriter, req *http.Request) {
@@ -222,6 +268,7 @@ func (h *httpServer) handleStateUNLOCK(writer http.ResponseWriter, req *http.Req
 			_, _ = io.WriteString(writer, existingLock)
 		} else {
 			delete(h.locks, resource)
+			delete(h.leases, resource)
 			_, _ = io.WriteString(writer, existingLock)
 		}
 	} else {
@@ -420,6 +467,94 @@ func TestMTLSServer_WithCertPasses(t *testing.T) {
 	}
 }
 
+func TestMTLSServer_LockLease(t *testing.T) {
+	// Ensure that the lock is renewed while it is held, and not after
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCallback := NewMockHttpServerCallback(ctrl)
+
+	mockCallback.EXPECT().
+		StateGET(gomock.Any()).
+		AnyTimes()
+	mockCallback.EXPECT().
+		StatePOST(gomock.Any())
+	// The first lock and at least two renewals
+	var locks atomic.Int32
+	mockCallback.EXPECT().
+		StateLOCK(gomock.Any()).
+		MinTimes(3).
+		Do(func(*http.Request) { locks.Add(1) })
+	mockCallback.EXPECT().
+		StateUNLOCK(gomock.Any())
+
+	ts, err := NewHttpTestServer(withHttpServerCallback(mockCallback), withLockLease(1))
+	if err != nil {
+		t.Fatalf("unexpected error creating test server: %v", err)
+	}
+	defer ts.Close()
+
+	url := ts.URL + "/state/sample"
+	caData, err := os.ReadFile("testdata/certs/ca.cert.pem")
+	if err != nil {
+		t.Fatalf("error reading ca certs: %v", err)
+	}
+	clientCertData, err := os.ReadFile("testdata/certs/client.crt")
+	if err != nil {
+		t.Fatalf("error reading client cert: %v", err)
+	}
+	clientKeyData, err := os.ReadFile("testdata/certs/client.key")
+	if err != nil {
+		t.Fatalf("error reading client key: %v", err)
+	}
+	conf := map[string]cty.Value{
+		"address":                   cty.StringVal(url),
+		"lock_address":              cty.StringVal(url),
+		"unlock_address":            cty.StringVal(url),
+		"client_ca_certificate_pem": cty.StringVal(string(caData)),
+		"client_certificate_pem":    cty.StringVal(string(clientCertData)),
+		"client_private_key_pem":    cty.StringVal(string(clientKeyData)),
+	}
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), configs.SynthBody("synth", conf)).(*Backend)
+
+	sm, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("unexpected error fetching StateMgr with %s: %v", backend.DefaultStateName, err)
+	}
+
+	info := statemgr.NewLockInfo()
+	id, err := sm.Lock(info)
+	if err != nil {
+		t.Fatalf("unexpected error locking: %v", err)
+	}
+	if info.LeaseTTL != 1 || info.LeaseExpires == nil {
+		t.Fatalf("expected a lease of 1s on the lock, got %d", info.LeaseTTL)
+	}
+
+	// The lease would have expired twice by now if it wasn't renewed
+	time.Sleep(2200 * time.Millisecond)
+
+	if err = sm.RefreshState(); err != nil {
+		t.Fatalf("unexpected error calling RefreshState: %v", err)
+	}
+	if err = sm.WriteState(states.NewState()); err != nil {
+		t.Fatalf("error writing state: %v", err)
+	}
+	if err = sm.PersistState(nil); err != nil {
+		t.Fatalf("error persisting state with a renewed lease: %v", err)
+	}
+
+	if err = sm.Unlock(id); err != nil {
+		t.Fatalf("unexpected error unlocking: %v", err)
+	}
+
+	// No more renewals after the lock is released
+	renewed := locks.Load()
+	time.Sleep(700 * time.Millisecond)
+	if got := locks.Load(); got != renewed {
+		t.Fatalf("expected no renewals after unlocking, got %d", got-renewed)
+	}
+}
+
 // TestRunServer allows running the server for local debugging; it runs until ctl-c is received
 func TestRunServer(t *testing.T) {
 	if _, ok := os.LookupEnv("TEST_RUN_SERVER"); !ok {
@@ -7,6 +7,7 @@ package inmem
 
 import (
 	"crypto/md5"
+	"strconv"
 
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -17,6 +18,10 @@ type RemoteClient struct {
 	Data []byte
 	MD5  []byte
 	Name string
+
+	// history holds every payload written by Put since the last Delete,
+	// oldest first.
+	history [][]byte
 }
 
 func (c *RemoteClient) Get() (*remote.Payload, error) {
@@ -35,15 +40,45 @@ func (c *RemoteClient) Put(data []byte) error {
 
 	c.Data = data
 	c.MD5 = md5[:]
+	c.history = append(c.history, data)
 	return nil
 }
 
 func (c *RemoteClient) Delete() error {
 	c.Data = nil
 	c.MD5 = nil
+	c.history = nil
 	return nil
 }
 
+// ListVersions returns every payload written since the last Delete, newest
+// first. The version IDs are sequence numbers starting at 1.
+func (c *RemoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	versions := make([]statemgr.StateVersion, 0, len(c.history))
+	for i := len(c.history) - 1; i >= 0; i-- {
+		versions = append(versions, statemgr.StateVersion{
+			ID:      strconv.Itoa(i + 1),
+			Size:    int64(len(c.history[i])),
+			Current: i == len(c.history)-1,
+		})
+	}
+	return versions, nil
+}
+
+func (c *RemoteClient) GetVersion(id string) (*remote.Payload, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil || i < 1 || i > len(c.history) {
+		return nil, nil
+	}
+
+	data := c.history[i-1]
+	md5 := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  md5[:],
+	}, nil
+}
+
 func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 	return locks.lock(c.Name, info)
 }
@@ -17,6 +17,7 @@ import (
 func TestRemoteClient_impl(t *testing.T) {
 	var _ remote.Client = new(RemoteClient)
 	var _ remote.ClientLocker = new(RemoteClient)
+	var _ remote.ClientHistorian = new(RemoteClient)
 }
 
 func TestRemoteClient(t *testing.T) {
@@ -19,8 +19,10 @@ import (
 )
 
 const (
-	statesTableName = "states"
-	statesIndexName = "states_by_name"
+	statesTableName        = "states"
+	statesIndexName        = "states_by_name"
+	statesHistoryTableName = "states_history"
+	statesHistoryIndexName = "states_history_by_name"
 )
 
 func defaultBoolFunc(k string, dv bool) schema.SchemaDefaultFunc {
@@ -71,6 +73,13 @@ func New(enc encryption.StateEncryption) backend.Backend {
 				Description: "If set to `true`, OpenTofu won't try to create the Postgres index",
 				DefaultFunc: defaultBoolFunc("PG_SKIP_INDEX_CREATION", false),
 			},
+
+			"history_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of earlier versions of each state to keep in the database. Set to 0 to disable keeping earlier versions",
+				Default:     0,
+			},
 		},
 	}
 
@@ -84,10 +93,11 @@ type Backend struct {
 	encryption encryption.StateEncryption
 
 	// The fields below are set from configure
-	db         *sql.DB
-	configData *schema.ResourceData
-	connStr    string
-	schemaName string
+	db           *sql.DB
+	configData   *schema.ResourceData
+	connStr      string
+	schemaName   string
+	historyLimit int
 }
 
 func (b *Backend) configure(ctx context.Context) error {
@@ -97,6 +107,10 @@ func (b *Backend) configure(ctx context.Context) error {
 
 	b.connStr = data.Get("conn_str").(string)
 	b.schemaName = pq.QuoteIdentifier(data.Get("schema_name").(string))
+	b.historyLimit = data.Get("history_limit").(int)
+	if b.historyLimit < 0 {
+		return fmt.Errorf("history_limit must not be negative")
+	}
 
 	db, err := sql.Open("postgres", b.connStr)
 	if err != nil {
@@ -139,6 +153,18 @@ func (b *Backend) configure(ctx context.Context) error {
 		if _, err := db.Exec(fmt.Sprintf(query, b.schemaName, statesTableName)); err != nil {
 			return err
 		}
+
+		if b.historyLimit > 0 {
+			query = `CREATE TABLE IF NOT EXISTS %s.%s (
+				id bigserial PRIMARY KEY,
+				name text NOT NULL,
+				data text,
+				created_at timestamptz NOT NULL DEFAULT now()
+				)`
+			if _, err := db.Exec(fmt.Sprintf(query, b.schemaName, statesHistoryTableName)); err != nil {
+				return err
+			}
+		}
 	}
 
 	if !data.Get("skip_index_creation").(bool) {
@@ -146,6 +172,13 @@ func (b *Backend) configure(ctx context.Context) error {
 		if _, err := db.Exec(fmt.Sprintf(query, statesIndexName, b.schemaName, statesTableName)); err != nil {
 			return err
 		}
+
+		if b.historyLimit > 0 {
+			query = `CREATE INDEX IF NOT EXISTS %s ON %s.%s (name, id)`
+			if _, err := db.Exec(fmt.Sprintf(query, statesHistoryIndexName, b.schemaName, statesHistoryTableName)); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Assign db after its schema is prepared.
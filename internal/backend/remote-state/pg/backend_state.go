@@ -51,6 +51,13 @@ func (b *Backend) DeleteWorkspace(name string, _ bool) error {
 		return err
 	}
 
+	if b.historyLimit > 0 {
+		_, err = b.db.Exec(fmt.Sprintf(query, b.schemaName, statesHistoryTableName), name)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -58,9 +65,10 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 	// Build the state client
 	var stateMgr statemgr.Full = remote.NewState(
 		&RemoteClient{
-			Client:     b.db,
-			Name:       name,
-			SchemaName: b.schemaName,
+			Client:       b.db,
+			Name:         name,
+			SchemaName:   b.schemaName,
+			HistoryLimit: b.historyLimit,
 		},
 		b.encryption,
 	)
@@ -9,6 +9,7 @@ import (
 	"crypto/md5"
 	"database/sql"
 	"fmt"
+	"strconv"
 
 	uuid "github.com/hashicorp/go-uuid"
 	_ "github.com/lib/pq"
@@ -21,6 +22,9 @@ type RemoteClient struct {
 	Client     *sql.DB
 	Name       string
 	SchemaName string
+	// HistoryLimit is the number of earlier versions of the state to keep
+	// in the history table. History is disabled when it is 0.
+	HistoryLimit int
 
 	info *statemgr.LockInfo
 }
@@ -46,14 +50,37 @@ func (c *RemoteClient) Get() (*remote.Payload, error) {
 }
 
 func (c *RemoteClient) Put(data []byte) error {
+	tx, err := c.Client.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `INSERT INTO %s.%s (name, data) VALUES ($1, $2)
 		ON CONFLICT (name) DO UPDATE
 		SET data = $2 WHERE %s.name = $1`
-	_, err := c.Client.Exec(fmt.Sprintf(query, c.SchemaName, statesTableName, statesTableName), c.Name, data)
+	_, err = tx.Exec(fmt.Sprintf(query, c.SchemaName, statesTableName, statesTableName), c.Name, data)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if c.HistoryLimit > 0 {
+		query = `INSERT INTO %s.%s (name, data) VALUES ($1, $2)`
+		_, err = tx.Exec(fmt.Sprintf(query, c.SchemaName, statesHistoryTableName), c.Name, data)
+		if err != nil {
+			return err
+		}
+
+		// Only keep the newest versions
+		query = `DELETE FROM %s.%s WHERE name = $1 AND id NOT IN (
+			SELECT id FROM %s.%s WHERE name = $1 ORDER BY id DESC LIMIT $2)`
+		_, err = tx.Exec(fmt.Sprintf(query, c.SchemaName, statesHistoryTableName, c.SchemaName, statesHistoryTableName), c.Name, c.HistoryLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (c *RemoteClient) Delete() error {
@@ -62,9 +89,82 @@ func (c *RemoteClient) Delete() error {
 	if err != nil {
 		return err
 	}
+	if c.HistoryLimit > 0 {
+		_, err = c.Client.Exec(fmt.Sprintf(query, c.SchemaName, statesHistoryTableName), c.Name)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ListVersions returns the versions of the state kept in the history table,
+// newest first.
+func (c *RemoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	if c.HistoryLimit == 0 {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	query := `SELECT h.id, h.created_at, octet_length(h.data), h.data IS NOT DISTINCT FROM s.data
+		FROM %s.%s h LEFT JOIN %s.%s s ON s.name = h.name
+		WHERE h.name = $1 ORDER BY h.id DESC`
+	rows, err := c.Client.Query(fmt.Sprintf(query, c.SchemaName, statesHistoryTableName, c.SchemaName, statesTableName), c.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []statemgr.StateVersion
+	for rows.Next() {
+		var id int64
+		var size sql.NullInt64
+		var matchesCurrent bool
+		var version statemgr.StateVersion
+		if err := rows.Scan(&id, &version.Created, &size, &matchesCurrent); err != nil {
+			return nil, err
+		}
+		version.ID = strconv.FormatInt(id, 10)
+		version.Size = size.Int64
+		// An older version may have the same data if it was restored
+		version.Current = matchesCurrent && len(versions) == 0
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetVersion reads the version of the state with the given ID from the
+// history table. It returns nil if the version doesn't exist.
+func (c *RemoteClient) GetVersion(id string) (*remote.Payload, error) {
+	if c.HistoryLimit == 0 {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	historyID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("state version ID should be a number, got %q", id)
+	}
+
+	query := `SELECT data FROM %s.%s WHERE name = $1 AND id = $2`
+	row := c.Client.QueryRow(fmt.Sprintf(query, c.SchemaName, statesHistoryTableName), c.Name, historyID)
+	var data []byte
+	err = row.Scan(&data)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		md5 := md5.Sum(data)
+		return &remote.Payload{
+			Data: data,
+			MD5:  md5[:],
+		}, nil
+	}
+}
+
 func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 	var err error
 	var lockID string
@@ -21,6 +21,7 @@ import (
 func TestRemoteClient_impl(t *testing.T) {
 	var _ remote.Client = new(RemoteClient)
 	var _ remote.ClientLocker = new(RemoteClient)
+	var _ remote.ClientHistorian = new(RemoteClient)
 }
 
 func TestRemoteClient(t *testing.T) {
@@ -80,3 +81,52 @@ func TestRemoteLocks(t *testing.T) {
 
 	remote.TestRemoteLocks(t, s1.(*remote.State).Client, s2.(*remote.State).Client)
 }
+
+func TestRemoteClient_history(t *testing.T) {
+	testACC(t)
+	connStr := getDatabaseUrl()
+	schemaName := fmt.Sprintf("terraform_%s", t.Name())
+	dbCleaner, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
+
+	config := backend.TestWrapConfig(map[string]interface{}{
+		"conn_str":      connStr,
+		"schema_name":   schemaName,
+		"history_limit": 2,
+	})
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Backend)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := s.(*remote.State).Client.(*RemoteClient)
+
+	for _, data := range []string{"one", "two", "three"} {
+		if err := client.Put([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := client.ListVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Fatalf("expected only the newest version to be current: %#v", versions)
+	}
+
+	payload, err := client.GetVersion(versions[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload == nil || string(payload.Data) != "two" {
+		t.Fatalf("unexpected payload for version %s: %#v", versions[1].ID, payload)
+	}
+}
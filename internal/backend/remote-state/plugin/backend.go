@@ -0,0 +1,197 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// New creates a new backend that stores the state through a backend plugin,
+// which is installed from the given source.
+func New(source getproviders.Source, enc encryption.StateEncryption) backend.Backend {
+	return &Backend{
+		source:     source,
+		encryption: enc,
+	}
+}
+
+type Backend struct {
+	source     getproviders.Source
+	encryption encryption.StateEncryption
+
+	// locks are the dependency locks that the backend plugin is selected
+	// from, and install allows Configure to install a backend plugin that
+	// is not locked yet. Both are set by SetDependencyLocks.
+	locks   *depsfile.Locks
+	install bool
+
+	// The fields below are set from configure
+	plugin *backendplugin.GRPCBackend
+}
+
+var _ backend.Backend = (*Backend)(nil)
+var _ backend.PluginInstaller = (*Backend)(nil)
+
+func (b *Backend) SetDependencyLocks(locks *depsfile.Locks, install bool) {
+	b.locks = locks
+	b.install = install
+}
+
+func (b *Backend) ConfigSchema() *configschema.Block {
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"source": {
+				Type:        cty.String,
+				Required:    true,
+				Description: "The address of the backend plugin in a registry, in the same form as a provider source address.",
+			},
+			"version": {
+				Type:        cty.String,
+				Optional:    true,
+				Description: "A version constraint for the backend plugin.",
+			},
+			"config": {
+				Type:        cty.DynamicPseudoType,
+				Optional:    true,
+				Description: "The configuration of the backend plugin, as an object that conforms to its schema.",
+			},
+		},
+	}
+}
+
+func (b *Backend) PrepareConfig(obj cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if obj.IsNull() {
+		return obj, diags
+	}
+
+	_, _, moreDiags := pluginRequirement(obj)
+	diags = diags.Append(moreDiags)
+
+	if config := obj.GetAttr("config"); !config.IsNull() && config.IsKnown() {
+		if ty := config.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid config value",
+				`The "config" attribute value must be an object.`,
+				cty.GetAttrPath("config"),
+			))
+		}
+	}
+
+	return obj, diags
+}
+
+func (b *Backend) Configure(obj cty.Value) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if obj.IsNull() {
+		return diags
+	}
+
+	provider, constraints, moreDiags := pluginRequirement(obj)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	plugin, err := b.startPlugin(provider, constraints)
+	if err != nil {
+		return diags.Append(tfdiags.AttributeValue(
+			tfdiags.Error,
+			"Failed to start backend plugin",
+			fmt.Sprintf("Could not start the backend plugin %s: %s.", provider.ForDisplay(), err),
+			cty.GetAttrPath("source"),
+		))
+	}
+
+	schema, moreDiags := plugin.GetSchema()
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		plugin.Close()
+		return diags
+	}
+
+	config, err := conformToSchema(obj.GetAttr("config"), schema)
+	if err != nil {
+		plugin.Close()
+		return diags.Append(tfdiags.AttributeValue(
+			tfdiags.Error,
+			"Invalid backend plugin configuration",
+			fmt.Sprintf("The configuration for the backend plugin %s is not valid: %s.", provider.ForDisplay(), err),
+			cty.GetAttrPath("config"),
+		))
+	}
+
+	config, moreDiags = plugin.PrepareConfig(config)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		plugin.Close()
+		return diags
+	}
+
+	diags = diags.Append(plugin.Configure(config))
+	if diags.HasErrors() {
+		plugin.Close()
+		return diags
+	}
+
+	b.plugin = plugin
+	return diags
+}
+
+// pluginRequirement returns the address and version constraints of the
+// backend plugin from the backend configuration.
+func pluginRequirement(obj cty.Value) (addrs.Provider, getproviders.VersionConstraints, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	var provider addrs.Provider
+	if source := obj.GetAttr("source"); source.IsNull() || source.AsString() == "" {
+		diags = diags.Append(tfdiags.AttributeValue(
+			tfdiags.Error,
+			"Invalid source value",
+			`The "source" attribute value must not be empty.`,
+			cty.GetAttrPath("source"),
+		))
+	} else {
+		var parseDiags tfdiags.Diagnostics
+		provider, parseDiags = addrs.ParseProviderSourceString(source.AsString())
+		if parseDiags.HasErrors() {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid source value",
+				fmt.Sprintf(`The "source" attribute value is not a valid plugin address: %s`, parseDiags.Err()),
+				cty.GetAttrPath("source"),
+			))
+		}
+	}
+
+	var constraints getproviders.VersionConstraints
+	if version := obj.GetAttr("version"); !version.IsNull() && version.AsString() != "" {
+		var err error
+		constraints, err = getproviders.ParseVersionConstraints(version.AsString())
+		if err != nil {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid version value",
+				fmt.Sprintf(`The "version" attribute value is not a valid version constraint: %s.`, err),
+				cty.GetAttrPath("version"),
+			))
+		}
+	}
+
+	return provider, constraints, diags
+}
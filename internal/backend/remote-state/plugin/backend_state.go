@@ -0,0 +1,118 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func (b *Backend) Workspaces() ([]string, error) {
+	if b.plugin == nil {
+		return nil, errNotConfigured
+	}
+	return b.plugin.Workspaces()
+}
+
+func (b *Backend) DeleteWorkspace(name string, force bool) error {
+	if b.plugin == nil {
+		return errNotConfigured
+	}
+	if name == backend.DefaultStateName || name == "" {
+		return fmt.Errorf("can't delete default state")
+	}
+	return b.plugin.DeleteWorkspace(name, force)
+}
+
+func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
+	if b.plugin == nil {
+		return nil, errNotConfigured
+	}
+
+	client, err := b.plugin.StateClient(name)
+	if err != nil {
+		return nil, err
+	}
+	stateMgr := remote.NewState(client, b.encryption)
+
+	// the default state always exists
+	if name == backend.DefaultStateName {
+		return stateMgr, nil
+	}
+
+	// Check to see if this state already exists.
+	existing, err := b.Workspaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range existing {
+		if s == name {
+			return stateMgr, nil
+		}
+	}
+
+	// Grab a lock, we use this to write an empty state if one doesn't
+	// exist already. We have to write an empty state as a sentinel value
+	// so Workspaces() knows it exists.
+	lockInfo := statemgr.NewLockInfo()
+	lockInfo.Operation = "init"
+	lockId, err := stateMgr.Lock(lockInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock state in backend plugin: %w", err)
+	}
+
+	// Local helper function so we can call it multiple places
+	lockUnlock := func(parent error) error {
+		if err := stateMgr.Unlock(lockId); err != nil {
+			return fmt.Errorf(strings.TrimSpace(errStateUnlock), lockId, err)
+		}
+
+		return parent
+	}
+
+	// Grab the value
+	if err := stateMgr.RefreshState(); err != nil {
+		err = lockUnlock(err)
+		return nil, err
+	}
+
+	// If we have no state, we have to create an empty state
+	if v := stateMgr.State(); v == nil {
+		if err := stateMgr.WriteState(states.NewState()); err != nil {
+			err = lockUnlock(err)
+			return nil, err
+		}
+		if err := stateMgr.PersistState(nil); err != nil {
+			err = lockUnlock(err)
+			return nil, err
+		}
+	}
+
+	// Unlock, the state should now be initialized
+	if err := lockUnlock(nil); err != nil {
+		return nil, err
+	}
+
+	return stateMgr, nil
+}
+
+var errNotConfigured = errors.New("the backend plugin is not configured")
+
+const errStateUnlock = `
+Error unlocking backend plugin state. Lock ID: %s
+
+Error: %w
+
+You may have to force-unlock this state in order to use it again.
+The plugin backend acquires a lock during initialization to ensure
+the minimum required key/values are prepared.
+`
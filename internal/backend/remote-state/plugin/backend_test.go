@@ -0,0 +1,254 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/encryption/enctest"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// testServeEnv is set to make the test binary serve a TestBackend as a
+// backend plugin, instead of running the tests.
+const testServeEnv = "TF_BACKEND_PLUGIN_TEST_SERVE"
+
+var testPlugin = addrs.NewDefaultProvider("test")
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testServeEnv) != "" {
+		backendplugin.Serve(&backendplugin.ServeOpts{
+			Backend: func() backendplugin.Backend {
+				return &backendplugin.TestBackend{}
+			},
+		})
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testPluginCache sets up a data directory where the test binary is
+// installed as version 1.0.0 of the test backend plugin, and returns the
+// dependency locks that select it.
+func testPluginCache(t *testing.T) *depsfile.Locks {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	t.Setenv("TF_DATA_DIR", dataDir)
+	t.Setenv(testServeEnv, "1")
+	t.Cleanup(goplugin.CleanupClients)
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(
+		pluginCacheDir(),
+		testPlugin.Hostname.String(), testPlugin.Namespace, testPlugin.Type,
+		"1.0.0", getproviders.CurrentPlatform.String(),
+	)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(exe, filepath.Join(dir, "terraform-provider-test")); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := getproviders.PackageHashV1(getproviders.PackageLocalDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	locks := depsfile.NewLocks()
+	locks.SetBackendPlugin(testPlugin, getproviders.MustParseVersion("1.0.0"), nil, []getproviders.Hash{hash})
+	return locks
+}
+
+func testBackendConfig(t *testing.T, locks *depsfile.Locks, path string, enc encryption.StateEncryption) backend.Backend {
+	t.Helper()
+
+	config := map[string]interface{}{
+		"source":  testPlugin.String(),
+		"version": "~> 1.0",
+		"config": map[string]interface{}{
+			"path": path,
+		},
+	}
+	b := New(getproviders.NewMockSource(nil, nil), enc)
+	b.(*Backend).SetDependencyLocks(locks, false)
+	return backend.TestBackendConfig(t, b, backend.TestWrapConfig(config))
+}
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackend(t *testing.T) {
+	locks := testPluginCache(t)
+	path := t.TempDir()
+
+	b1 := testBackendConfig(t, locks, path, encryption.StateEncryptionDisabled())
+	b2 := testBackendConfig(t, locks, path, encryption.StateEncryptionDisabled())
+
+	backend.TestBackendStates(t, b1)
+	backend.TestBackendStateLocks(t, b1, b2)
+	backend.TestBackendStateForceUnlock(t, b1, b2)
+}
+
+func TestBackend_encrypted(t *testing.T) {
+	locks := testPluginCache(t)
+	path := t.TempDir()
+
+	b := testBackendConfig(t, locks, path, enctest.EncryptionRequired().State())
+	backend.TestBackendStates(t, b)
+}
+
+func TestBackend_invalidPluginConfig(t *testing.T) {
+	locks := testPluginCache(t)
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		wantErr string
+	}{
+		"unsupported argument": {
+			map[string]interface{}{"path": "foo", "bucket": "bar"},
+			`unsupported argument "bucket"`,
+		},
+		"missing required argument": {
+			map[string]interface{}{"lock": true},
+			`the argument "path" is required`,
+		},
+		"rejected by the plugin": {
+			map[string]interface{}{"path": ""},
+			"The path must not be empty.",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := New(getproviders.NewMockSource(nil, nil), encryption.StateEncryptionDisabled())
+			b.(*Backend).SetDependencyLocks(locks, false)
+			config := cty.ObjectVal(map[string]cty.Value{
+				"source":  cty.StringVal(testPlugin.String()),
+				"version": cty.NullVal(cty.String),
+				"config":  hcl2shim.HCL2ValueFromConfigValue(tc.config),
+			})
+			diags := b.Configure(config)
+			if !diags.HasErrors() {
+				t.Fatal("expected an error")
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, tc.wantErr) {
+				t.Fatalf("wrong error %q, want it to contain %q", got, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackend_installPlugin(t *testing.T) {
+	t.Setenv("TF_DATA_DIR", t.TempDir())
+
+	var packages []getproviders.PackageMeta
+	for _, v := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		meta, close, err := getproviders.FakeInstallablePackageMeta(testPlugin, getproviders.MustParseVersion(v), nil, getproviders.CurrentPlatform, "")
+		t.Cleanup(close)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages = append(packages, meta)
+	}
+	src := getproviders.NewMockSource(packages, nil)
+	b := New(src, encryption.StateEncryptionDisabled()).(*Backend)
+	constraints := getproviders.MustParseVersionConstraints("~> 1.0")
+
+	// Outside of init, a plugin that isn't locked must not be installed
+	locks := depsfile.NewLocks()
+	b.SetDependencyLocks(locks, false)
+	if _, err := b.installPlugin(testPlugin, constraints); err == nil || !strings.Contains(err.Error(), "tofu init") {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if got := len(src.CallLog()); got != 0 {
+		t.Fatalf("the source was queried outside of init")
+	}
+
+	b.SetDependencyLocks(locks, true)
+	cached, err := b.installPlugin(testPlugin, constraints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cached.Version.String(), "1.1.0"; got != want {
+		t.Fatalf("wrong version %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(cached.PackageDir, pluginCacheDir()) {
+		t.Fatalf("plugin installed in %s, outside of %s", cached.PackageDir, pluginCacheDir())
+	}
+	lock := locks.BackendPlugin(testPlugin)
+	if lock == nil {
+		t.Fatal("the plugin was not recorded in the dependency locks")
+	}
+	if got, want := lock.Version().String(), "1.1.0"; got != want {
+		t.Fatalf("wrong locked version %s, want %s", got, want)
+	}
+	if matches, err := cached.MatchesAnyHash(lock.PreferredHashes()); err != nil || !matches {
+		t.Fatalf("the installed plugin does not match the locked hashes %v: %v", lock.PreferredHashes(), err)
+	}
+	if len(locks.AllProviders()) != 0 {
+		t.Fatalf("the plugin was recorded as a provider")
+	}
+
+	// Afterwards, the locked plugin is found in the cache directory
+	calls := len(src.CallLog())
+	b.SetDependencyLocks(locks, false)
+	if _, err := b.installPlugin(testPlugin, constraints); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(src.CallLog()); got != calls {
+		t.Fatalf("the source was queried again for an installed plugin")
+	}
+
+	// A locked version that doesn't meet new version constraints must be
+	// upgraded with init
+	constraints = getproviders.MustParseVersionConstraints("~> 2.0")
+	if _, err := b.installPlugin(testPlugin, constraints); err == nil || !strings.Contains(err.Error(), "tofu init") {
+		t.Fatalf("wrong error: %v", err)
+	}
+	b.SetDependencyLocks(locks, true)
+	cached, err = b.installPlugin(testPlugin, constraints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cached.Version.String(), "2.0.0"; got != want {
+		t.Fatalf("wrong version %s, want %s", got, want)
+	}
+	if got, want := locks.BackendPlugin(testPlugin).Version().String(), "2.0.0"; got != want {
+		t.Fatalf("wrong locked version %s, want %s", got, want)
+	}
+}
+
+func TestBackend_lockedHashMismatch(t *testing.T) {
+	locks := testPluginCache(t)
+	locks.SetBackendPlugin(testPlugin, getproviders.MustParseVersion("1.0.0"), nil, []getproviders.Hash{
+		getproviders.HashScheme1.New("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="),
+	})
+
+	b := New(getproviders.NewMockSource(nil, nil), encryption.StateEncryptionDisabled()).(*Backend)
+	b.SetDependencyLocks(locks, false)
+	_, err := b.installPlugin(testPlugin, nil)
+	if err == nil || !strings.Contains(err.Error(), "does not match any of the checksums") {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
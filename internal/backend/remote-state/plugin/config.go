@@ -0,0 +1,144 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+// conformToSchema converts the "config" attribute of the backend
+// configuration, which is an object of any type, to a value of the type
+// implied by the schema of the backend plugin. Missing optional attributes
+// are null, and missing nested blocks are null or empty collections, as if
+// the configuration was decoded with the schema.
+func conformToSchema(v cty.Value, schema *configschema.Block) (cty.Value, error) {
+	if v.IsNull() {
+		v = cty.EmptyObjectVal
+	}
+	if !v.IsKnown() {
+		return cty.UnknownVal(schema.ImpliedType()), nil
+	}
+	if ty := v.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+		return cty.NilVal, fmt.Errorf("an object is required")
+	}
+
+	given := v.AsValueMap()
+	for _, name := range sortedNames(given) {
+		_, isAttr := schema.Attributes[name]
+		_, isBlock := schema.BlockTypes[name]
+		if !isAttr && !isBlock {
+			return cty.NilVal, fmt.Errorf("unsupported argument %q", name)
+		}
+	}
+
+	vals := make(map[string]cty.Value)
+	for name, attr := range schema.Attributes {
+		av, ok := given[name]
+		if !ok || av.IsNull() {
+			if attr.Required {
+				return cty.NilVal, fmt.Errorf("the argument %q is required", name)
+			}
+			vals[name] = cty.NullVal(attr.Type)
+			continue
+		}
+
+		converted, err := convert.Convert(av, attr.Type)
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("invalid value for %q: %w", name, err)
+		}
+		vals[name] = converted
+	}
+
+	for name, nested := range schema.BlockTypes {
+		bv, err := conformBlock(given[name], nested)
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("invalid value for %q: %w", name, err)
+		}
+		vals[name] = bv
+	}
+
+	return cty.ObjectVal(vals), nil
+}
+
+// conformBlock converts the value given for a nested block to the type
+// implied by its schema. The value is cty.NilVal if it wasn't given.
+func conformBlock(v cty.Value, nested *configschema.NestedBlock) (cty.Value, error) {
+	ty := nested.Block.ImpliedType()
+
+	switch nested.Nesting {
+	case configschema.NestingSingle, configschema.NestingGroup:
+		if v == cty.NilVal || v.IsNull() {
+			if nested.Nesting == configschema.NestingSingle {
+				return cty.NullVal(ty), nil
+			}
+			return conformToSchema(cty.EmptyObjectVal, &nested.Block)
+		}
+		return conformToSchema(v, &nested.Block)
+
+	case configschema.NestingList, configschema.NestingSet:
+		var elems []cty.Value
+		if v != cty.NilVal && !v.IsNull() {
+			if !v.CanIterateElements() || v.Type().IsMapType() || v.Type().IsObjectType() {
+				return cty.NilVal, fmt.Errorf("a list of objects is required")
+			}
+			for it := v.ElementIterator(); it.Next(); {
+				_, ev := it.Element()
+				conformed, err := conformToSchema(ev, &nested.Block)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				elems = append(elems, conformed)
+			}
+		}
+		switch {
+		case len(elems) == 0 && nested.Nesting == configschema.NestingList:
+			return cty.ListValEmpty(ty), nil
+		case len(elems) == 0:
+			return cty.SetValEmpty(ty), nil
+		case nested.Nesting == configschema.NestingList:
+			return cty.ListVal(elems), nil
+		default:
+			return cty.SetVal(elems), nil
+		}
+
+	case configschema.NestingMap:
+		elems := make(map[string]cty.Value)
+		if v != cty.NilVal && !v.IsNull() {
+			if !v.Type().IsMapType() && !v.Type().IsObjectType() {
+				return cty.NilVal, fmt.Errorf("a map of objects is required")
+			}
+			for key, ev := range v.AsValueMap() {
+				conformed, err := conformToSchema(ev, &nested.Block)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				elems[key] = conformed
+			}
+		}
+		if len(elems) == 0 {
+			return cty.MapValEmpty(ty), nil
+		}
+		return cty.MapVal(elems), nil
+
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported nesting mode %s", nested.Nesting)
+	}
+}
+
+func sortedNames(m map[string]cty.Value) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
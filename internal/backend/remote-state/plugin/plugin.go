@@ -0,0 +1,150 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/logging"
+	"github.com/opentofu/opentofu/internal/providercache"
+)
+
+// pluginCacheDir returns the directory that backend plugins are installed
+// into, which is inside the data directory of the working directory.
+func pluginCacheDir() string {
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = ".terraform"
+	}
+	return filepath.Join(dataDir, "backend-plugins")
+}
+
+// installPlugin returns the backend plugin that is selected in the dependency
+// locks, which must be installed in the cache directory and match the locked
+// hashes.
+//
+// If installation is allowed, it also installs the locked version if it isn't
+// in the cache directory yet, or selects the newest version that meets the
+// version constraints if there is no lock for them, and records it in the
+// dependency locks.
+func (b *Backend) installPlugin(provider addrs.Provider, constraints getproviders.VersionConstraints) (*providercache.CachedProvider, error) {
+	if b.locks == nil {
+		b.locks = depsfile.NewLocks()
+	}
+
+	lock := b.locks.BackendPlugin(provider)
+	if lock != nil && !getproviders.MeetingConstraints(constraints).Has(lock.Version()) {
+		if !b.install {
+			return nil, fmt.Errorf("the locked version %s does not meet the version constraints; run \"tofu init\" to select a new version", lock.Version())
+		}
+		lock = nil
+	}
+
+	dir := providercache.NewDir(pluginCacheDir())
+	if lock != nil {
+		if cached := dir.ProviderVersion(provider, lock.Version()); cached != nil {
+			matches, err := cached.MatchesAnyHash(lock.PreferredHashes())
+			if err != nil {
+				return nil, fmt.Errorf("failed to verify the checksum of version %s: %w", lock.Version(), err)
+			}
+			if matches {
+				return cached, nil
+			}
+			if !b.install {
+				return nil, fmt.Errorf("the installed version %s does not match any of the checksums recorded in the dependency lock file; run \"tofu init\" to install it again", lock.Version())
+			}
+		}
+	}
+	if !b.install {
+		if lock == nil {
+			return nil, fmt.Errorf("the plugin is not recorded in the dependency lock file; run \"tofu init\" to install it")
+		}
+		return nil, fmt.Errorf("the locked version %s is not installed; run \"tofu init\" to install it", lock.Version())
+	}
+
+	log.Printf("[INFO] backend/plugin: installing %s", provider)
+
+	// The installer only deals with provider locks, so we give it the lock
+	// of the backend plugin as if it were a provider.
+	locks := depsfile.NewLocks()
+	if lock != nil {
+		locks.SetProvider(provider, lock.Version(), lock.VersionConstraints(), append([]getproviders.Hash(nil), lock.AllHashes()...))
+	}
+	installer := providercache.NewInstaller(dir, b.source)
+	reqs := getproviders.Requirements{provider: constraints}
+	newLocks, err := installer.EnsureProviderVersions(context.TODO(), locks, reqs, providercache.InstallNewProvidersOnly)
+	if err != nil {
+		return nil, err
+	}
+	newLock := newLocks.Provider(provider)
+	if newLock == nil {
+		return nil, fmt.Errorf("no version of the plugin that meets the version constraints was installed")
+	}
+
+	// The installer has written to the directory, so we need a new Dir to
+	// see the changes.
+	dir = providercache.NewDir(pluginCacheDir())
+	cached := dir.ProviderVersion(provider, newLock.Version())
+	if cached == nil {
+		return nil, fmt.Errorf("version %s of the plugin was not installed", newLock.Version())
+	}
+
+	b.locks.SetBackendPlugin(provider, newLock.Version(), constraints, append([]getproviders.Hash(nil), newLock.AllHashes()...))
+	return cached, nil
+}
+
+// startPlugin installs the backend plugin if needed and allowed, and starts
+// it.
+func (b *Backend) startPlugin(provider addrs.Provider, constraints getproviders.VersionConstraints) (*backendplugin.GRPCBackend, error) {
+	cached, err := b.installPlugin(provider, constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	execFile, err := cached.ExecutableFile()
+	if err != nil {
+		return nil, err
+	}
+
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  backendplugin.Handshake,
+		Plugins:          backendplugin.PluginSet(nil),
+		Logger:           logging.NewProviderLogger("backend-"),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		Managed:          true,
+		Cmd:              exec.Command(execFile),
+		AutoMTLS:         true,
+		SyncStdout:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", provider)),
+		SyncStderr:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", provider)),
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+
+	raw, err := rpcClient.Dispense(backendplugin.BackendPluginName)
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+
+	p := raw.(*backendplugin.GRPCBackend)
+	p.PluginClient = client
+	return p, nil
+}
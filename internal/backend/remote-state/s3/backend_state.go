@@ -144,6 +144,7 @@ func (b *Backend) remoteClient(name string) (*RemoteClient, error) {
 		acl:                   b.acl,
 		kmsKeyID:              b.kmsKeyID,
 		ddbTable:              b.ddbTable,
+		useLockfile:           b.useLockfile,
 		skipS3Checksum:        b.skipS3Checksum,
 	}
 
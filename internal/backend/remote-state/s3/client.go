@@ -17,6 +17,8 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -24,6 +26,8 @@ import (
 	dtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	multierror "github.com/hashicorp/go-multierror"
 	uuid "github.com/hashicorp/go-uuid"
 
@@ -35,6 +39,7 @@ import (
 const (
 	s3EncryptionAlgorithm  = "AES256"
 	stateIDSuffix          = "-md5"
+	lockFileSuffix         = ".tflock"
 	s3ErrCodeInternalError = "InternalError"
 )
 
@@ -48,6 +53,7 @@ type RemoteClient struct {
 	acl                   string
 	kmsKeyID              string
 	ddbTable              string
+	useLockfile           bool
 
 	skipS3Checksum bool
 }
@@ -71,7 +77,7 @@ func (c *RemoteClient) Get() (payload *remote.Payload, err error) {
 	// If we have a checksum, and the returned payload doesn't match, we retry
 	// up until deadline.
 	for {
-		payload, err = c.get(ctx)
+		payload, err = c.get(ctx, "")
 		if err != nil {
 			return nil, err
 		}
@@ -109,7 +115,9 @@ func (c *RemoteClient) Get() (payload *remote.Payload, err error) {
 	return payload, err
 }
 
-func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
+// get reads the state object. If versionID is empty, the latest version is
+// returned.
+func (c *RemoteClient) get(ctx context.Context, versionID string) (*remote.Payload, error) {
 	var output *s3.GetObjectOutput
 	var err error
 
@@ -119,6 +127,9 @@ func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
 		Bucket: &c.bucketName,
 		Key:    &c.path,
 	}
+	if versionID != "" {
+		inputHead.VersionId = aws.String(versionID)
+	}
 
 	if c.serverSideEncryption && c.customerEncryptionKey != nil {
 		inputHead.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(c.customerEncryptionKey))
@@ -146,6 +157,9 @@ func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
 		Bucket: &c.bucketName,
 		Key:    &c.path,
 	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
 
 	if c.serverSideEncryption && c.customerEncryptionKey != nil {
 		input.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(c.customerEncryptionKey))
@@ -190,6 +204,32 @@ func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
 }
 
 func (c *RemoteClient) Put(data []byte) error {
+	i := c.putObjectInput(c.path, data)
+
+	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
+
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	_, err := c.s3Client.PutObject(ctx, i)
+	if err != nil {
+		return fmt.Errorf("failed to upload state: %w", err)
+	}
+
+	sum := md5.Sum(data)
+	if err := c.putMD5(ctx, sum[:]); err != nil {
+		// if this errors out, we unfortunately have to error out altogether,
+		// since the next Get will inevitably fail.
+		return fmt.Errorf("failed to store state MD5: %w", err)
+
+	}
+
+	return nil
+}
+
+// putObjectInput returns the input for uploading the given data to the
+// given key, with the checksum, encryption and ACL settings of the backend.
+func (c *RemoteClient) putObjectInput(key string, data []byte) *s3.PutObjectInput {
 	contentType := "application/json"
 	contentLength := int64(len(data))
 
@@ -198,7 +238,7 @@ func (c *RemoteClient) Put(data []byte) error {
 		ContentLength: aws.Int64(contentLength),
 		Body:          bytes.NewReader(data),
 		Bucket:        &c.bucketName,
-		Key:           &c.path,
+		Key:           aws.String(key),
 	}
 
 	if !c.skipS3Checksum {
@@ -230,25 +270,7 @@ func (c *RemoteClient) Put(data []byte) error {
 		i.ACL = types.ObjectCannedACL(c.acl)
 	}
 
-	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
-
-	ctx := context.TODO()
-	ctx, _ = attachLoggerToContext(ctx)
-
-	_, err := c.s3Client.PutObject(ctx, i)
-	if err != nil {
-		return fmt.Errorf("failed to upload state: %w", err)
-	}
-
-	sum := md5.Sum(data)
-	if err := c.putMD5(ctx, sum[:]); err != nil {
-		// if this errors out, we unfortunately have to error out altogether,
-		// since the next Get will inevitably fail.
-		return fmt.Errorf("failed to store state MD5: %w", err)
-
-	}
-
-	return nil
+	return i
 }
 
 func (c *RemoteClient) Delete() error {
@@ -272,7 +294,7 @@ func (c *RemoteClient) Delete() error {
 }
 
 func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
-	if c.ddbTable == "" {
+	if !c.IsLockingEnabled() {
 		return "", nil
 	}
 
@@ -287,6 +309,133 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 		info.ID = lockID
 	}
 
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	if c.useLockfile {
+		if err := c.lockS3(ctx, info); err != nil {
+			return "", err
+		}
+	}
+
+	if c.ddbTable != "" {
+		if err := c.lockDynamoDB(ctx, info); err != nil {
+			if c.useLockfile {
+				// Release the lock file we just took, so that we don't
+				// leave a half-acquired lock behind.
+				if unlockErr := c.unlockS3(ctx, info.ID); unlockErr != nil {
+					err = multierror.Append(err, unlockErr)
+				}
+			}
+			return "", err
+		}
+	}
+
+	return info.ID, nil
+}
+
+// lockS3 takes the lock by creating the lock file next to the state, using
+// a conditional write so that the upload fails if the file already exists.
+func (c *RemoteClient) lockS3(ctx context.Context, info *statemgr.LockInfo) error {
+	input := c.putObjectInput(c.lockFilePath(), info.Marshal())
+	_, err := c.s3Client.PutObject(ctx, input, func(o *s3.Options) {
+		// The If-None-Match header makes S3 reject the upload if an object
+		// with the same key already exists.
+		o.APIOptions = append(o.APIOptions, smithyhttp.SetHeaderValue("If-None-Match", "*"))
+	})
+	if err == nil {
+		return nil
+	}
+
+	lockErr := &statemgr.LockError{Err: err}
+	if isConditionalWriteConflict(err) {
+		lockErr.Err = fmt.Errorf("the state is already locked: the lock file %s exists", c.lockFilePath())
+		lockInfo, infoErr := c.getLockFileInfo(ctx)
+		if infoErr != nil {
+			lockErr.Err = multierror.Append(lockErr.Err, infoErr)
+		}
+		lockErr.Info = lockInfo
+	}
+	return lockErr
+}
+
+// isConditionalWriteConflict returns true if the given error means that a
+// conditional write failed because the object already exists, or because
+// another conditional write to the same key is in progress.
+func isConditionalWriteConflict(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "PreconditionFailed", "ConditionalRequestConflict":
+			return true
+		}
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.HTTPStatusCode() {
+		case http.StatusPreconditionFailed, http.StatusConflict:
+			return true
+		}
+	}
+	return false
+}
+
+func (c *RemoteClient) getLockFileInfo(ctx context.Context) (*statemgr.LockInfo, error) {
+	input := &s3.GetObjectInput{
+		Bucket: &c.bucketName,
+		Key:    aws.String(c.lockFilePath()),
+	}
+	if c.serverSideEncryption && c.customerEncryptionKey != nil {
+		input.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(c.customerEncryptionKey))
+		input.SSECustomerAlgorithm = aws.String(s3EncryptionAlgorithm)
+		input.SSECustomerKeyMD5 = aws.String(c.getSSECustomerKeyMD5())
+	}
+
+	output, err := c.s3Client.GetObject(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the lock file: %w", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the lock file: %w", err)
+	}
+
+	lockInfo := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, lockInfo); err != nil {
+		return nil, fmt.Errorf("failed to decode the lock file: %w", err)
+	}
+	return lockInfo, nil
+}
+
+func (c *RemoteClient) unlockS3(ctx context.Context, id string) error {
+	lockErr := &statemgr.LockError{}
+
+	lockInfo, err := c.getLockFileInfo(ctx)
+	if err != nil {
+		lockErr.Err = fmt.Errorf("failed to retrieve lock info: %w", err)
+		return lockErr
+	}
+	lockErr.Info = lockInfo
+
+	if lockInfo.ID != id {
+		lockErr.Err = fmt.Errorf("lock id %q does not match existing lock", id)
+		return lockErr
+	}
+
+	_, err = c.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &c.bucketName,
+		Key:    aws.String(c.lockFilePath()),
+	})
+	if err != nil {
+		lockErr.Err = err
+		return lockErr
+	}
+	return nil
+}
+
+func (c *RemoteClient) lockDynamoDB(ctx context.Context, info *statemgr.LockInfo) error {
 	putParams := &dynamodb.PutItemInput{
 		Item: map[string]dtypes.AttributeValue{
 			"LockID": &dtypes.AttributeValueMemberS{Value: c.lockPath()},
@@ -296,7 +445,6 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 		ConditionExpression: aws.String("attribute_not_exists(LockID)"),
 	}
 
-	ctx := context.TODO()
 	_, err := c.dynClient.PutItem(ctx, putParams)
 	if err != nil {
 		lockInfo, infoErr := c.getLockInfo(ctx)
@@ -308,10 +456,10 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 			Err:  err,
 			Info: lockInfo,
 		}
-		return "", lockErr
+		return lockErr
 	}
 
-	return info.ID, nil
+	return nil
 }
 
 func (c *RemoteClient) getMD5(ctx context.Context) ([]byte, error) {
@@ -423,12 +571,30 @@ func (c *RemoteClient) getLockInfo(ctx context.Context) (*statemgr.LockInfo, err
 }
 
 func (c *RemoteClient) Unlock(id string) error {
-	if c.ddbTable == "" {
+	if !c.IsLockingEnabled() {
 		return nil
 	}
 
-	lockErr := &statemgr.LockError{}
 	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	if c.ddbTable != "" {
+		if err := c.unlockDynamoDB(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if c.useLockfile {
+		if err := c.unlockS3(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RemoteClient) unlockDynamoDB(ctx context.Context, id string) error {
+	lockErr := &statemgr.LockError{}
 
 	// TODO: store the path and lock ID in separate fields, and have proper
 	// projection expression only delete the lock if both match, rather than
@@ -464,13 +630,68 @@ func (c *RemoteClient) lockPath() string {
 	return fmt.Sprintf("%s/%s", c.bucketName, c.path)
 }
 
+// lockFilePath returns the key of the lock file used when use_lockfile is set.
+func (c *RemoteClient) lockFilePath() string {
+	return c.path + lockFileSuffix
+}
+
 func (c *RemoteClient) getSSECustomerKeyMD5() string {
 	b := md5.Sum(c.customerEncryptionKey)
 	return base64.StdEncoding.EncodeToString(b[:])
 }
 
 func (c *RemoteClient) IsLockingEnabled() bool {
-	return c.ddbTable != ""
+	return c.ddbTable != "" || c.useLockfile
+}
+
+// ListVersions returns the versions of the state object, newest first. If
+// versioning isn't enabled on the bucket, only the current version is
+// returned.
+func (c *RemoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	var versions []statemgr.StateVersion
+	pg := s3.NewListObjectVersionsPaginator(c.s3Client, &s3.ListObjectVersionsInput{
+		Bucket: &c.bucketName,
+		Prefix: &c.path,
+	})
+	for pg.HasMorePages() {
+		page, err := pg.NextPage(ctx)
+		if err != nil {
+			var nb *types.NoSuchBucket
+			if errors.As(err, &nb) {
+				return nil, fmt.Errorf(errS3NoSuchBucket, err)
+			}
+			return nil, fmt.Errorf("failed to list state versions: %w", err)
+		}
+
+		for _, v := range page.Versions {
+			// The prefix also matches other objects, such as the lock file.
+			if aws.ToString(v.Key) != c.path {
+				continue
+			}
+			versions = append(versions, statemgr.StateVersion{
+				ID:      aws.ToString(v.VersionId),
+				Created: aws.ToTime(v.LastModified),
+				Size:    aws.ToInt64(v.Size),
+				Current: aws.ToBool(v.IsLatest),
+			})
+		}
+	}
+
+	// S3 returns the versions of a key newest first, but we don't want to
+	// rely on that for S3-compatible services.
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Created.After(versions[j].Created)
+	})
+	return versions, nil
+}
+
+// GetVersion returns the given version of the state object.
+func (c *RemoteClient) GetVersion(id string) (*remote.Payload, error) {
+	ctx := context.TODO()
+	return c.get(ctx, id)
 }
 
 const errBadChecksumFmt = `state data in S3 does not have the expected content.
@@ -19,11 +19,13 @@ import (
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestRemoteClient_impl(t *testing.T) {
 	var _ remote.Client = new(RemoteClient)
 	var _ remote.ClientLocker = new(RemoteClient)
+	var _ remote.ClientHistorian = new(RemoteClient)
 }
 
 func TestRemoteClient(t *testing.T) {
@@ -87,6 +89,132 @@ func TestRemoteClientLocks(t *testing.T) {
 	remote.TestRemoteLocks(t, s1.(*remote.State).Client, s2.(*remote.State).Client)
 }
 
+func TestRemoteClient_fakeS3(t *testing.T) {
+	bucketName := "tofu-test-fake"
+	_, server := newFakeS3(t, bucketName)
+
+	b := testFakeS3Backend(t, server, bucketName, nil)
+
+	state, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote.TestClient(t, state.(*remote.State).Client)
+}
+
+func TestRemoteClientLocks_lockfile(t *testing.T) {
+	bucketName := "tofu-test-fake"
+	fake, server := newFakeS3(t, bucketName)
+
+	config := map[string]interface{}{"use_lockfile": true}
+	b1 := testFakeS3Backend(t, server, bucketName, config)
+	b2 := testFakeS3Backend(t, server, bucketName, config)
+
+	s1, err := b1.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := b2.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := s1.(*remote.State).Client.(*RemoteClient)
+	if !client.IsLockingEnabled() {
+		t.Fatal("expected locking to be enabled")
+	}
+
+	remote.TestRemoteLocks(t, s1.(*remote.State).Client, s2.(*remote.State).Client)
+
+	// The lock file must be removed once the lock is released.
+	fake.mu.Lock()
+	lockFile := fake.latest(client.lockFilePath())
+	fake.mu.Unlock()
+	if lockFile != nil {
+		t.Fatalf("lock file %s was not removed", client.lockFilePath())
+	}
+}
+
+func TestBackendLocked_lockfile(t *testing.T) {
+	bucketName := "tofu-test-fake"
+	_, server := newFakeS3(t, bucketName)
+
+	config := map[string]interface{}{"use_lockfile": true}
+	b1 := testFakeS3Backend(t, server, bucketName, config)
+	b2 := testFakeS3Backend(t, server, bucketName, config)
+
+	backend.TestBackendStateLocks(t, b1, b2)
+	backend.TestBackendStateForceUnlock(t, b1, b2)
+}
+
+func TestRemoteClient_history(t *testing.T) {
+	bucketName := "tofu-test-fake"
+	_, server := newFakeS3(t, bucketName)
+
+	b := testFakeS3Backend(t, server, bucketName, map[string]interface{}{"use_lockfile": true})
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	historian, ok := s.(statemgr.Historian)
+	if !ok {
+		t.Fatal("state manager does not implement statemgr.Historian")
+	}
+
+	// Write a few versions of the state
+	state := statemgr.TestFullInitialState()
+	for i := 0; i < 3; i++ {
+		if err := s.WriteState(state); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.PersistState(nil); err != nil {
+			t.Fatal(err)
+		}
+		state = state.DeepCopy()
+		state.RootModule().SetOutputValue("serial", cty.NumberIntVal(int64(i)), false)
+	}
+
+	versions, err := historian.ListStateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %d: %#v", len(versions), versions)
+	}
+	for i, v := range versions {
+		if v.Current != (i == 0) {
+			t.Fatalf("version %d: expected current to be %t", i, i == 0)
+		}
+		if i > 0 && !v.Created.Before(versions[i-1].Created) {
+			t.Fatalf("versions are not sorted newest first: %#v", versions)
+		}
+	}
+
+	oldest, err := historian.GetStateVersion(versions[len(versions)-1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest.State.RootModule().OutputValues["serial"] != nil {
+		t.Fatalf("expected the oldest version to be the first one written, got:\n%s", oldest.State)
+	}
+	newest, err := historian.GetStateVersion(versions[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newest.Serial <= oldest.Serial {
+		t.Fatalf("expected the newest serial %d to be higher than the oldest %d", newest.Serial, oldest.Serial)
+	}
+	if v := newest.State.RootModule().OutputValues["serial"]; v == nil || !v.Value.RawEquals(cty.NumberIntVal(1)) {
+		t.Fatalf("unexpected newest version:\n%s", newest.State)
+	}
+
+	if _, err := historian.GetStateVersion("missing"); err == nil {
+		t.Fatal("expected an error for a missing version")
+	}
+}
+
 // verify that we can unlock a state with an existing lock
 func TestForceUnlock(t *testing.T) {
 	testACC(t)
@@ -329,18 +457,30 @@ func TestRemoteClient_stateChecksum(t *testing.T) {
 }
 
 // Tests the IsLockingEnabled method for the S3 remote client.
-// It checks if locking is enabled based on the ddbTable field.
+// It checks if locking is enabled based on the ddbTable and useLockfile fields.
 func TestRemoteClient_IsLockingEnabled(t *testing.T) {
 	tests := []struct {
-		name       string
-		ddbTable   string
-		wantResult bool
+		name        string
+		ddbTable    string
+		useLockfile bool
+		wantResult  bool
 	}{
 		{
 			name:       "Locking enabled when ddbTable is set",
 			ddbTable:   "my-lock-table",
 			wantResult: true,
 		},
+		{
+			name:        "Locking enabled when useLockfile is set",
+			useLockfile: true,
+			wantResult:  true,
+		},
+		{
+			name:        "Locking enabled when both are set",
+			ddbTable:    "my-lock-table",
+			useLockfile: true,
+			wantResult:  true,
+		},
 		{
 			name:       "Locking disabled when ddbTable is empty",
 			ddbTable:   "",
@@ -351,7 +491,8 @@ func TestRemoteClient_IsLockingEnabled(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			client := &RemoteClient{
-				ddbTable: tt.ddbTable,
+				ddbTable:    tt.ddbTable,
+				useLockfile: tt.useLockfile,
 			}
 
 			gotResult := client.IsLockingEnabled()
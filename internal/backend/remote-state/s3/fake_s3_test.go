@@ -0,0 +1,263 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+// fakeS3 is a minimal, in-memory stand-in for the S3 API that supports the
+// operations used by the backend: object reads and writes with versioning,
+// conditional writes with If-None-Match, and listing. It only understands
+// path-style requests for a single bucket.
+type fakeS3 struct {
+	bucket string
+
+	mu      sync.Mutex
+	objects map[string][]*fakeS3Version
+	serial  int
+	now     time.Time
+}
+
+type fakeS3Version struct {
+	id           string
+	data         []byte
+	deleteMarker bool
+	lastModified time.Time
+}
+
+func newFakeS3(t *testing.T, bucket string) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	f := &fakeS3{
+		bucket:  bucket,
+		objects: make(map[string][]*fakeS3Version),
+		now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+// latest returns the current version of the given key, or nil if the key
+// doesn't exist.
+func (f *fakeS3) latest(key string) *fakeS3Version {
+	versions := f.objects[key]
+	if len(versions) == 0 || versions[len(versions)-1].deleteMarker {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+func (f *fakeS3) addVersion(key string, data []byte, deleteMarker bool) *fakeS3Version {
+	f.serial++
+	f.now = f.now.Add(time.Second)
+	v := &fakeS3Version{
+		id:           fmt.Sprintf("v%d", f.serial),
+		data:         data,
+		deleteMarker: deleteMarker,
+		lastModified: f.now,
+	}
+	f.objects[key] = append(f.objects[key], v)
+	return v
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != f.bucket {
+		fakeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case key == "" && r.Method == http.MethodGet && query.Has("versions"):
+		f.listObjectVersions(w, query.Get("prefix"))
+	case key == "" && r.Method == http.MethodGet:
+		f.listObjects(w, query.Get("prefix"))
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		f.getObject(w, r, key, query.Get("versionId"))
+	case r.Method == http.MethodPut:
+		f.putObject(w, r, key)
+	case r.Method == http.MethodDelete:
+		if f.latest(key) != nil {
+			f.addVersion(key, nil, true)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fakeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) getObject(w http.ResponseWriter, r *http.Request, key string, versionID string) {
+	var version *fakeS3Version
+	if versionID == "" {
+		version = f.latest(key)
+	} else {
+		for _, v := range f.objects[key] {
+			if v.id == versionID && !v.deleteMarker {
+				version = v
+			}
+		}
+	}
+	if version == nil {
+		fakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(version.data)))
+	w.Header().Set("Last-Modified", version.lastModified.Format(http.TimeFormat))
+	w.Header().Set("x-amz-version-id", version.id)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(version.data)
+	}
+}
+
+func (f *fakeS3) putObject(w http.ResponseWriter, r *http.Request, key string) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		fakeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" && f.latest(key) != nil {
+		fakeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+		return
+	}
+
+	v := f.addVersion(key, data, false)
+	w.Header().Set("x-amz-version-id", v.id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeS3) keys(prefix string) []string {
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeS3) listObjects(w http.ResponseWriter, prefix string) {
+	type content struct {
+		Key  string
+		Size int
+	}
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		IsTruncated bool
+		Contents    []content
+	}{Name: f.bucket, Prefix: prefix}
+	for _, key := range f.keys(prefix) {
+		if v := f.latest(key); v != nil {
+			result.Contents = append(result.Contents, content{Key: key, Size: len(v.data)})
+		}
+	}
+	result.KeyCount = len(result.Contents)
+	fakeS3XML(w, result)
+}
+
+func (f *fakeS3) listObjectVersions(w http.ResponseWriter, prefix string) {
+	type version struct {
+		Key          string
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool
+		LastModified string
+		Size         int
+	}
+	result := struct {
+		XMLName      xml.Name `xml:"ListVersionsResult"`
+		Name         string
+		Prefix       string
+		IsTruncated  bool
+		Version      []version
+		DeleteMarker []version
+	}{Name: f.bucket, Prefix: prefix}
+	for _, key := range f.keys(prefix) {
+		versions := f.objects[key]
+		// S3 lists the versions of a key newest first
+		for i := len(versions) - 1; i >= 0; i-- {
+			v := versions[i]
+			entry := version{
+				Key:          key,
+				VersionID:    v.id,
+				IsLatest:     i == len(versions)-1,
+				LastModified: v.lastModified.Format(time.RFC3339),
+				Size:         len(v.data),
+			}
+			if v.deleteMarker {
+				result.DeleteMarker = append(result.DeleteMarker, entry)
+			} else {
+				result.Version = append(result.Version, entry)
+			}
+		}
+	}
+	fakeS3XML(w, result)
+}
+
+func fakeS3XML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func fakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
+
+// testFakeS3Backend configures a backend that talks to the given fake S3
+// server, with any additional configuration merged in.
+func testFakeS3Backend(t *testing.T, server *httptest.Server, bucket string, config map[string]interface{}) *Backend {
+	t.Helper()
+
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	c := map[string]interface{}{
+		"bucket":                      bucket,
+		"key":                         "test/state",
+		"region":                      "us-east-1",
+		"access_key":                  "test",
+		"secret_key":                  "test",
+		"endpoint":                    server.URL,
+		"use_path_style":              true,
+		"skip_credentials_validation": true,
+		"skip_requesting_account_id":  true,
+		"skip_metadata_api_check":     true,
+		"skip_region_validation":      true,
+	}
+	for k, v := range config {
+		c[k] = v
+	}
+	return backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(c)).(*Backend)
+}
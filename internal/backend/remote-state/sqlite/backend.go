@@ -0,0 +1,148 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	statesTableName        = "states"
+	statesHistoryTableName = "states_history"
+	statesHistoryIndexName = "states_history_by_name"
+	locksTableName         = "locks"
+)
+
+// New creates a new backend for SQLite remote state.
+func New(enc encryption.StateEncryption) backend.Backend {
+	s := &schema.Backend{
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the SQLite database file",
+				DefaultFunc: schema.EnvDefaultFunc("TF_SQLITE_PATH", nil),
+			},
+
+			"history_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of earlier versions of each state to keep in the database. Set to 0 to disable keeping earlier versions",
+				Default:     10,
+			},
+
+			"busy_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Time in milliseconds to wait for other processes using the database before giving up",
+				Default:     5000,
+			},
+		},
+	}
+
+	result := &Backend{Backend: s, encryption: enc}
+	result.Backend.ConfigureFunc = result.configure
+	return result
+}
+
+type Backend struct {
+	*schema.Backend
+	encryption encryption.StateEncryption
+
+	// The fields below are set from configure
+	db           *sql.DB
+	configData   *schema.ResourceData
+	path         string
+	historyLimit int
+}
+
+func (b *Backend) configure(ctx context.Context) error {
+	// Grab the resource data
+	b.configData = schema.FromContextBackendConfig(ctx)
+	data := b.configData
+
+	b.path = data.Get("path").(string)
+	if b.path == "" {
+		return fmt.Errorf("path must be set to the location of the SQLite database file")
+	}
+	b.historyLimit = data.Get("history_limit").(int)
+	if b.historyLimit < 0 {
+		return fmt.Errorf("history_limit must not be negative")
+	}
+	busyTimeout := data.Get("busy_timeout").(int)
+	if busyTimeout < 0 {
+		return fmt.Errorf("busy_timeout must not be negative")
+	}
+
+	// Transactions take the write lock of the database when they begin, so
+	// that concurrent transactions wait for each other instead of failing
+	// when they try to write.
+	params := url.Values{
+		"_pragma": []string{fmt.Sprintf("busy_timeout(%d)", busyTimeout)},
+		"_txlock": []string{"immediate"},
+	}
+	db, err := sql.Open("sqlite", databaseURI(b.path, params))
+	if err != nil {
+		return err
+	}
+
+	// Prepare tables & indexes.
+	queries := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			name TEXT PRIMARY KEY,
+			data BLOB NOT NULL
+			)`, statesTableName),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			data BLOB NOT NULL,
+			created_at TEXT NOT NULL
+			)`, statesHistoryTableName),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (name, id)`, statesHistoryIndexName, statesHistoryTableName),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			name TEXT PRIMARY KEY,
+			id TEXT NOT NULL,
+			info BLOB NOT NULL
+			)`, locksTableName),
+	}
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			db.Close()
+			return fmt.Errorf("failed to prepare the SQLite database at %s: %w", b.path, err)
+		}
+	}
+
+	// Assign db after its tables are prepared.
+	b.db = db
+
+	return nil
+}
+
+// databaseURI returns a "file:" URI for the database file at the given path.
+// The path is escaped, so that characters like "?" and "#" in it are not
+// mistaken for the start of the parameters.
+func databaseURI(path string, params url.Values) string {
+	path = filepath.ToSlash(path)
+	if filepath.VolumeName(path) != "" {
+		// SQLite expects a slash before the drive letter of a Windows path
+		path = "/" + path
+	}
+	u := url.URL{
+		Scheme:   "file",
+		Opaque:   (&url.URL{Path: path}).EscapedPath(),
+		RawQuery: params.Encode(),
+	}
+	return u.String()
+}
@@ -0,0 +1,187 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/encryption/enctest"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// testBackendConfig returns the configuration of a backend that uses a new
+// database file in a temporary directory.
+func testBackendConfig(t *testing.T) map[string]interface{} {
+	return map[string]interface{}{
+		"path": filepath.Join(t.TempDir(), "state.db"),
+	}
+}
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackendConfig(t *testing.T) {
+	config := testBackendConfig(t)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
+
+	if b.path != config["path"] {
+		t.Fatalf("unexpected path %q", b.path)
+	}
+	if b.historyLimit != 10 {
+		t.Fatalf("expected the default history limit of 10, got %d", b.historyLimit)
+	}
+
+	// The tables are created when the backend is configured
+	for _, table := range []string{statesTableName, statesHistoryTableName, locksTableName} {
+		var name string
+		err := b.db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&name)
+		if err != nil {
+			t.Fatalf("table %s was not created: %s", table, err)
+		}
+	}
+}
+
+func TestBackendConfig_pathWithSpecialCharacters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state?mode=ro#x%20.db")
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
+		"path":         path,
+		"busy_timeout": 1234,
+	})).(*Backend)
+
+	// The database is created at the exact path, not at a path cut off at the
+	// first special character
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(path) {
+		t.Fatalf("expected only %q to be created, got %v", filepath.Base(path), entries)
+	}
+
+	// The parameters are still applied
+	var busyTimeout int
+	if err := b.db.QueryRow(`PRAGMA busy_timeout`).Scan(&busyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if busyTimeout != 1234 {
+		t.Fatalf("expected a busy timeout of 1234, got %d", busyTimeout)
+	}
+}
+
+func TestBackendConfig_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		config map[string]interface{}
+		want   string
+	}{
+		"missing path": {
+			config: map[string]interface{}{},
+			want:   "path must be set",
+		},
+		"negative history_limit": {
+			config: map[string]interface{}{
+				"path":          filepath.Join(t.TempDir(), "state.db"),
+				"history_limit": -1,
+			},
+			want: "history_limit must not be negative",
+		},
+		"negative busy_timeout": {
+			config: map[string]interface{}{
+				"path":         filepath.Join(t.TempDir(), "state.db"),
+				"busy_timeout": -1,
+			},
+			want: "busy_timeout must not be negative",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TF_SQLITE_PATH", "")
+			b := New(encryption.StateEncryptionDisabled())
+			config := backend.TestWrapConfig(tc.config)
+
+			var diags tfdiags.Diagnostics
+			obj, decDiags := hcldec.Decode(config, b.ConfigSchema().DecoderSpec(), nil)
+			diags = diags.Append(decDiags)
+			newObj, valDiags := b.PrepareConfig(obj)
+			diags = diags.Append(valDiags.InConfigBody(config, ""))
+			if !diags.HasErrors() {
+				diags = diags.Append(b.Configure(newObj))
+			}
+
+			if !diags.HasErrors() {
+				t.Fatal("error expected but got none")
+			}
+			if !strings.Contains(diags.ErrWithWarnings().Error(), tc.want) {
+				t.Fatalf("failed to find %q in %s", tc.want, diags.ErrWithWarnings())
+			}
+		})
+	}
+}
+
+func TestBackendStates(t *testing.T) {
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(t))).(*Backend)
+	backend.TestBackendStates(t, b)
+}
+
+func TestBackendStateLocks(t *testing.T) {
+	config := backend.TestWrapConfig(testBackendConfig(t))
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Backend)
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Backend)
+	backend.TestBackendStateLocks(t, b1, b2)
+	backend.TestBackendStateForceUnlock(t, b1, b2)
+}
+
+func TestBackendEncryption(t *testing.T) {
+	b := backend.TestBackendConfig(t, New(enctest.EncryptionRequired().State()), backend.TestWrapConfig(testBackendConfig(t))).(*Backend)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := states.NewState()
+	state.RootModule().SetOutputValue("secret", cty.StringVal("hunter2"), false)
+	if err := s.WriteState(state); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PersistState(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range []string{statesTableName, statesHistoryTableName} {
+		var data []byte
+		err := b.db.QueryRow(`SELECT data FROM `+table+` WHERE name = ? ORDER BY rowid DESC LIMIT 1`, backend.DefaultStateName).Scan(&data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "hunter2") {
+			t.Fatalf("the state in the %s table is not encrypted:\n%s", table, data)
+		}
+	}
+
+	// A second backend with the same encryption configuration can read it
+	b2 := backend.TestBackendConfig(t, New(enctest.EncryptionRequired().State()), backend.TestWrapConfig(map[string]interface{}{"path": b.path})).(*Backend)
+	s2, err := b2.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s2.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s2.State().RootModule().OutputValues["secret"]; got == nil || !got.Value.RawEquals(cty.StringVal("hunter2")) {
+		t.Fatalf("unexpected output value %#v", got)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sqlite
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// RemoteClient is a remote client that stores data in a SQLite database
+type RemoteClient struct {
+	Client *sql.DB
+	Name   string
+	// Path is the path of the database file, which is reported in the lock
+	// info.
+	Path string
+	// HistoryLimit is the number of earlier versions of the state to keep
+	// in the history table. History is disabled when it is 0.
+	HistoryLimit int
+}
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	query := `SELECT data FROM %s WHERE name = ?`
+	row := c.Client.QueryRow(fmt.Sprintf(query, statesTableName), c.Name)
+	var data []byte
+	err := row.Scan(&data)
+	switch {
+	case err == sql.ErrNoRows:
+		// No existing state returns empty.
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		md5 := md5.Sum(data)
+		return &remote.Payload{
+			Data: data,
+			MD5:  md5[:],
+		}, nil
+	}
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	tx, err := c.Client.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO %s (name, data) VALUES (?, ?)
+		ON CONFLICT (name) DO UPDATE SET data = excluded.data`
+	_, err = tx.Exec(fmt.Sprintf(query, statesTableName), c.Name, data)
+	if err != nil {
+		return err
+	}
+
+	if c.HistoryLimit > 0 {
+		query = `INSERT INTO %s (name, data, created_at) VALUES (?, ?, ?)`
+		_, err = tx.Exec(fmt.Sprintf(query, statesHistoryTableName), c.Name, data, time.Now().UTC().Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		// Only keep the newest versions
+		query = `DELETE FROM %s WHERE name = ? AND id NOT IN (
+			SELECT id FROM %s WHERE name = ? ORDER BY id DESC LIMIT ?)`
+		_, err = tx.Exec(fmt.Sprintf(query, statesHistoryTableName, statesHistoryTableName), c.Name, c.Name, c.HistoryLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (c *RemoteClient) Delete() error {
+	tx, err := c.Client.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM %s WHERE name = ?`
+	for _, table := range []string{statesTableName, statesHistoryTableName} {
+		if _, err := tx.Exec(fmt.Sprintf(query, table), c.Name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListVersions returns the versions of the state kept in the history table,
+// newest first.
+func (c *RemoteClient) ListVersions() ([]statemgr.StateVersion, error) {
+	if c.HistoryLimit == 0 {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	query := `SELECT h.id, h.created_at, length(h.data), h.data IS s.data
+		FROM %s h LEFT JOIN %s s ON s.name = h.name
+		WHERE h.name = ? ORDER BY h.id DESC`
+	rows, err := c.Client.Query(fmt.Sprintf(query, statesHistoryTableName, statesTableName), c.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []statemgr.StateVersion
+	for rows.Next() {
+		var id, size int64
+		var created string
+		var matchesCurrent bool
+		if err := rows.Scan(&id, &created, &size, &matchesCurrent); err != nil {
+			return nil, err
+		}
+		version := statemgr.StateVersion{
+			ID:   strconv.FormatInt(id, 10),
+			Size: size,
+			// An older version may have the same data if it was restored
+			Current: matchesCurrent && len(versions) == 0,
+		}
+		version.Created, _ = time.Parse(time.RFC3339Nano, created)
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetVersion reads the version of the state with the given ID from the
+// history table. It returns nil if the version doesn't exist.
+func (c *RemoteClient) GetVersion(id string) (*remote.Payload, error) {
+	if c.HistoryLimit == 0 {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+
+	historyID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("state version ID should be a number, got %q", id)
+	}
+
+	query := `SELECT data FROM %s WHERE name = ? AND id = ?`
+	row := c.Client.QueryRow(fmt.Sprintf(query, statesHistoryTableName), c.Name, historyID)
+	var data []byte
+	err = row.Scan(&data)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		md5 := md5.Sum(data)
+		return &remote.Payload{
+			Data: data,
+			MD5:  md5[:],
+		}, nil
+	}
+}
+
+// Lock adds a row for the workspace to the locks table. The check for an
+// existing lock and the insert happen in one transaction, which holds the
+// write lock of the database, so only one client can take the lock.
+func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
+	if info.ID == "" {
+		lockID, err := uuid.GenerateUUID()
+		if err != nil {
+			return "", err
+		}
+		info.ID = lockID
+	}
+	info.Path = c.Path
+
+	tx, err := c.Client.Begin()
+	if err != nil {
+		return "", &statemgr.LockError{Info: info, Err: err}
+	}
+	defer tx.Rollback()
+
+	existing, err := c.lockInfo(tx)
+	if err != nil {
+		return "", &statemgr.LockError{Info: info, Err: err}
+	}
+	if existing != nil {
+		return "", &statemgr.LockError{Info: existing, Err: fmt.Errorf("Workspace is already locked: %s", c.Name)}
+	}
+
+	query := `INSERT INTO %s (name, id, info) VALUES (?, ?, ?)`
+	if _, err := tx.Exec(fmt.Sprintf(query, locksTableName), c.Name, info.ID, info.Marshal()); err != nil {
+		return "", &statemgr.LockError{Info: info, Err: err}
+	}
+	if err := tx.Commit(); err != nil {
+		return "", &statemgr.LockError{Info: info, Err: err}
+	}
+
+	return info.ID, nil
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	tx, err := c.Client.Begin()
+	if err != nil {
+		return &statemgr.LockError{Err: err}
+	}
+	defer tx.Rollback()
+
+	existing, err := c.lockInfo(tx)
+	if err != nil {
+		return &statemgr.LockError{Err: err}
+	}
+	if existing == nil {
+		return &statemgr.LockError{Err: fmt.Errorf("Workspace is not locked: %s", c.Name)}
+	}
+	if existing.ID != id {
+		return &statemgr.LockError{Info: existing, Err: fmt.Errorf("lock id %q does not match existing lock", id)}
+	}
+
+	query := `DELETE FROM %s WHERE name = ? AND id = ?`
+	if _, err := tx.Exec(fmt.Sprintf(query, locksTableName), c.Name, id); err != nil {
+		return &statemgr.LockError{Info: existing, Err: err}
+	}
+	if err := tx.Commit(); err != nil {
+		return &statemgr.LockError{Info: existing, Err: err}
+	}
+
+	return nil
+}
+
+// lockInfo returns the lock info of the workspace in the given transaction,
+// or nil if the workspace is not locked.
+func (c *RemoteClient) lockInfo(tx *sql.Tx) (*statemgr.LockInfo, error) {
+	query := `SELECT info FROM %s WHERE name = ?`
+	row := tx.QueryRow(fmt.Sprintf(query, locksTableName), c.Name)
+	var data []byte
+	err := row.Scan(&data)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("failed to read the lock info: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestRemoteClient_impl(t *testing.T) {
+	var _ remote.Client = new(RemoteClient)
+	var _ remote.ClientLocker = new(RemoteClient)
+	var _ remote.ClientHistorian = new(RemoteClient)
+}
+
+func TestRemoteClient(t *testing.T) {
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(testBackendConfig(t))).(*Backend)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote.TestClient(t, s.(*remote.State).Client)
+}
+
+func TestRemoteLocks(t *testing.T) {
+	config := backend.TestWrapConfig(testBackendConfig(t))
+
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Backend)
+	s1, err := b1.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), config).(*Backend)
+	s2, err := b2.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote.TestRemoteLocks(t, s1.(*remote.State).Client, s2.(*remote.State).Client)
+}
+
+func TestRemoteClient_history(t *testing.T) {
+	config := testBackendConfig(t)
+	config["history_limit"] = 2
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := s.(*remote.State).Client.(*RemoteClient)
+
+	for _, data := range []string{"one", "two", "three"} {
+		if err := client.Put([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := client.ListVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Fatalf("expected only the newest version to be current: %#v", versions)
+	}
+	if versions[0].Created.IsZero() || versions[0].Size != int64(len("three")) {
+		t.Fatalf("unexpected newest version: %#v", versions[0])
+	}
+
+	payload, err := client.GetVersion(versions[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload == nil || string(payload.Data) != "two" {
+		t.Fatalf("unexpected payload for version %s: %#v", versions[1].ID, payload)
+	}
+
+	payload, err = client.GetVersion("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != nil {
+		t.Fatalf("expected no payload for an unknown version, got %#v", payload)
+	}
+
+	// Deleting the workspace also deletes its history
+	if err := client.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	versions, err = client.ListVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 0 {
+		t.Fatalf("expected no versions after delete, got %#v", versions)
+	}
+}
+
+func TestRemoteClient_historyDisabled(t *testing.T) {
+	config := testBackendConfig(t)
+	config["history_limit"] = 0
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := s.(*remote.State).Client.(*RemoteClient)
+
+	if err := client.Put([]byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := b.db.QueryRow(`SELECT count(*) FROM ` + statesHistoryTableName).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no history rows, got %d", count)
+	}
+	if _, err := client.ListVersions(); !errors.Is(err, statemgr.ErrHistoryUnsupported) {
+		t.Fatalf("expected ErrHistoryUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Backend is the interface that is exposed over the backend plugin protocol.
+//
+// It is the part of backend.Backend that configures the backend and manages
+// its workspaces. Instead of state managers, a Backend returns the
+// remote.Client that stores the state of each workspace, because the state
+// is serialized and encrypted by OpenTofu before it is sent to the plugin.
+type Backend interface {
+	// ConfigSchema returns a description of the expected configuration
+	// structure for the backend.
+	ConfigSchema() *configschema.Block
+
+	// PrepareConfig checks the validity of the values in the given
+	// configuration, and inserts any missing defaults.
+	PrepareConfig(cty.Value) (cty.Value, tfdiags.Diagnostics)
+
+	// Configure uses the provided configuration to set configuration fields
+	// within the backend.
+	Configure(cty.Value) tfdiags.Diagnostics
+
+	// Workspaces returns a list of the names of all of the workspaces that
+	// exist in the backend.
+	Workspaces() ([]string, error)
+
+	// DeleteWorkspace removes the workspace with the given name if it exists.
+	DeleteWorkspace(name string, force bool) error
+
+	// StateClient returns the client that stores the state of the given
+	// workspace. If the client also implements remote.ClientLocker, the
+	// state of the workspace can be locked.
+	StateClient(workspace string) (remote.Client, error)
+}
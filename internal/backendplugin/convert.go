@@ -0,0 +1,188 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/msgpack"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	proto "github.com/opentofu/opentofu/internal/tfbackend1"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ConfigSchemaToProto takes a *configschema.Block and converts it to a
+// proto.Schema_Block for a grpc response.
+func ConfigSchemaToProto(b *configschema.Block) *proto.Schema_Block {
+	block := &proto.Schema_Block{
+		Description: b.Description,
+		Deprecated:  b.Deprecated,
+	}
+
+	for _, name := range sortedKeys(b.Attributes) {
+		a := b.Attributes[name]
+
+		ty, err := json.Marshal(a.Type)
+		if err != nil {
+			panic(err)
+		}
+
+		block.Attributes = append(block.Attributes, &proto.Schema_Attribute{
+			Name:        name,
+			Type:        ty,
+			Description: a.Description,
+			Required:    a.Required,
+			Optional:    a.Optional,
+			Computed:    a.Computed,
+			Sensitive:   a.Sensitive,
+			Deprecated:  a.Deprecated,
+		})
+	}
+
+	for _, name := range sortedKeys(b.BlockTypes) {
+		nb := b.BlockTypes[name]
+		block.BlockTypes = append(block.BlockTypes, &proto.Schema_NestedBlock{
+			TypeName: name,
+			Block:    ConfigSchemaToProto(&nb.Block),
+			Nesting:  nestingModeToProto[nb.Nesting],
+			MinItems: int64(nb.MinItems),
+			MaxItems: int64(nb.MaxItems),
+		})
+	}
+
+	return block
+}
+
+// ProtoToConfigSchema takes the proto.Schema_Block from a grpc response and
+// converts it to a *configschema.Block.
+func ProtoToConfigSchema(b *proto.Schema_Block) *configschema.Block {
+	block := &configschema.Block{
+		Attributes: make(map[string]*configschema.Attribute),
+		BlockTypes: make(map[string]*configschema.NestedBlock),
+
+		Description: b.Description,
+		Deprecated:  b.Deprecated,
+	}
+
+	for _, a := range b.Attributes {
+		attr := &configschema.Attribute{
+			Description: a.Description,
+			Required:    a.Required,
+			Optional:    a.Optional,
+			Computed:    a.Computed,
+			Sensitive:   a.Sensitive,
+			Deprecated:  a.Deprecated,
+		}
+
+		if err := json.Unmarshal(a.Type, &attr.Type); err != nil {
+			panic(err)
+		}
+
+		block.Attributes[a.Name] = attr
+	}
+
+	for _, nb := range b.BlockTypes {
+		nested := &configschema.NestedBlock{
+			Nesting:  protoToNestingMode[nb.Nesting],
+			MinItems: int(nb.MinItems),
+			MaxItems: int(nb.MaxItems),
+		}
+		if nb.Block != nil {
+			nested.Block = *ProtoToConfigSchema(nb.Block)
+		}
+		block.BlockTypes[nb.TypeName] = nested
+	}
+
+	return block
+}
+
+var nestingModeToProto = map[configschema.NestingMode]proto.Schema_NestedBlock_NestingMode{
+	configschema.NestingSingle: proto.Schema_NestedBlock_SINGLE,
+	configschema.NestingGroup:  proto.Schema_NestedBlock_GROUP,
+	configschema.NestingList:   proto.Schema_NestedBlock_LIST,
+	configschema.NestingSet:    proto.Schema_NestedBlock_SET,
+	configschema.NestingMap:    proto.Schema_NestedBlock_MAP,
+}
+
+var protoToNestingMode = map[proto.Schema_NestedBlock_NestingMode]configschema.NestingMode{
+	proto.Schema_NestedBlock_SINGLE: configschema.NestingSingle,
+	proto.Schema_NestedBlock_GROUP:  configschema.NestingGroup,
+	proto.Schema_NestedBlock_LIST:   configschema.NestingList,
+	proto.Schema_NestedBlock_SET:    configschema.NestingSet,
+	proto.Schema_NestedBlock_MAP:    configschema.NestingMap,
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiagnosticsToProto converts diagnostics to proto.Diagnostics for a grpc
+// response.
+func DiagnosticsToProto(diags tfdiags.Diagnostics) []*proto.Diagnostic {
+	var ret []*proto.Diagnostic
+	for _, d := range diags {
+		desc := d.Description()
+		severity := proto.Diagnostic_ERROR
+		if d.Severity() == tfdiags.Warning {
+			severity = proto.Diagnostic_WARNING
+		}
+		ret = append(ret, &proto.Diagnostic{
+			Severity: severity,
+			Summary:  desc.Summary,
+			Detail:   desc.Detail,
+		})
+	}
+	return ret
+}
+
+// ProtoToDiagnostics converts the proto.Diagnostics of a grpc response to
+// diagnostics.
+func ProtoToDiagnostics(ds []*proto.Diagnostic) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, d := range ds {
+		severity := tfdiags.Error
+		if d.Severity == proto.Diagnostic_WARNING {
+			severity = tfdiags.Warning
+		}
+		diags = diags.Append(tfdiags.WholeContainingBody(severity, d.Summary, d.Detail))
+	}
+	return diags
+}
+
+// errorDiagnostics returns the error as proto.Diagnostics, or nil if there
+// is no error.
+func errorDiagnostics(err error) []*proto.Diagnostic {
+	if err == nil {
+		return nil
+	}
+	return DiagnosticsToProto(tfdiags.Diagnostics(nil).Append(err))
+}
+
+// dynamicValue encodes the value as a proto.DynamicValue of the given type.
+func dynamicValue(v cty.Value, ty cty.Type) (*proto.DynamicValue, error) {
+	mp, err := msgpack.Marshal(v, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DynamicValue{Msgpack: mp}, nil
+}
+
+// decodeDynamicValue decodes a proto.DynamicValue as a value of the given
+// type. A missing value decodes as null.
+func decodeDynamicValue(v *proto.DynamicValue, ty cty.Type) (cty.Value, error) {
+	if v == nil {
+		return cty.NullVal(ty), nil
+	}
+	return msgpack.Unmarshal(v.Msgpack, ty)
+}
@@ -0,0 +1,13 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package backendplugin implements version 1 of the backend plugin protocol,
+// defined in docs/plugin-protocol/tfbackend1.0.proto, which lets a backend
+// that is not compiled into OpenTofu store the state of each workspace.
+//
+// GRPCBackend is the client side of the protocol, used by the "plugin"
+// backend. Serve is the server side, used by the main function of a backend
+// plugin to expose an implementation of Backend.
+package backendplugin
@@ -0,0 +1,292 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/logging"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	proto "github.com/opentofu/opentofu/internal/tfbackend1"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+var logger = logging.HCLogger()
+
+// GRPCBackend handles the client, or core side of the plugin rpc connection.
+// The GRPCBackend methods are mostly a translation layer between the
+// Backend interface and the grpc proto types.
+type GRPCBackend struct {
+	// PluginClient provides a reference to the plugin.Client which controls
+	// the plugin process. This allows the GRPCBackend a way to shutdown the
+	// plugin process.
+	PluginClient *plugin.Client
+
+	// Proto client use to make the grpc service calls.
+	client proto.BackendClient
+
+	// this context is created by the plugin package, and is canceled when the
+	// plugin process ends.
+	ctx context.Context
+
+	mu sync.Mutex
+	// schema stores the configuration schema of the backend, so that it is
+	// only requested once.
+	schema *configschema.Block
+}
+
+var _ Backend = (*GRPCBackend)(nil)
+
+// GetSchema returns the configuration schema of the backend.
+func (b *GRPCBackend) GetSchema() (*configschema.Block, tfdiags.Diagnostics) {
+	logger.Trace("GRPCBackend: GetSchema")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.schema != nil {
+		return b.schema, nil
+	}
+
+	var diags tfdiags.Diagnostics
+	protoResp, err := b.client.GetSchema(b.ctx, new(proto.GetSchema_Request))
+	if err != nil {
+		return nil, diags.Append(grpcErr(err))
+	}
+
+	diags = diags.Append(ProtoToDiagnostics(protoResp.Diagnostics))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if protoResp.Schema == nil || protoResp.Schema.Block == nil {
+		return nil, diags.Append(errors.New("missing backend schema"))
+	}
+
+	b.schema = ProtoToConfigSchema(protoResp.Schema.Block)
+	return b.schema, diags
+}
+
+// ConfigSchema returns the configuration schema of the backend, or an empty
+// schema if it can't be retrieved. Use GetSchema to get the errors.
+func (b *GRPCBackend) ConfigSchema() *configschema.Block {
+	schema, diags := b.GetSchema()
+	if diags.HasErrors() {
+		logger.Error("GRPCBackend: failed to get the backend schema", "error", diags.Err())
+		return &configschema.Block{}
+	}
+	return schema
+}
+
+func (b *GRPCBackend) PrepareConfig(config cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	logger.Trace("GRPCBackend: PrepareConfig")
+
+	schema, diags := b.GetSchema()
+	if diags.HasErrors() {
+		return config, diags
+	}
+	ty := schema.ImpliedType()
+
+	mp, err := dynamicValue(config, ty)
+	if err != nil {
+		return config, diags.Append(err)
+	}
+
+	protoResp, err := b.client.PrepareConfig(b.ctx, &proto.PrepareConfig_Request{Config: mp})
+	if err != nil {
+		return config, diags.Append(grpcErr(err))
+	}
+
+	diags = diags.Append(ProtoToDiagnostics(protoResp.Diagnostics))
+	if diags.HasErrors() {
+		return config, diags
+	}
+
+	prepared, err := decodeDynamicValue(protoResp.PreparedConfig, ty)
+	if err != nil {
+		return config, diags.Append(err)
+	}
+	return prepared, diags
+}
+
+func (b *GRPCBackend) Configure(config cty.Value) tfdiags.Diagnostics {
+	logger.Trace("GRPCBackend: Configure")
+
+	schema, diags := b.GetSchema()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	mp, err := dynamicValue(config, schema.ImpliedType())
+	if err != nil {
+		return diags.Append(err)
+	}
+
+	protoResp, err := b.client.Configure(b.ctx, &proto.Configure_Request{Config: mp})
+	if err != nil {
+		return diags.Append(grpcErr(err))
+	}
+	return diags.Append(ProtoToDiagnostics(protoResp.Diagnostics))
+}
+
+func (b *GRPCBackend) Workspaces() ([]string, error) {
+	logger.Trace("GRPCBackend: Workspaces")
+
+	protoResp, err := b.client.Workspaces(b.ctx, new(proto.Workspaces_Request))
+	if err != nil {
+		return nil, grpcErr(err).Err()
+	}
+	if err := ProtoToDiagnostics(protoResp.Diagnostics).Err(); err != nil {
+		return nil, err
+	}
+	return protoResp.Workspaces, nil
+}
+
+func (b *GRPCBackend) DeleteWorkspace(name string, force bool) error {
+	logger.Trace("GRPCBackend: DeleteWorkspace")
+
+	protoResp, err := b.client.DeleteWorkspace(b.ctx, &proto.DeleteWorkspace_Request{
+		Workspace: name,
+		Force:     force,
+	})
+	if err != nil {
+		return grpcErr(err).Err()
+	}
+	return ProtoToDiagnostics(protoResp.Diagnostics).Err()
+}
+
+// StateClient returns a remote.Client that stores the state of the given
+// workspace through the plugin. The client also implements
+// remote.ClientLocker.
+func (b *GRPCBackend) StateClient(workspace string) (remote.Client, error) {
+	return &RemoteClient{
+		backend:   b,
+		workspace: workspace,
+	}, nil
+}
+
+// Close kills the plugin process.
+func (b *GRPCBackend) Close() error {
+	logger.Trace("GRPCBackend: Close")
+
+	// Make sure to stop the server if we're not running within go-plugin.
+	if b.PluginClient == nil {
+		return nil
+	}
+
+	b.PluginClient.Kill()
+	return nil
+}
+
+// RemoteClient is a remote.Client that stores the state of a workspace
+// through a backend plugin.
+type RemoteClient struct {
+	backend   *GRPCBackend
+	workspace string
+}
+
+var (
+	_ remote.Client       = (*RemoteClient)(nil)
+	_ remote.ClientLocker = (*RemoteClient)(nil)
+)
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	logger.Trace("GRPCBackend: GetState", "workspace", c.workspace)
+
+	protoResp, err := c.backend.client.GetState(c.backend.ctx, &proto.GetState_Request{Workspace: c.workspace})
+	if err != nil {
+		return nil, grpcErr(err).Err()
+	}
+	if err := ProtoToDiagnostics(protoResp.Diagnostics).Err(); err != nil {
+		return nil, err
+	}
+	if !protoResp.Exists {
+		return nil, nil
+	}
+
+	return &remote.Payload{
+		Data: protoResp.Data,
+		MD5:  protoResp.Md5,
+	}, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	logger.Trace("GRPCBackend: PutState", "workspace", c.workspace)
+
+	protoResp, err := c.backend.client.PutState(c.backend.ctx, &proto.PutState_Request{
+		Workspace: c.workspace,
+		Data:      data,
+	})
+	if err != nil {
+		return grpcErr(err).Err()
+	}
+	return ProtoToDiagnostics(protoResp.Diagnostics).Err()
+}
+
+func (c *RemoteClient) Delete() error {
+	logger.Trace("GRPCBackend: DeleteState", "workspace", c.workspace)
+
+	protoResp, err := c.backend.client.DeleteState(c.backend.ctx, &proto.DeleteState_Request{Workspace: c.workspace})
+	if err != nil {
+		return grpcErr(err).Err()
+	}
+	return ProtoToDiagnostics(protoResp.Diagnostics).Err()
+}
+
+func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
+	logger.Trace("GRPCBackend: LockState", "workspace", c.workspace)
+
+	protoResp, err := c.backend.client.LockState(c.backend.ctx, &proto.LockState_Request{
+		Workspace: c.workspace,
+		Info:      info.Marshal(),
+	})
+	if err != nil {
+		return "", grpcErr(err).Err()
+	}
+	if err := protoToLockError(protoResp.HeldLockInfo, protoResp.Diagnostics); err != nil {
+		return "", err
+	}
+	return protoResp.LockId, nil
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	logger.Trace("GRPCBackend: UnlockState", "workspace", c.workspace)
+
+	protoResp, err := c.backend.client.UnlockState(c.backend.ctx, &proto.UnlockState_Request{
+		Workspace: c.workspace,
+		LockId:    id,
+	})
+	if err != nil {
+		return grpcErr(err).Err()
+	}
+	return protoToLockError(protoResp.HeldLockInfo, protoResp.Diagnostics)
+}
+
+// protoToLockError returns the error of a LockState or UnlockState response,
+// as a *statemgr.LockError if the response includes the lock info of the
+// current holder of the lock.
+func protoToLockError(heldLockInfo []byte, protoDiags []*proto.Diagnostic) error {
+	err := ProtoToDiagnostics(protoDiags).Err()
+	if len(heldLockInfo) == 0 {
+		return err
+	}
+
+	info := &statemgr.LockInfo{}
+	if jsonErr := json.Unmarshal(heldLockInfo, info); jsonErr != nil {
+		return fmt.Errorf("invalid lock info from backend plugin: %w", jsonErr)
+	}
+	return &statemgr.LockError{
+		Info: info,
+		Err:  err,
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	proto "github.com/opentofu/opentofu/internal/tfbackend1"
+)
+
+var (
+	typeComparer = cmp.Comparer(cty.Type.Equals)
+	equateEmpty  = cmpopts.EquateEmpty()
+)
+
+// testGRPCBackend returns a GRPCBackend that is connected over gRPC to a
+// server for the given backend.
+func testGRPCBackend(t *testing.T, b Backend) *GRPCBackend {
+	t.Helper()
+
+	client, server := plugin.TestPluginGRPCConn(t, PluginSet(func() proto.BackendServer {
+		return NewGRPCServer(b)
+	}))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense(BackendPluginName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw.(*GRPCBackend)
+}
+
+// testConfiguredGRPCBackend returns a GRPCBackend for a TestBackend that
+// stores the states in a temporary directory.
+func testConfiguredGRPCBackend(t *testing.T, lock bool) *GRPCBackend {
+	t.Helper()
+
+	b := testGRPCBackend(t, &TestBackend{})
+	diags := b.Configure(cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(t.TempDir()),
+		"lock": cty.BoolVal(lock),
+	}))
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	return b
+}
+
+func TestGRPCBackend_config(t *testing.T) {
+	impl := &TestBackend{}
+	b := testGRPCBackend(t, impl)
+
+	schema, diags := b.GetSchema()
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	if diff := cmp.Diff(impl.ConfigSchema(), schema, typeComparer, equateEmpty); diff != "" {
+		t.Fatalf("wrong schema\n%s", diff)
+	}
+
+	path := t.TempDir()
+	prepared, diags := b.PrepareConfig(cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(path),
+		"lock": cty.NullVal(cty.Bool),
+	}))
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	if got, want := prepared.GetAttr("lock"), cty.True; !got.RawEquals(want) {
+		t.Fatalf("wrong prepared lock %#v, want %#v", got, want)
+	}
+
+	_, diags = b.PrepareConfig(cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(""),
+		"lock": cty.NullVal(cty.Bool),
+	}))
+	if got, want := diags.Err().Error(), "Invalid path: The path must not be empty."; got != want {
+		t.Fatalf("wrong error %q, want %q", got, want)
+	}
+
+	if diags := b.Configure(prepared); diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	if impl.Path != path || !impl.Lock {
+		t.Fatalf("wrong configuration %#v", impl)
+	}
+}
+
+func TestGRPCBackend_workspaces(t *testing.T) {
+	b := testConfiguredGRPCBackend(t, true)
+
+	c, err := b.StateClient("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put([]byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	workspaces, err := b.Workspaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"default", "foo"}, workspaces); diff != "" {
+		t.Fatalf("wrong workspaces\n%s", diff)
+	}
+
+	if err := b.DeleteWorkspace("default", false); err == nil {
+		t.Fatal("expected an error deleting the default workspace")
+	}
+	if err := b.DeleteWorkspace("foo", false); err != nil {
+		t.Fatal(err)
+	}
+
+	workspaces, err = b.Workspaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"default"}, workspaces); diff != "" {
+		t.Fatalf("wrong workspaces\n%s", diff)
+	}
+}
+
+func TestGRPCBackend_stateClient(t *testing.T) {
+	b := testConfiguredGRPCBackend(t, true)
+
+	a, err := b.StateClient("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote.TestClient(t, a)
+
+	c, err := b.StateClient("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote.TestRemoteLocks(t, a, c)
+}
+
+func TestGRPCBackend_lockError(t *testing.T) {
+	b := testConfiguredGRPCBackend(t, true)
+
+	c, err := b.StateClient("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	locker := c.(remote.ClientLocker)
+
+	info := statemgr.NewLockInfo()
+	info.Operation = "test"
+	info.Who = "someone"
+	if _, err := locker.Lock(info); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = locker.Lock(statemgr.NewLockInfo())
+	var lockErr *statemgr.LockError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected a LockError, got %#v", err)
+	}
+	if lockErr.Info.ID != info.ID || lockErr.Info.Who != "someone" {
+		t.Fatalf("wrong lock info %#v", lockErr.Info)
+	}
+
+	err = locker.Unlock("wrong")
+	if !errors.As(err, &lockErr) || lockErr.Info.ID != info.ID {
+		t.Fatalf("expected a LockError with the held lock, got %#v", err)
+	}
+}
+
+func TestGRPCBackend_noLocking(t *testing.T) {
+	b := testConfiguredGRPCBackend(t, false)
+
+	c, err := b.StateClient("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	locker := c.(remote.ClientLocker)
+
+	// Without locking, every lock succeeds with an empty ID
+	for i := 0; i < 2; i++ {
+		id, err := locker.Lock(statemgr.NewLockInfo())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "" {
+			t.Fatalf("unexpected lock ID %q", id)
+		}
+	}
+	if err := locker.Unlock(""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigSchemaToProto_roundTrip(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"endpoints": {Type: cty.List(cty.String), Required: true},
+			"password":  {Type: cty.String, Optional: true, Sensitive: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"retry": {
+				Nesting: configschema.NestingSingle,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"attempts": {Type: cty.Number, Optional: true},
+					},
+				},
+			},
+		},
+	}
+
+	got := ProtoToConfigSchema(ConfigSchemaToProto(schema))
+	if diff := cmp.Diff(schema, got, typeComparer, equateEmpty); diff != "" {
+		t.Fatalf("wrong schema\n%s", diff)
+	}
+}
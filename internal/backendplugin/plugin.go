@@ -0,0 +1,64 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"context"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	proto "github.com/opentofu/opentofu/internal/tfbackend1"
+)
+
+const (
+	// BackendPluginName is the name of the plugin that is dispensed from the
+	// plugin server.
+	BackendPluginName = "backend"
+
+	// ProtocolVersion is the version of the backend plugin protocol.
+	ProtocolVersion = 1
+)
+
+// Handshake is the HandshakeConfig used to configure clients and servers.
+var Handshake = plugin.HandshakeConfig{
+	// The ProtocolVersion is the version that must match between OpenTofu
+	// and backend plugins.
+	ProtocolVersion: ProtocolVersion,
+
+	// The magic cookie values should NEVER be changed. They are different
+	// from those of provider plugins, so that a provider can't be mistaken
+	// for a backend plugin.
+	MagicCookieKey:   "TF_BACKEND_PLUGIN_MAGIC_COOKIE",
+	MagicCookieValue: "84b270e8c5a170d8b38b242f7b6b4e90a6471c0c525a04da1b6eab4d045019a3",
+}
+
+// PluginSet returns the plugins that a backend plugin serves, or that a
+// client dispenses, for the given server implementation. The server may be
+// nil on the client side.
+func PluginSet(server func() proto.BackendServer) plugin.PluginSet {
+	return plugin.PluginSet{
+		BackendPluginName: &GRPCBackendPlugin{GRPCBackend: server},
+	}
+}
+
+// GRPCBackendPlugin implements plugin.GRPCPlugin for the go-plugin package.
+type GRPCBackendPlugin struct {
+	plugin.Plugin
+	GRPCBackend func() proto.BackendServer
+}
+
+func (p *GRPCBackendPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCBackend{
+		client: proto.NewBackendClient(c),
+		ctx:    ctx,
+	}, nil
+}
+
+func (p *GRPCBackendPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterBackendServer(s, p.GRPCBackend())
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	plugin "github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	proto "github.com/opentofu/opentofu/internal/tfbackend1"
+)
+
+// ServeOpts are the configurations to serve a backend plugin.
+type ServeOpts struct {
+	// Backend returns a new, unconfigured instance of the backend.
+	Backend func() Backend
+}
+
+// Serve serves a backend plugin. This function never returns and should be
+// the final function called in the main function of the plugin.
+func Serve(opts *ServeOpts) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: PluginSet(func() proto.BackendServer {
+			return NewGRPCServer(opts.Backend())
+		}),
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+// NewGRPCServer returns the server, or plugin side of the plugin rpc
+// connection, which exposes the given backend.
+func NewGRPCServer(b Backend) proto.BackendServer {
+	return &grpcServer{
+		backend: b,
+		clients: make(map[string]remote.Client),
+	}
+}
+
+// grpcServer is a translation layer between the grpc proto types and a
+// Backend.
+type grpcServer struct {
+	backend Backend
+
+	// clients holds the state client of each workspace that was used, so
+	// that a client which keeps track of its own locks sees the unlock
+	// request for the lock it acquired.
+	mu      sync.Mutex
+	clients map[string]remote.Client
+}
+
+var _ proto.BackendServer = (*grpcServer)(nil)
+
+func (s *grpcServer) client(workspace string) (remote.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.clients[workspace]; ok {
+		return c, nil
+	}
+	c, err := s.backend.StateClient(workspace)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[workspace] = c
+	return c, nil
+}
+
+func (s *grpcServer) GetSchema(_ context.Context, req *proto.GetSchema_Request) (*proto.GetSchema_Response, error) {
+	return &proto.GetSchema_Response{
+		Schema: &proto.Schema{
+			Block: ConfigSchemaToProto(s.backend.ConfigSchema()),
+		},
+	}, nil
+}
+
+func (s *grpcServer) PrepareConfig(_ context.Context, req *proto.PrepareConfig_Request) (*proto.PrepareConfig_Response, error) {
+	resp := &proto.PrepareConfig_Response{}
+	ty := s.backend.ConfigSchema().ImpliedType()
+
+	config, err := decodeDynamicValue(req.Config, ty)
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+
+	prepared, diags := s.backend.PrepareConfig(config)
+	resp.Diagnostics = DiagnosticsToProto(diags)
+	if diags.HasErrors() {
+		return resp, nil
+	}
+
+	resp.PreparedConfig, err = dynamicValue(prepared, ty)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostics(err)...)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) Configure(_ context.Context, req *proto.Configure_Request) (*proto.Configure_Response, error) {
+	resp := &proto.Configure_Response{}
+
+	config, err := decodeDynamicValue(req.Config, s.backend.ConfigSchema().ImpliedType())
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+
+	resp.Diagnostics = DiagnosticsToProto(s.backend.Configure(config))
+	return resp, nil
+}
+
+func (s *grpcServer) Workspaces(_ context.Context, req *proto.Workspaces_Request) (*proto.Workspaces_Response, error) {
+	workspaces, err := s.backend.Workspaces()
+	return &proto.Workspaces_Response{
+		Workspaces:  workspaces,
+		Diagnostics: errorDiagnostics(err),
+	}, nil
+}
+
+func (s *grpcServer) DeleteWorkspace(_ context.Context, req *proto.DeleteWorkspace_Request) (*proto.DeleteWorkspace_Response, error) {
+	err := s.backend.DeleteWorkspace(req.Workspace, req.Force)
+	if err == nil {
+		s.mu.Lock()
+		delete(s.clients, req.Workspace)
+		s.mu.Unlock()
+	}
+	return &proto.DeleteWorkspace_Response{
+		Diagnostics: errorDiagnostics(err),
+	}, nil
+}
+
+func (s *grpcServer) GetState(_ context.Context, req *proto.GetState_Request) (*proto.GetState_Response, error) {
+	resp := &proto.GetState_Response{}
+
+	c, err := s.client(req.Workspace)
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+
+	payload, err := c.Get()
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+	if payload != nil {
+		resp.Exists = true
+		resp.Data = payload.Data
+		resp.Md5 = payload.MD5
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) PutState(_ context.Context, req *proto.PutState_Request) (*proto.PutState_Response, error) {
+	c, err := s.client(req.Workspace)
+	if err == nil {
+		err = c.Put(req.Data)
+	}
+	return &proto.PutState_Response{
+		Diagnostics: errorDiagnostics(err),
+	}, nil
+}
+
+func (s *grpcServer) DeleteState(_ context.Context, req *proto.DeleteState_Request) (*proto.DeleteState_Response, error) {
+	c, err := s.client(req.Workspace)
+	if err == nil {
+		err = c.Delete()
+	}
+	return &proto.DeleteState_Response{
+		Diagnostics: errorDiagnostics(err),
+	}, nil
+}
+
+func (s *grpcServer) LockState(_ context.Context, req *proto.LockState_Request) (*proto.LockState_Response, error) {
+	resp := &proto.LockState_Response{}
+
+	c, err := s.client(req.Workspace)
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+	locker, ok := c.(remote.ClientLocker)
+	if !ok {
+		return resp, nil
+	}
+
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(req.Info, info); err != nil {
+		resp.Diagnostics = errorDiagnostics(fmt.Errorf("invalid lock info: %w", err))
+		return resp, nil
+	}
+
+	resp.LockId, err = locker.Lock(info)
+	resp.HeldLockInfo, resp.Diagnostics = lockErrorToProto(err)
+	return resp, nil
+}
+
+func (s *grpcServer) UnlockState(_ context.Context, req *proto.UnlockState_Request) (*proto.UnlockState_Response, error) {
+	resp := &proto.UnlockState_Response{}
+
+	c, err := s.client(req.Workspace)
+	if err != nil {
+		resp.Diagnostics = errorDiagnostics(err)
+		return resp, nil
+	}
+	locker, ok := c.(remote.ClientLocker)
+	if !ok {
+		return resp, nil
+	}
+
+	resp.HeldLockInfo, resp.Diagnostics = lockErrorToProto(locker.Unlock(req.LockId))
+	return resp, nil
+}
+
+// lockErrorToProto splits an error returned by Lock or Unlock into the lock
+// info of the current holder of the lock, if any, and the diagnostics.
+func lockErrorToProto(err error) ([]byte, []*proto.Diagnostic) {
+	if err == nil {
+		return nil, nil
+	}
+
+	var lockErr *statemgr.LockError
+	if errors.As(err, &lockErr) && lockErr.Info != nil {
+		if lockErr.Err != nil {
+			err = lockErr.Err
+		} else {
+			err = errors.New("the state is locked")
+		}
+		return lockErr.Info.Marshal(), errorDiagnostics(err)
+	}
+	return nil, errorDiagnostics(err)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// TestBackend is a Backend for testing the backend plugin protocol. It stores
+// the state of each workspace in a file in the directory given by the "path"
+// attribute, so that several plugin processes can share the states.
+//
+// The "lock" attribute defaults to true. If it is false, the states can't
+// be locked.
+type TestBackend struct {
+	Path string
+	Lock bool
+}
+
+var _ Backend = (*TestBackend)(nil)
+
+func (b *TestBackend) ConfigSchema() *configschema.Block {
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"path": {
+				Type:        cty.String,
+				Required:    true,
+				Description: "The directory that stores the states.",
+			},
+			"lock": {
+				Type:        cty.Bool,
+				Optional:    true,
+				Description: "Whether to lock state access.",
+			},
+		},
+	}
+}
+
+func (b *TestBackend) PrepareConfig(config cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if path := config.GetAttr("path"); path.IsNull() || path.AsString() == "" {
+		diags = diags.Append(tfdiags.AttributeValue(
+			tfdiags.Error,
+			"Invalid path",
+			"The path must not be empty.",
+			cty.GetAttrPath("path"),
+		))
+	}
+
+	if config.GetAttr("lock").IsNull() {
+		config = cty.ObjectVal(map[string]cty.Value{
+			"path": config.GetAttr("path"),
+			"lock": cty.True,
+		})
+	}
+	return config, diags
+}
+
+func (b *TestBackend) Configure(config cty.Value) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	b.Path = config.GetAttr("path").AsString()
+	b.Lock = config.GetAttr("lock").True()
+	if err := os.MkdirAll(b.Path, 0o755); err != nil {
+		diags = diags.Append(err)
+	}
+	return diags
+}
+
+func (b *TestBackend) Workspaces() ([]string, error) {
+	entries, err := os.ReadDir(b.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := []string{backend.DefaultStateName}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".tfstate")
+		if ok && name != backend.DefaultStateName {
+			workspaces = append(workspaces, name)
+		}
+	}
+	sort.Strings(workspaces[1:])
+	return workspaces, nil
+}
+
+func (b *TestBackend) DeleteWorkspace(name string, _ bool) error {
+	if name == backend.DefaultStateName || name == "" {
+		return fmt.Errorf("can't delete default state")
+	}
+	return testClient{path: filepath.Join(b.Path, name)}.Delete()
+}
+
+func (b *TestBackend) StateClient(workspace string) (remote.Client, error) {
+	c := testClient{path: filepath.Join(b.Path, workspace)}
+	if b.Lock {
+		return testLockingClient{c}, nil
+	}
+	return c, nil
+}
+
+type testClient struct {
+	path string
+}
+
+func (c testClient) Get() (*remote.Payload, error) {
+	data, err := os.ReadFile(c.path + ".tfstate")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  sum[:],
+	}, nil
+}
+
+func (c testClient) Put(data []byte) error {
+	return os.WriteFile(c.path+".tfstate", data, 0o644)
+}
+
+func (c testClient) Delete() error {
+	err := os.Remove(c.path + ".tfstate")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+type testLockingClient struct {
+	testClient
+}
+
+func (c testLockingClient) Lock(info *statemgr.LockInfo) (string, error) {
+	f, err := os.OpenFile(c.path+".lock", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		held, readErr := c.lockInfo()
+		if readErr != nil {
+			return "", readErr
+		}
+		return "", &statemgr.LockError{
+			Info: held,
+			Err:  fmt.Errorf("state already locked"),
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(info.Marshal()); err != nil {
+		return "", err
+	}
+	return info.ID, nil
+}
+
+func (c testLockingClient) Unlock(id string) error {
+	held, err := c.lockInfo()
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("state not locked")
+	}
+	if err != nil {
+		return err
+	}
+	if held.ID != id {
+		return &statemgr.LockError{
+			Info: held,
+			Err:  fmt.Errorf("lock id %q does not match existing lock", id),
+		}
+	}
+	return os.Remove(c.path + ".lock")
+}
+
+func (c testLockingClient) lockInfo() (*statemgr.LockInfo, error) {
+	data, err := os.ReadFile(c.path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -160,6 +160,18 @@ func (p *Provider) ValidateResourceConfig(req providers.ValidateResourceConfigRe
 	return validateDataStoreResourceConfig(req)
 }
 
+func (p *Provider) OpenEphemeralResource(providers.OpenEphemeralResourceRequest) providers.OpenEphemeralResourceResponse {
+	panic("unimplemented - terraform provider has no ephemeral resources")
+}
+
+func (p *Provider) RenewEphemeralResource(providers.RenewEphemeralResourceRequest) providers.RenewEphemeralResourceResponse {
+	panic("unimplemented - terraform provider has no ephemeral resources")
+}
+
+func (p *Provider) CloseEphemeralResource(providers.CloseEphemeralResourceRequest) providers.CloseEphemeralResourceResponse {
+	panic("unimplemented - terraform provider has no ephemeral resources")
+}
+
 func (p *Provider) GetFunctions() providers.GetFunctionsResponse {
 	panic("unimplemented - terraform provider has no functions")
 }
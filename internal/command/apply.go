@@ -88,8 +88,11 @@ func (c *ApplyCommand) Run(rawArgs []string) int {
 		return 1
 	}
 
-	// Check for invalid combination of plan file and variable overrides
-	if planFile != nil && !args.Vars.Empty() {
+	// Check for invalid combination of plan file and variable overrides.
+	// Only a plan that has ephemeral variables, whose values are never saved,
+	// can accept variable values when applied. The backend will check that
+	// only those variables are set.
+	if planFile != nil && !args.Vars.Empty() && !planHasApplyTimeVariables(planFile) {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
 			"Can't set variables when applying a saved plan",
@@ -207,6 +210,22 @@ func (c *ApplyCommand) LoadPlanFile(path string, enc encryption.Encryption) (*pl
 	return planFile, diags
 }
 
+// planHasApplyTimeVariables returns true if the given plan file is a local
+// plan that was created with values for ephemeral input variables, which must
+// be provided again when applying it.
+func planHasApplyTimeVariables(planFile *planfile.WrappedPlanFile) bool {
+	lp, ok := planFile.Local()
+	if !ok {
+		return false
+	}
+	plan, err := lp.ReadPlan()
+	if err != nil {
+		// We'll let the backend report the problem with the plan file.
+		return false
+	}
+	return len(plan.ApplyTimeVariables) > 0
+}
+
 func (c *ApplyCommand) PrepareBackend(planFile *planfile.WrappedPlanFile, args *arguments.State, viewType arguments.ViewType, enc encryption.StateEncryption) (backend.Enhanced, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
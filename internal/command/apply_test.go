@@ -826,6 +826,7 @@ func TestApply_plan_remoteState(t *testing.T) {
 		"update_method":             cty.NullVal(cty.String),
 		"lock_address":              cty.NullVal(cty.String),
 		"unlock_address":            cty.NullVal(cty.String),
+		"history_address":           cty.NullVal(cty.String),
 		"lock_method":               cty.NullVal(cty.String),
 		"unlock_method":             cty.NullVal(cty.String),
 		"username":                  cty.NullVal(cty.String),
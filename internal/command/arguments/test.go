@@ -22,9 +22,18 @@ type Test struct {
 	// always be discovered.
 	TestDirectory string
 
+	// FileParallelism is the maximum number of test files that OpenTofu will
+	// execute concurrently. Defaults to 1, which executes the test files one
+	// after another.
+	FileParallelism int
+
 	// ViewType specifies which output format to use: human or JSON.
 	ViewType ViewType
 
+	// JUnitXMLFile, if set, is the path of a file that OpenTofu will write a
+	// JUnit XML report of the test results into, alongside the normal output.
+	JUnitXMLFile string
+
 	// You can specify common variables for all tests from the command line.
 	Vars *Vars
 
@@ -45,7 +54,9 @@ func ParseTest(args []string) (*Test, tfdiags.Diagnostics) {
 	cmdFlags := extendedFlagSet("test", nil, nil, test.Vars)
 	cmdFlags.Var((*flagStringSlice)(&test.Filter), "filter", "filter")
 	cmdFlags.StringVar(&test.TestDirectory, "test-directory", configs.DefaultTestDirectory, "test-directory")
+	cmdFlags.IntVar(&test.FileParallelism, "file-parallelism", 1, "file-parallelism")
 	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.StringVar(&test.JUnitXMLFile, "junit-xml", "", "junit-xml")
 	cmdFlags.BoolVar(&test.Verbose, "verbose", false, "verbose")
 
 	if err := cmdFlags.Parse(args); err != nil {
@@ -55,6 +66,13 @@ func ParseTest(args []string) (*Test, tfdiags.Diagnostics) {
 			err.Error()))
 	}
 
+	if test.FileParallelism < 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid file-parallelism",
+			"The -file-parallelism option must be at least 1."))
+	}
+
 	switch {
 	case jsonOutput:
 		test.ViewType = ViewJSON
@@ -76,60 +76,104 @@ func TestParseTest(t *testing.T) {
 		"defaults": {
 			args: nil,
 			want: &Test{
-				Filter:        nil,
-				TestDirectory: "tests",
-				ViewType:      ViewHuman,
-				Vars:          &Vars{},
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
 			},
 			wantDiags: nil,
 		},
 		"with-filters": {
 			args: []string{"-filter=one.tftest.hcl", "-filter=two.tftest.hcl"},
 			want: &Test{
-				Filter:        []string{"one.tftest.hcl", "two.tftest.hcl"},
-				TestDirectory: "tests",
-				ViewType:      ViewHuman,
-				Vars:          &Vars{},
+				Filter:          []string{"one.tftest.hcl", "two.tftest.hcl"},
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
 			},
 			wantDiags: nil,
 		},
 		"json": {
 			args: []string{"-json"},
 			want: &Test{
-				Filter:        nil,
-				TestDirectory: "tests",
-				ViewType:      ViewJSON,
-				Vars:          &Vars{},
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewJSON,
+				Vars:            &Vars{},
 			},
 			wantDiags: nil,
 		},
 		"test-directory": {
 			args: []string{"-test-directory=other"},
 			want: &Test{
-				Filter:        nil,
-				TestDirectory: "other",
-				ViewType:      ViewHuman,
-				Vars:          &Vars{},
+				Filter:          nil,
+				TestDirectory:   "other",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
 			},
 			wantDiags: nil,
 		},
 		"verbose": {
 			args: []string{"-verbose"},
 			want: &Test{
-				Filter:        nil,
-				TestDirectory: "tests",
-				ViewType:      ViewHuman,
-				Verbose:       true,
-				Vars:          &Vars{},
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				Verbose:         true,
+				Vars:            &Vars{},
+			},
+		},
+		"file-parallelism": {
+			args: []string{"-file-parallelism=4"},
+			want: &Test{
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 4,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
+			},
+		},
+		"invalid file-parallelism": {
+			args: []string{"-file-parallelism=0"},
+			want: &Test{
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 0,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
+			},
+			wantDiags: tfdiags.Diagnostics{
+				tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid file-parallelism",
+					"The -file-parallelism option must be at least 1.",
+				),
+			},
+		},
+		"junit-xml": {
+			args: []string{"-junit-xml=report.xml"},
+			want: &Test{
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				JUnitXMLFile:    "report.xml",
+				Vars:            &Vars{},
 			},
 		},
 		"unknown flag": {
 			args: []string{"-boop"},
 			want: &Test{
-				Filter:        nil,
-				TestDirectory: "tests",
-				ViewType:      ViewHuman,
-				Vars:          &Vars{},
+				Filter:          nil,
+				TestDirectory:   "tests",
+				FileParallelism: 1,
+				ViewType:        ViewHuman,
+				Vars:            &Vars{},
 			},
 			wantDiags: tfdiags.Diagnostics{
 				tfdiags.Sourceless(
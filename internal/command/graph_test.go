@@ -130,6 +130,7 @@ func TestGraph_plan(t *testing.T) {
 	beConfig := cty.ObjectVal(map[string]cty.Value{
 		"path":          cty.NilVal,
 		"workspace_dir": cty.NilVal,
+		"history_limit": cty.NilVal,
 	})
 	emptyConfig, err := plans.NewDynamicValue(beConfig, beConfig.Type())
 	if err != nil {
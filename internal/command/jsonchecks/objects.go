@@ -31,6 +31,8 @@ func makeStaticObjectAddr(addr addrs.ConfigCheckable) staticObjectAddr {
 			ret["mode"] = "managed"
 		case addrs.DataResourceMode:
 			ret["mode"] = "data"
+		case addrs.EphemeralResourceMode:
+			ret["mode"] = "ephemeral"
 		default:
 			panic(fmt.Sprintf("unsupported resource mode %#v", addr.Resource.Mode))
 		}
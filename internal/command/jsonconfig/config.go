@@ -54,6 +54,7 @@ type moduleCall struct {
 	Expressions       map[string]interface{} `json:"expressions,omitempty"`
 	CountExpression   *expression            `json:"count_expression,omitempty"`
 	ForEachExpression *expression            `json:"for_each_expression,omitempty"`
+	EnabledExpression *expression            `json:"enabled_expression,omitempty"`
 	Module            module                 `json:"module,omitempty"`
 	VersionConstraint string                 `json:"version_constraint,omitempty"`
 	DependsOn         []string               `json:"depends_on,omitempty"`
@@ -67,6 +68,8 @@ type variable struct {
 	Default     json.RawMessage `json:"default,omitempty"`
 	Description string          `json:"description,omitempty"`
 	Sensitive   bool            `json:"sensitive,omitempty"`
+	Ephemeral   bool            `json:"ephemeral,omitempty"`
+	Deprecated  string          `json:"deprecated,omitempty"`
 }
 
 // Resource is the representation of a resource in the config
@@ -74,7 +77,7 @@ type resource struct {
 	// Address is the absolute resource address
 	Address string `json:"address,omitempty"`
 
-	// Mode can be "managed" or "data"
+	// Mode can be "managed", "data" or "ephemeral"
 	Mode string `json:"mode,omitempty"`
 
 	Type string `json:"type,omitempty"`
@@ -100,17 +103,21 @@ type resource struct {
 	// "values" property conforms to.
 	SchemaVersion uint64 `json:"schema_version"`
 
-	// CountExpression and ForEachExpression describe the expressions given for
-	// the corresponding meta-arguments in the resource configuration block.
-	// These are omitted if the corresponding argument isn't set.
+	// CountExpression, ForEachExpression and EnabledExpression describe the
+	// expressions given for the corresponding meta-arguments in the resource
+	// configuration block. These are omitted if the corresponding argument
+	// isn't set.
 	CountExpression   *expression `json:"count_expression,omitempty"`
 	ForEachExpression *expression `json:"for_each_expression,omitempty"`
+	EnabledExpression *expression `json:"enabled_expression,omitempty"`
 
 	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 type output struct {
 	Sensitive   bool       `json:"sensitive,omitempty"`
+	Ephemeral   bool       `json:"ephemeral,omitempty"`
+	Deprecated  string     `json:"deprecated,omitempty"`
 	Expression  expression `json:"expression,omitempty"`
 	DependsOn   []string   `json:"depends_on,omitempty"`
 	Description string     `json:"description,omitempty"`
@@ -319,13 +326,21 @@ func marshalModule(c *configs.Config, schemas *tofu.Schemas, addr string) (modul
 		return module, err
 	}
 
+	ephemeralResources, err := marshalResources(c.Module.EphemeralResources, schemas, addr)
+	if err != nil {
+		return module, err
+	}
+
 	rs = append(managedResources, dataResources...)
+	rs = append(rs, ephemeralResources...)
 	module.Resources = rs
 
 	outputs := make(map[string]output)
 	for _, v := range c.Module.Outputs {
 		o := output{
 			Sensitive:  v.Sensitive,
+			Ephemeral:  v.Ephemeral,
+			Deprecated: v.Deprecated,
 			Expression: marshalExpression(v.Expr),
 		}
 		if v.Description != "" {
@@ -367,6 +382,8 @@ func marshalModule(c *configs.Config, schemas *tofu.Schemas, addr string) (modul
 				Default:     defaultValJSON,
 				Description: v.Description,
 				Sensitive:   v.Sensitive,
+				Ephemeral:   v.Ephemeral,
+				Deprecated:  v.Deprecated,
 			}
 		}
 		module.Variables = vars
@@ -411,6 +428,9 @@ func marshalModuleCall(c *configs.Config, mc *configs.ModuleCall, schemas *tofu.
 			ret.ForEachExpression = &fExp
 		}
 	}
+	if eExp := marshalExpression(mc.Enabled); !eExp.Empty() {
+		ret.EnabledExpression = &eExp
+	}
 
 	schema := &configschema.Block{}
 	schema.Attributes = make(map[string]*configschema.Attribute)
@@ -459,6 +479,8 @@ func marshalResources(resources map[string]*configs.Resource, schemas *tofu.Sche
 			r.Mode = "managed"
 		case addrs.DataResourceMode:
 			r.Mode = "data"
+		case addrs.EphemeralResourceMode:
+			r.Mode = "ephemeral"
 		default:
 			return rs, fmt.Errorf("resource %s has an unsupported mode %s", r.Address, v.Mode.String())
 		}
@@ -472,6 +494,9 @@ func marshalResources(resources map[string]*configs.Resource, schemas *tofu.Sche
 				r.ForEachExpression = &fExp
 			}
 		}
+		if eExp := marshalExpression(v.Enabled); !eExp.Empty() {
+			r.EnabledExpression = &eExp
+		}
 
 		schema, schemaVer := schemas.ResourceTypeConfig(
 			v.Provider,
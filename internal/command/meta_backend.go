@@ -328,7 +328,7 @@ func (m *Meta) BackendForLocalPlan(settings plans.Backend, enc encryption.StateE
 		return nil, diags
 	}
 
-	configureDiags := b.Configure(newVal)
+	configureDiags := m.configureBackend(b, newVal, false)
 	diags = diags.Append(configureDiags)
 	if configureDiags.HasErrors() {
 		return nil, diags
@@ -664,7 +664,7 @@ func (m *Meta) backendFromConfig(opts *BackendOpts, enc encryption.StateEncrypti
 		// AND we're not providing any overrides. An override can mean a change overriding an unchanged backend block (indicated by the hash value).
 		if (uint64(cHash) == s.Backend.Hash) && (!opts.Init || opts.ConfigOverride == nil) {
 			log.Printf("[TRACE] Meta.Backend: using already-initialized, unchanged %q backend configuration", c.Type)
-			savedBackend, diags := m.savedBackend(sMgr, enc)
+			savedBackend, diags := m.savedBackend(sMgr, opts.Init, enc)
 			// Verify that selected workspace exist. Otherwise prompt user to create one
 			if opts.Init && savedBackend != nil {
 				if err := m.selectWorkspace(savedBackend); err != nil {
@@ -681,7 +681,7 @@ func (m *Meta) backendFromConfig(opts *BackendOpts, enc encryption.StateEncrypti
 		// don't need to migrate, we update the backend cache hash value.
 		if !m.backendConfigNeedsMigration(c, s.Backend) {
 			log.Printf("[TRACE] Meta.Backend: using already-initialized %q backend configuration", c.Type)
-			savedBackend, moreDiags := m.savedBackend(sMgr, enc)
+			savedBackend, moreDiags := m.savedBackend(sMgr, opts.Init, enc)
 			diags = diags.Append(moreDiags)
 			if moreDiags.HasErrors() {
 				return nil, diags
@@ -838,7 +838,7 @@ func (m *Meta) backendFromState(ctx context.Context, enc encryption.StateEncrypt
 		return nil, diags
 	}
 
-	configDiags := b.Configure(newVal)
+	configDiags := m.configureBackend(b, newVal, false)
 	diags = diags.Append(configDiags)
 	if configDiags.HasErrors() {
 		return nil, diags
@@ -913,7 +913,7 @@ func (m *Meta) backend_c_r_S(
 	}
 
 	// Initialize the configured backend
-	b, moreDiags := m.savedBackend(sMgr, enc)
+	b, moreDiags := m.savedBackend(sMgr, opts.Init, enc)
 	diags = diags.Append(moreDiags)
 	if moreDiags.HasErrors() {
 		return nil, diags
@@ -1180,7 +1180,7 @@ func (m *Meta) backend_C_r_S_changed(c *configs.Backend, cHash int, sMgr *clista
 	// state lives.
 	if cloudMode != cloud.ConfigChangeInPlace {
 		// Grab the existing backend
-		oldB, oldBDiags := m.savedBackend(sMgr, enc)
+		oldB, oldBDiags := m.savedBackend(sMgr, opts.Init, enc)
 		diags = diags.Append(oldBDiags)
 		if oldBDiags.HasErrors() {
 			return nil, diags
@@ -1256,12 +1256,44 @@ func (m *Meta) backend_C_r_S_changed(c *configs.Backend, cHash int, sMgr *clista
 	return b, diags
 }
 
-// Initializing a saved backend from the cache file (legacy state file)
+// configureBackend configures the given backend with the given configuration.
+// A backend that installs a plugin selects it from the dependency lock file,
+// and is only allowed to install a plugin that isn't locked yet if install is
+// true, in which case the selection is recorded in the dependency lock file.
+// This must only be the case during "tofu init".
+func (m *Meta) configureBackend(b backend.Backend, configVal cty.Value, install bool) tfdiags.Diagnostics {
+	installer, ok := b.(backend.PluginInstaller)
+	if !ok {
+		return b.Configure(configVal)
+	}
+
+	locks, diags := m.lockedDependencies()
+	if diags.HasErrors() {
+		return diags
+	}
+	previousLocks := locks.DeepCopy()
+	installer.SetDependencyLocks(locks, install)
+
+	configureDiags := b.Configure(configVal)
+	diags = diags.Append(configureDiags)
+	if configureDiags.HasErrors() {
+		return diags
+	}
+
+	if !locks.Equal(previousLocks) {
+		diags = diags.Append(m.replaceLockedDependencies(locks))
+	}
+	return diags
+}
+
+// Initializing a saved backend from the cache file (legacy state file). If
+// init is true, a backend plugin may be installed and recorded in the
+// dependency lock file.
 //
 // TODO: This is extremely similar to Meta.backendFromState() but for legacy reasons this is the
 // function used by the migration APIs within this file. The other handles 'init -backend=false',
 // specifically.
-func (m *Meta) savedBackend(sMgr *clistate.LocalState, enc encryption.StateEncryption) (backend.Backend, tfdiags.Diagnostics) {
+func (m *Meta) savedBackend(sMgr *clistate.LocalState, init bool, enc encryption.StateEncryption) (backend.Backend, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
 	s := sMgr.State()
@@ -1295,7 +1327,7 @@ func (m *Meta) savedBackend(sMgr *clistate.LocalState, enc encryption.StateEncry
 		return nil, diags
 	}
 
-	configDiags := b.Configure(newVal)
+	configDiags := m.configureBackend(b, newVal, init)
 	diags = diags.Append(configDiags)
 	if configDiags.HasErrors() {
 		return nil, diags
@@ -1440,7 +1472,7 @@ func (m *Meta) backendInitFromConfig(c *configs.Backend, enc encryption.StateEnc
 		return nil, cty.NilVal, diags
 	}
 
-	configureDiags := b.Configure(newVal)
+	configureDiags := m.configureBackend(b, newVal, true)
 	diags = diags.Append(configureDiags.InConfigBody(c.Config, ""))
 
 	// If the result of loading the backend is an enhanced backend,
@@ -11,6 +11,7 @@ import (
 	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/opentofu/opentofu/internal/command/jsonfunction"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/lang"
 )
 
@@ -52,6 +53,18 @@ func (c *MetadataFunctionsCommand) Run(args []string) int {
 	}
 
 	scope := &lang.Scope{}
+
+	// The user-defined functions of the module in the current directory, if
+	// any, are included in the "module::" namespace.
+	if c.dirIsConfigPath(".") {
+		mod, diags := c.loadSingleModule(".", configs.SelectiveLoadAll)
+		if diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		scope.ModuleFunctions = mod.ScopeFunctions()
+	}
+
 	funcs := scope.Functions()
 	filteredFuncs := make(map[string]function.Function)
 	for k, v := range funcs {
@@ -75,6 +88,10 @@ const metadataFunctionsCommandHelp = `
 Usage: tofu [global options] metadata functions -json
 
   Prints out a json representation of the available function signatures.
+
+  When run in a directory containing OpenTofu configuration, the functions
+  declared in "function" blocks of that module are included in the
+  module:: namespace.
 `
 
 func isIgnoredFunction(name string) bool {
@@ -70,6 +70,37 @@ func TestMetadataFunctions_output(t *testing.T) {
 	}
 }
 
+func TestMetadataFunctions_moduleFunctions(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("metadata-functions"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	c := &MetadataFunctionsCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	if code := c.Run([]string{"-json"}); code != 0 {
+		t.Fatalf("wrong exit status %d; want 0\nstderr: %s", code, ui.ErrorWriter.String())
+	}
+
+	var got functions
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	gotSlug, ok := got.Signatures["module::slug"]
+	wantSlug := "{\"description\":\"Converts a name into a slug.\",\"return_type\":\"dynamic\",\"parameters\":[{\"name\":\"value\",\"description\":\"The name to convert.\",\"type\":\"string\"}]}"
+	if !ok {
+		t.Fatal(`missing function signature for "module::slug"`)
+	}
+	if string(gotSlug) != wantSlug {
+		t.Fatalf("wrong function signature for \"module::slug\":\ngot: %q\nwant: %q", gotSlug, wantSlug)
+	}
+}
+
 type functions struct {
 	FormatVersion string                     `json:"format_version"`
 	Signatures    map[string]json.RawMessage `json:"function_signatures,omitempty"`
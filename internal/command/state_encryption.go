@@ -0,0 +1,37 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// StateEncryptionCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type StateEncryptionCommand struct {
+	StateMeta
+}
+
+func (c *StateEncryptionCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *StateEncryptionCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state encryption <subcommand> [options] [args]
+
+  This command has subcommands for inspecting the encryption of state and
+  plan files.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateEncryptionCommand) Synopsis() string {
+	return "Inspect the encryption of state and plan files"
+}
@@ -0,0 +1,295 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+// StateEncryptionStatusCommand is a Command implementation that reports how
+// a state or plan file is encrypted, and optionally whether the current
+// encryption configuration is able to decrypt it.
+type StateEncryptionStatusCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateEncryptionStatusCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var jsonOutput, decrypt, plan bool
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state encryption status")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "produce JSON output")
+	cmdFlags.BoolVar(&decrypt, "decrypt", false, "try to decrypt the file")
+	cmdFlags.BoolVar(&plan, "plan", false, "read a plan file")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("Exactly zero or one argument expected.\n")
+		return cli.RunResultHelp
+	}
+	if plan && len(args) == 0 {
+		c.Ui.Error("The -plan option requires the path of a plan file.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	var data []byte
+	if len(args) == 1 {
+		var err error
+		data, err = os.ReadFile(args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read %s: %s", args[0], err))
+			return 1
+		}
+	} else {
+		var err error
+		data, err = c.readWorkspaceState(enc)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read the state: %s", err))
+			return 1
+		}
+		if data == nil {
+			c.Ui.Output("No state.")
+			return 0
+		}
+	}
+
+	info, err := encryption.InspectPayload(data)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to inspect the file: %s", err))
+		return 1
+	}
+
+	var result *encryption.DecryptionResult
+	if decrypt {
+		var r encryption.DecryptionResult
+		if plan {
+			r = enc.Plan().TryDecryptPlan(data)
+		} else {
+			r = enc.State().TryDecryptState(data)
+		}
+		result = &r
+	}
+
+	if jsonOutput {
+		out, err := json.MarshalIndent(newEncryptionStatusOutput(info, result), "", "  ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to encode the encryption status: %s", err))
+			return 1
+		}
+		c.Ui.Output(string(out))
+	} else {
+		c.Ui.Output(formatEncryptionStatus(info, result))
+	}
+
+	if result != nil && result.Err != nil {
+		return 1
+	}
+	return 0
+}
+
+// readWorkspaceState returns the stored state of the current workspace as
+// it was read from the backend, before decrypting it. It returns nil if the
+// workspace has no state.
+func (c *StateEncryptionStatusCommand) readWorkspaceState(enc encryption.Encryption) ([]byte, error) {
+	// The state managers only return the decrypted state, so we wrap the
+	// state encryption to capture what they read.
+	stateEnc := &capturingStateEncryption{StateEncryption: enc.State()}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, stateEnc)
+	if backendDiags.HasErrors() {
+		return nil, backendDiags.Err()
+	}
+
+	workspace, err := c.Workspace()
+	if err != nil {
+		return nil, err
+	}
+
+	stateMgr, err := b.StateMgr(workspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	// The state may not be readable with the current configuration, which
+	// is one of the things this command reports, so we only fail if nothing
+	// was read at all.
+	refreshErr := stateMgr.RefreshState()
+	if data := stateEnc.captured(); data != nil {
+		return data, nil
+	}
+	return nil, refreshErr
+}
+
+func (c *StateEncryptionStatusCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state encryption status [options] [PATH]
+
+  Show how a state or plan file is encrypted.
+
+  By default, this command inspects the state of the current workspace. If
+  PATH is given, the state file (or plan file, with -plan) at that path is
+  inspected instead.
+
+  The file is not decrypted unless -decrypt is given, so this command can be
+  used to find out which key providers a file was encrypted with when the
+  current configuration is not able to read it.
+
+Options:
+
+  -decrypt            Try to decrypt the file with the current encryption
+                      configuration, and report which method was able to
+                      read it. The command exits with an error if the file
+                      can not be decrypted.
+
+  -json               Produce the output in a machine-readable JSON format.
+
+  -plan               Treat PATH as a plan file instead of a state file.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateEncryptionStatusCommand) Synopsis() string {
+	return "Show how a state or plan file is encrypted"
+}
+
+// encryptionStatusOutput is the JSON representation of the encryption
+// status of a file.
+type encryptionStatusOutput struct {
+	Encrypted         bool                        `json:"encrypted"`
+	Mode              string                      `json:"mode,omitempty"`
+	EncryptionVersion string                      `json:"encryption_version,omitempty"`
+	KeyProviderMeta   []string                    `json:"key_provider_meta"`
+	Decryption        *encryptionDecryptionOutput `json:"decryption,omitempty"`
+}
+
+type encryptionDecryptionOutput struct {
+	Success  bool   `json:"success"`
+	Method   string `json:"method,omitempty"`
+	Fallback bool   `json:"fallback"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newEncryptionStatusOutput(info *encryption.PayloadInfo, result *encryption.DecryptionResult) encryptionStatusOutput {
+	out := encryptionStatusOutput{
+		Encrypted:         info.Encrypted,
+		Mode:              info.Mode,
+		EncryptionVersion: info.Version,
+		KeyProviderMeta:   []string{},
+	}
+	for _, key := range info.KeyProviderMeta {
+		out.KeyProviderMeta = append(out.KeyProviderMeta, string(key))
+	}
+	if result != nil {
+		out.Decryption = &encryptionDecryptionOutput{
+			Success:  result.Err == nil,
+			Method:   string(result.Method),
+			Fallback: result.Err == nil && result.Status == encryption.DecryptedWithFallback,
+		}
+		if result.Err != nil {
+			out.Decryption.Error = result.Err.Error()
+		}
+	}
+	return out
+}
+
+func formatEncryptionStatus(info *encryption.PayloadInfo, result *encryption.DecryptionResult) string {
+	var b strings.Builder
+	if info.Encrypted {
+		b.WriteString("Encrypted:         yes\n")
+		fmt.Fprintf(&b, "Mode:              %s\n", info.Mode)
+		fmt.Fprintf(&b, "Format version:    %s\n", info.Version)
+		if len(info.KeyProviderMeta) == 0 {
+			b.WriteString("Key provider meta: none\n")
+		} else {
+			b.WriteString("Key provider meta:\n")
+			for _, key := range info.KeyProviderMeta {
+				fmt.Fprintf(&b, "  - %s\n", key)
+			}
+		}
+	} else {
+		b.WriteString("Encrypted:         no\n")
+	}
+
+	switch {
+	case result == nil:
+		b.WriteString("Decryption:        not attempted, use -decrypt to check the current configuration")
+	case result.Err != nil:
+		fmt.Fprintf(&b, "Decryption:        failed: %s", result.Err)
+	case result.Method == "":
+		b.WriteString("Decryption:        readable without encryption")
+	case result.Status == encryption.DecryptedWithFallback:
+		fmt.Fprintf(&b, "Decryption:        readable with the fallback method %s", result.Method)
+	default:
+		fmt.Fprintf(&b, "Decryption:        readable with the primary method %s", result.Method)
+	}
+	return b.String()
+}
+
+// capturingStateEncryption wraps a StateEncryption and keeps a copy of the
+// last state it was asked to decrypt.
+type capturingStateEncryption struct {
+	encryption.StateEncryption
+
+	mu   sync.Mutex
+	data []byte
+}
+
+func (e *capturingStateEncryption) DecryptState(encryptedState []byte) ([]byte, error) {
+	e.capture(encryptedState)
+	return e.StateEncryption.DecryptState(encryptedState)
+}
+
+func (e *capturingStateEncryption) DecryptStateWithStatus(encryptedState []byte) ([]byte, encryption.DecryptionStatus, error) {
+	e.capture(encryptedState)
+	return e.StateEncryption.DecryptStateWithStatus(encryptedState)
+}
+
+func (e *capturingStateEncryption) capture(encryptedState []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.data = append([]byte(nil), encryptedState...)
+}
+
+func (e *capturingStateEncryption) captured() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.data
+}
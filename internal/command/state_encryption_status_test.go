@@ -0,0 +1,114 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateEncryptionStatus(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-rekey"), td)
+	defer testChdir(t, td)()
+
+	newUi := func() (*cli.MockUi, Meta) {
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		return ui, Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+		}
+	}
+
+	// The state is not encrypted yet, and can only be read with the fallback.
+	ui, meta := newUi()
+	c := &StateEncryptionStatusCommand{Meta: meta}
+	if code := c.Run([]string{"-decrypt"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{
+		"Encrypted:         no",
+		"readable with the fallback method method.unencrypted.migrate",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output:\n%s", expected, output)
+		}
+	}
+
+	ui, meta = newUi()
+	rekey := &StateRekeyCommand{Meta: meta}
+	if code := rekey.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// Without -decrypt, only the envelope is inspected.
+	ui, meta = newUi()
+	c = &StateEncryptionStatusCommand{Meta: meta}
+	if code := c.Run([]string{"-json"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	var actual encryptionStatusOutput
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &actual); err != nil {
+		t.Fatalf("invalid JSON output: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+	expected := encryptionStatusOutput{
+		Encrypted:         true,
+		Mode:              "full",
+		EncryptionVersion: "v0",
+		KeyProviderMeta:   []string{"key_provider.pbkdf2.mykey"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	ui, meta = newUi()
+	c = &StateEncryptionStatusCommand{Meta: meta}
+	path := filepath.Join("terraform.tfstate.d", "foo", "terraform.tfstate")
+	if code := c.Run([]string{"-json", "-decrypt", path}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	actual = encryptionStatusOutput{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &actual); err != nil {
+		t.Fatalf("invalid JSON output: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+	expected.Decryption = &encryptionDecryptionOutput{
+		Success: true,
+		Method:  "method.aes_gcm.new_method",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestStateEncryptionStatus_args(t *testing.T) {
+	for name, args := range map[string][]string{
+		"too-many-args": {"foo", "bar"},
+		"plan-no-path":  {"-plan"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ui := new(cli.MockUi)
+			view, _ := testView(t)
+			c := &StateEncryptionStatusCommand{
+				Meta: Meta{
+					Ui:   ui,
+					View: view,
+				},
+			}
+
+			if code := c.Run(args); code != cli.RunResultHelp {
+				t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
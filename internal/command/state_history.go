@@ -0,0 +1,174 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// StateHistoryCommand is a Command implementation that lists the earlier
+// versions of the state that are kept by the backend.
+type StateHistoryCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateHistoryCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state history")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("This command does not accept any arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	_, stateMgr, diags := c.historyStateMgr("state history")
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	versions, err := stateMgr.ListStateVersions()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to list state versions: %s", formatStateHistoryError(err)))
+		return 1
+	}
+	if len(versions) == 0 {
+		c.Ui.Output("No state versions found.")
+		return 0
+	}
+
+	width := 0
+	for _, v := range versions {
+		width = max(width, len(v.ID))
+	}
+	for _, v := range versions {
+		line := fmt.Sprintf("%-*s  %s  %d bytes", width, v.ID, formatStateVersionTime(v.Created), v.Size)
+		if v.Current {
+			line += "  (current)"
+		}
+		c.Ui.Output(line)
+	}
+	return 0
+}
+
+// stateHistoryManager is a state manager that can also list and read earlier
+// versions of the state.
+type stateHistoryManager interface {
+	statemgr.Full
+	statemgr.Historian
+}
+
+// historyStateMgr returns the state manager of the current workspace, if it
+// is able to list and read earlier versions of the state.
+func (c *Meta) historyStateMgr(command string) (backend.Enhanced, stateHistoryManager, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	workspace, err := c.Workspace()
+	if err != nil {
+		return nil, nil, diags.Append(fmt.Errorf("Error selecting workspace: %w", err))
+	}
+
+	// Check remote OpenTofu version is compatible
+	remoteVersionDiags := c.remoteVersionCheck(b, workspace)
+	diags = diags.Append(remoteVersionDiags)
+	if remoteVersionDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	stateMgr, err := b.StateMgr(workspace)
+	if err != nil {
+		return nil, nil, diags.Append(fmt.Errorf("Failed to load state: %w", err))
+	}
+
+	historian, ok := stateMgr.(stateHistoryManager)
+	if !ok {
+		return nil, nil, diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"State history not supported",
+			fmt.Sprintf("The %q command is not supported by the configured backend, because it does not keep earlier versions of the state.", command),
+		))
+	}
+	return b, historian, diags
+}
+
+func formatStateHistoryError(err error) string {
+	if errors.Is(err, statemgr.ErrHistoryUnsupported) {
+		return "the configured backend does not keep earlier versions of the state"
+	}
+	return err.Error()
+}
+
+func formatStateVersionTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (c *StateHistoryCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state history [options]
+
+  List the earlier versions of the state of the current workspace that are
+  kept by the backend, newest first.
+
+  Only some backends keep earlier versions of the state, for example the s3
+  backend when versioning is enabled on the bucket, or the local backend
+  when history_limit is set. Any listed version can be restored with
+  "tofu state restore", or with "tofu state rollback" to go back a number
+  of versions.
+
+Options:
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateHistoryCommand) Synopsis() string {
+	return "List earlier versions of the state"
+}
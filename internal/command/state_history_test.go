@@ -0,0 +1,116 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/remote-state/inmem"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestStateHistory(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("inmem-backend"), td)
+	defer testChdir(t, td)()
+	defer inmem.Reset()
+
+	ids := testStateHistoryInit(t, "first", "second")
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateHistoryCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected at least two versions, got:\n%s", ui.OutputWriter.String())
+	}
+	if !strings.HasPrefix(lines[0], ids["second"]+" ") || !strings.HasSuffix(lines[0], "(current)") {
+		t.Fatalf("expected the second version to be listed first as the current one, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], ids["first"]+" ") || strings.HasSuffix(lines[1], "(current)") {
+		t.Fatalf("expected the first version to be listed second, got %q", lines[1])
+	}
+}
+
+func TestStateHistory_unsupported(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-rekey"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateHistoryCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("expected failure, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	// The local backend only keeps earlier versions when history_limit is set
+	if !strings.Contains(ui.ErrorWriter.String(), "does not keep earlier versions of the state") {
+		t.Fatalf("unexpected error:\n%s", ui.ErrorWriter.String())
+	}
+}
+
+// testStateHistoryInit initializes the inmem backend in the current
+// directory, selects a new "test" workspace, and writes one version of its
+// state per given output value. It returns the version ID of each of them.
+//
+// The inmem backend resets the default workspace whenever it is configured,
+// so the versions are only kept for other workspaces.
+func testStateHistoryInit(t *testing.T, values ...string) map[string]string {
+	t.Helper()
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	initCmd := &InitCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := initCmd.Run(nil); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	newCmd := &WorkspaceNewCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := newCmd.Run([]string{"test"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter)
+	}
+
+	b := backend.TestBackendConfig(t, inmem.New(encryption.StateEncryptionDisabled()), nil)
+	sMgr, err := b.StateMgr("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make(map[string]string)
+	for _, value := range values {
+		state := states.NewState()
+		state.RootModule().SetOutputValue("value", cty.StringVal(value), false)
+		if err := statemgr.WriteAndPersist(sMgr, state, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		versions, err := sMgr.(statemgr.Historian).ListStateVersions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[value] = versions[0].ID
+	}
+	return ids
+}
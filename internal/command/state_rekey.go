@@ -0,0 +1,275 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// StateRekeyCommand is a Command implementation that encrypts the state of
+// every workspace again with the primary encryption method, so that a
+// fallback method can be removed after a key rotation.
+type StateRekeyCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateRekeyCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var dryRun, all bool
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state rekey")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry run")
+	cmdFlags.BoolVar(&all, "all", false, "rewrite all workspaces")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("This command does not accept any arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// The state managers only tell us whether they could read the state, so
+	// we wrap the state encryption to find out which method was used.
+	stateEnc := &rekeyStateEncryption{StateEncryption: enc.State()}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, stateEnc)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(backendDiags)
+		return 1
+	}
+
+	workspaces, err := b.Workspaces()
+	if err == backend.ErrWorkspacesNotSupported {
+		// Backends without workspaces still have a single state to rekey.
+		workspaces = []string{backend.DefaultStateName}
+		err = nil
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to list workspaces: %s", err))
+		return 1
+	}
+
+	var pending []string
+	failed := false
+	for _, workspace := range workspaces {
+		rewrite, err := c.rekeyWorkspace(b, workspace, stateEnc, dryRun, all)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to re-encrypt the state of workspace %q: %s", workspace, err))
+			failed = true
+			continue
+		}
+		if rewrite {
+			pending = append(pending, workspace)
+		}
+	}
+	if failed {
+		return 1
+	}
+
+	switch {
+	case len(pending) == 0:
+		c.Ui.Output("\nAll workspaces are already encrypted with the primary method.")
+	case dryRun:
+		c.Ui.Output(fmt.Sprintf(
+			"\nThe following workspaces would be re-encrypted with the primary method: %s.\n"+
+				"Run this command without -dry-run to re-encrypt them.",
+			strings.Join(pending, ", "),
+		))
+	default:
+		c.Ui.Output(fmt.Sprintf(
+			"\nRe-encrypted the following workspaces with the primary method: %s.",
+			strings.Join(pending, ", "),
+		))
+	}
+	return 0
+}
+
+// rekeyWorkspace reads the state of the given workspace and, unless this is
+// a dry run, writes it again if it could only be read with a fallback method
+// or if all is set. It returns whether the state was (or would be) written.
+func (c *StateRekeyCommand) rekeyWorkspace(b backend.Enhanced, workspace string, stateEnc *rekeyStateEncryption, dryRun, all bool) (bool, error) {
+	// Check remote OpenTofu version is compatible
+	remoteVersionDiags := c.remoteVersionCheck(b, workspace)
+	c.showDiagnostics(remoteVersionDiags)
+	if remoteVersionDiags.HasErrors() {
+		return false, fmt.Errorf("incompatible remote OpenTofu version")
+	}
+
+	stateMgr, err := b.StateMgr(workspace)
+	if err != nil {
+		return false, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	if c.stateLock && !dryRun {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-rekey"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return false, fmt.Errorf("failed to lock state")
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	stateEnc.reset()
+	if err := stateMgr.RefreshState(); err != nil {
+		return false, fmt.Errorf("failed to refresh state: %w", err)
+	}
+	if stateMgr.State() == nil {
+		c.Ui.Output(fmt.Sprintf("%s: no state snapshot, skipping", workspace))
+		return false, nil
+	}
+
+	fallback := stateEnc.usedFallback()
+	switch {
+	case fallback:
+		c.Ui.Output(fmt.Sprintf("%s: encrypted with a fallback method", workspace))
+	case all:
+		c.Ui.Output(fmt.Sprintf("%s: encrypted with the primary method, rewriting because of -all", workspace))
+	default:
+		c.Ui.Output(fmt.Sprintf("%s: encrypted with the primary method", workspace))
+		return false, nil
+	}
+	if dryRun {
+		return true, nil
+	}
+
+	// Some state managers skip writing a snapshot that is identical to the
+	// one they read, so we bump the serial to make sure that the state is
+	// encrypted again.
+	stateFile := statemgr.Export(stateMgr)
+	stateFile.Serial++
+	if err := statemgr.Import(stateFile, stateMgr, false); err != nil {
+		return false, fmt.Errorf("failed to write state: %w", err)
+	}
+
+	// Get schemas, if possible, before writing state
+	var schemas *tofu.Schemas
+	var diags tfdiags.Diagnostics
+	if isCloudMode(b) {
+		schemas, diags = c.MaybeGetSchemas(stateFile.State, nil)
+	}
+	c.showDiagnostics(diags)
+
+	if err := stateMgr.PersistState(schemas); err != nil {
+		return false, fmt.Errorf("failed to persist state: %w", err)
+	}
+	return true, nil
+}
+
+func (c *StateRekeyCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state rekey [options]
+
+  Encrypt the state of every workspace again with the primary encryption
+  method.
+
+  After rotating a key or changing the encryption method, the old method is
+  configured as a fallback so that existing state can still be read. This
+  command reads the state of each workspace and writes it again if it could
+  only be decrypted with a fallback method. Once it completes, the fallback
+  can be removed from the configuration.
+
+Options:
+
+  -all                Write the state of all workspaces, even those already
+                      encrypted with the primary method. This is useful
+                      after changing the key provider configuration without
+                      changing the method.
+
+  -dry-run            Only report which workspaces would be re-encrypted,
+                      without writing any state.
+
+  -lock=false         Don't hold a state lock during the operation. This is
+                      dangerous if others might concurrently run commands
+                      against the same workspace.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRekeyCommand) Synopsis() string {
+	return "Re-encrypt the state of all workspaces with the primary method"
+}
+
+// rekeyStateEncryption wraps a StateEncryption and records whether any state
+// was decrypted with a fallback method since the last reset.
+type rekeyStateEncryption struct {
+	encryption.StateEncryption
+
+	mu       sync.Mutex
+	fallback bool
+}
+
+func (e *rekeyStateEncryption) DecryptState(encryptedState []byte) ([]byte, error) {
+	decryptedState, _, err := e.DecryptStateWithStatus(encryptedState)
+	return decryptedState, err
+}
+
+func (e *rekeyStateEncryption) DecryptStateWithStatus(encryptedState []byte) ([]byte, encryption.DecryptionStatus, error) {
+	decryptedState, status, err := e.StateEncryption.DecryptStateWithStatus(encryptedState)
+	if err == nil && status == encryption.DecryptedWithFallback {
+		e.mu.Lock()
+		e.fallback = true
+		e.mu.Unlock()
+	}
+	return decryptedState, status, err
+}
+
+func (e *rekeyStateEncryption) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fallback = false
+}
+
+func (e *rekeyStateEncryption) usedFallback() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.fallback
+}
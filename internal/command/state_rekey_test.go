@@ -0,0 +1,172 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+func TestStateRekey(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-rekey"), td)
+	defer testChdir(t, td)()
+
+	statePaths := map[string]string{
+		"default": "terraform.tfstate",
+		"foo":     filepath.Join("terraform.tfstate.d", "foo", "terraform.tfstate"),
+	}
+	expected := map[string]*statefile.File{}
+	for workspace, path := range statePaths {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected[workspace], err = statefile.Read(f, encryption.StateEncryptionDisabled())
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newCommand := func() (*StateRekeyCommand, *cli.MockUi) {
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		return &StateRekeyCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+				View:             view,
+			},
+		}, ui
+	}
+
+	// A dry run must report both workspaces without touching the state.
+	c, ui := newCommand()
+	if code := c.Run([]string{"-dry-run"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "would be re-encrypted with the primary method: default, foo") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	for _, path := range statePaths {
+		if encrypted := testStateFileEncrypted(t, path); encrypted {
+			t.Fatalf("dry run encrypted %s", path)
+		}
+	}
+
+	c, ui = newCommand()
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output = ui.OutputWriter.String()
+	if !strings.Contains(output, "Re-encrypted the following workspaces with the primary method: default, foo") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	enc, diags := c.Encryption()
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	for workspace, path := range statePaths {
+		if encrypted := testStateFileEncrypted(t, path); !encrypted {
+			t.Fatalf("state of workspace %s was not encrypted", workspace)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := statefile.Read(f, enc.State())
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Lineage != expected[workspace].Lineage {
+			t.Fatalf("workspace %s: lineage changed from %q to %q", workspace, expected[workspace].Lineage, actual.Lineage)
+		}
+		if actual.Serial <= expected[workspace].Serial {
+			t.Fatalf("workspace %s: serial was not incremented", workspace)
+		}
+		if !actual.State.Equal(expected[workspace].State) {
+			t.Fatalf("workspace %s: state changed:\n%s", workspace, actual.State)
+		}
+	}
+
+	// Running the command again finds nothing left to do.
+	c, ui = newCommand()
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output = ui.OutputWriter.String()
+	if !strings.Contains(output, "All workspaces are already encrypted with the primary method.") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+// Backends that do not support workspaces, like http, have only the default
+// state to rekey.
+func TestStateRekey_workspacesNotSupported(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-rekey-http"), td)
+	defer testChdir(t, td)()
+
+	dataState, srv := testBackendState(t, testState(), 200)
+	defer srv.Close()
+	testStateFileRemote(t, dataState)
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRekeyCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+	if code := c.Run([]string{"-dry-run"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "would be re-encrypted with the primary method: default.") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestStateRekey_args(t *testing.T) {
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRekeyCommand{
+		Meta: Meta{
+			Ui:   ui,
+			View: view,
+		},
+	}
+
+	if code := c.Run([]string{"foo"}); code != cli.RunResultHelp {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
+// testStateFileEncrypted returns whether the state file at the given path
+// contains an encrypted payload.
+func testStateFileEncrypted(t *testing.T, path string) bool {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Contains(string(data), `"encrypted_data"`)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// StateRestoreCommand is a Command implementation that writes an earlier
+// version of the state, as listed by StateHistoryCommand, as the latest
+// version.
+type StateRestoreCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateRestoreCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var versionID string
+	var flagForce bool
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state restore")
+	cmdFlags.StringVar(&versionID, "version", "", "version to restore")
+	cmdFlags.BoolVar(&flagForce, "force", false, "")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("This command does not accept any arguments.\n")
+		return cli.RunResultHelp
+	}
+	if versionID == "" {
+		c.Ui.Error("The -version option is required.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	b, stateMgr, diags := c.historyStateMgr("state restore")
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-restore"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	if err := c.restoreStateVersion(b, stateMgr, versionID, flagForce); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Restored state version %s.", versionID))
+	return 0
+}
+
+// restoreStateVersion writes the given earlier version of the state as a new
+// version of the state. The caller must hold the state lock and refresh the
+// state manager first.
+func (c *Meta) restoreStateVersion(b backend.Enhanced, stateMgr stateHistoryManager, versionID string, force bool) error {
+	restored, err := statemgr.RestoreStateVersion(stateMgr, versionID, force)
+	if err != nil {
+		return fmt.Errorf("Failed to restore state: %s", formatStateHistoryError(err))
+	}
+
+	// Get schemas, if possible, before writing state
+	var schemas *tofu.Schemas
+	if isCloudMode(b) {
+		var schemaDiags tfdiags.Diagnostics
+		schemas, schemaDiags = c.MaybeGetSchemas(restored.State, nil)
+		c.showDiagnostics(schemaDiags)
+	}
+
+	if err := stateMgr.PersistState(schemas); err != nil {
+		return fmt.Errorf("Failed to persist state: %w", err)
+	}
+	return nil
+}
+
+func (c *StateRestoreCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state restore [options] -version=ID
+
+  Restore an earlier version of the state of the current workspace.
+
+  The version ID is one of those listed by "tofu state history". The earlier
+  version is written as a new version of the state with a higher serial, so
+  the current version stays in the history and the restore can be undone.
+
+Options:
+
+  -version=ID         The ID of the version to restore. Required.
+
+  -force              Restore the version even if its lineage doesn't match
+                      the lineage of the current state.
+
+  -lock=false         Don't hold a state lock during the operation. This is
+                      dangerous if others might concurrently run commands
+                      against the same workspace.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRestoreCommand) Synopsis() string {
+	return "Restore an earlier version of the state"
+}
@@ -0,0 +1,105 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/remote-state/inmem"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestStateRestore(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("inmem-backend"), td)
+	defer testChdir(t, td)()
+	defer inmem.Reset()
+
+	ids := testStateHistoryInit(t, "first", "second")
+
+	b := backend.TestBackendConfig(t, inmem.New(encryption.StateEncryptionDisabled()), nil)
+	sMgr, err := b.StateMgr("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sMgr.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	before := statemgr.Export(sMgr)
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRestoreCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run([]string{"-version=" + ids["first"]}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if err := sMgr.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	after := statemgr.Export(sMgr)
+	if got := after.State.RootModule().OutputValues["value"].Value; !got.RawEquals(cty.StringVal("first")) {
+		t.Fatalf("expected the first version to be restored, got %#v", got)
+	}
+	if after.Lineage != before.Lineage {
+		t.Fatalf("lineage changed from %q to %q", before.Lineage, after.Lineage)
+	}
+	if after.Serial <= before.Serial {
+		t.Fatalf("expected the serial to be incremented from %d, got %d", before.Serial, after.Serial)
+	}
+
+	// The restore is a new version, so the one it replaced is still there.
+	versions, err := sMgr.(statemgr.Historian).ListStateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) < 3 || versions[1].ID != ids["second"] {
+		t.Fatalf("unexpected versions: %#v", versions)
+	}
+}
+
+func TestStateRestore_missingVersion(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("inmem-backend"), td)
+	defer testChdir(t, td)()
+	defer inmem.Reset()
+
+	testStateHistoryInit(t, "first")
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRestoreCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run([]string{"-version=1000"}); code != 1 {
+		t.Fatalf("expected failure, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestStateRestore_args(t *testing.T) {
+	for name, args := range map[string][]string{
+		"no-version": nil,
+		"argument":   {"-version=1", "foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ui := new(cli.MockUi)
+			view, _ := testView(t)
+			c := &StateRestoreCommand{
+				Meta: Meta{Ui: ui, View: view},
+			}
+			if code := c.Run(args); code != cli.RunResultHelp {
+				t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// StateRollbackCommand is a Command implementation that restores the version
+// of the state that was current a given number of writes ago.
+type StateRollbackCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateRollbackCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var steps int
+	var flagForce bool
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state rollback")
+	cmdFlags.IntVar(&steps, "steps", 1, "number of versions to go back")
+	cmdFlags.BoolVar(&flagForce, "force", false, "")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("This command does not accept any arguments.\n")
+		return cli.RunResultHelp
+	}
+	if steps < 1 {
+		c.Ui.Error("The -steps option must be at least 1.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	b, stateMgr, diags := c.historyStateMgr("state rollback")
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-rollback"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	versions, err := stateMgr.ListStateVersions()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to list state versions: %s", formatStateHistoryError(err)))
+		return 1
+	}
+	target, err := rollbackTarget(versions, steps)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if err := c.restoreStateVersion(b, stateMgr, target.ID, flagForce); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Rolled back the state to version %s.", target.ID))
+	return 0
+}
+
+// rollbackTarget returns the version that is the given number of steps older
+// than the current version. If no version is marked as current, the newest
+// listed version is one step back.
+func rollbackTarget(versions []statemgr.StateVersion, steps int) (statemgr.StateVersion, error) {
+	current := -1
+	for i, v := range versions {
+		if v.Current {
+			current = i
+			break
+		}
+	}
+
+	earlier := len(versions) - current - 1
+	if steps > earlier {
+		switch earlier {
+		case 0:
+			return statemgr.StateVersion{}, errors.New("There are no earlier versions of the state to roll back to.")
+		case 1:
+			return statemgr.StateVersion{}, fmt.Errorf("Can't roll back %d versions, because there is only 1 earlier version of the state.", steps)
+		default:
+			return statemgr.StateVersion{}, fmt.Errorf("Can't roll back %d versions, because there are only %d earlier versions of the state.", steps, earlier)
+		}
+	}
+	return versions[current+steps], nil
+}
+
+func (c *StateRollbackCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state rollback [options]
+
+  Restore the version of the state of the current workspace that was
+  current before the latest write, or the given number of writes before.
+
+  This is a shortcut for "tofu state restore" with a version ID taken from
+  "tofu state history". As with a restore, the earlier version is written as
+  a new version of the state, so the rollback can be undone.
+
+Options:
+
+  -steps=1            The number of versions to go back. Defaults to 1.
+
+  -force              Roll back even if the lineage of the earlier version
+                      doesn't match the lineage of the current state.
+
+  -lock=false         Don't hold a state lock during the operation. This is
+                      dangerous if others might concurrently run commands
+                      against the same workspace.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRollbackCommand) Synopsis() string {
+	return "Restore the previous version of the state"
+}
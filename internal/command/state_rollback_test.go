@@ -0,0 +1,161 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/remote-state/inmem"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestStateRollback(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("inmem-backend"), td)
+	defer testChdir(t, td)()
+	defer inmem.Reset()
+
+	ids := testStateHistoryInit(t, "first", "second", "third")
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRollbackCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run([]string{"-steps=2"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), ids["first"]; !strings.Contains(got, want) {
+		t.Fatalf("expected the output to mention version %s, got:\n%s", want, got)
+	}
+
+	b := backend.TestBackendConfig(t, inmem.New(encryption.StateEncryptionDisabled()), nil)
+	sMgr, err := b.StateMgr("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sMgr.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	after := statemgr.Export(sMgr)
+	if got := after.State.RootModule().OutputValues["value"].Value; !got.RawEquals(cty.StringVal("first")) {
+		t.Fatalf("expected the first version to be restored, got %#v", got)
+	}
+}
+
+func TestStateRollback_tooFar(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("inmem-backend"), td)
+	defer testChdir(t, td)()
+	defer inmem.Reset()
+
+	testStateHistoryInit(t, "first", "second")
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &StateRollbackCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	// Creating the workspace wrote an empty first version
+	if code := c.Run([]string{"-steps=3"}); code != 1 {
+		t.Fatalf("expected failure, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if got, want := ui.ErrorWriter.String(), "only 2 earlier versions"; !strings.Contains(got, want) {
+		t.Fatalf("expected error containing %q, got:\n%s", want, got)
+	}
+}
+
+func TestStateRollback_localBackend(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+	config := `
+terraform {
+  backend "local" {
+    history_limit = 5
+  }
+}
+`
+	if err := os.WriteFile("main.tf", []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	initCmd := &InitCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := initCmd.Run(nil); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	sMgr := statemgr.NewFilesystem("terraform.tfstate", encryption.StateEncryptionDisabled())
+	sMgr.SetHistoryLimit(5)
+	for _, value := range []string{"first", "second"} {
+		state := states.NewState()
+		state.RootModule().SetOutputValue("value", cty.StringVal(value), false)
+		if err := statemgr.WriteAndPersist(sMgr, state, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &StateRollbackCommand{
+		Meta: Meta{Ui: ui, View: view},
+	}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	sMgr = statemgr.NewFilesystem("terraform.tfstate", encryption.StateEncryptionDisabled())
+	if err := sMgr.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	if got := sMgr.State().RootModule().OutputValues["value"].Value; !got.RawEquals(cty.StringVal("first")) {
+		t.Fatalf("expected the first version to be restored, got %#v", got)
+	}
+}
+
+func TestStateRollback_args(t *testing.T) {
+	for name, args := range map[string][]string{
+		"zero-steps": {"-steps=0"},
+		"argument":   {"foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ui := new(cli.MockUi)
+			view, _ := testView(t)
+			c := &StateRollbackCommand{
+				Meta: Meta{Ui: ui, View: view},
+			}
+			if code := c.Run(args); code != cli.RunResultHelp {
+				t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+			}
+		})
+	}
+}
+
+func TestRollbackTarget(t *testing.T) {
+	versions := []statemgr.StateVersion{{ID: "3", Current: true}, {ID: "2"}, {ID: "1"}}
+	if got, err := rollbackTarget(versions, 1); err != nil || got.ID != "2" {
+		t.Fatalf("unexpected target %#v, %v", got, err)
+	}
+
+	// Without a current version, the newest one is already one step back
+	versions[0].Current = false
+	if got, err := rollbackTarget(versions, 1); err != nil || got.ID != "3" {
+		t.Fatalf("unexpected target %#v, %v", got, err)
+	}
+
+	if _, err := rollbackTarget(nil, 1); err == nil {
+		t.Fatal("expected an error without versions")
+	}
+}
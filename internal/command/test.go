@@ -12,6 +12,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/hcl/v2"
@@ -34,6 +35,11 @@ import (
 
 const (
 	MainStateIdentifier = ""
+
+	// testRunExplicitStateKeyPrefix keeps the state keys given by state_key
+	// apart from the keys derived from module sources, so a state_key can
+	// never accidentally share a state with a module.
+	testRunExplicitStateKeyPrefix = "state_key:"
 )
 
 type TestCommand struct {
@@ -70,6 +76,10 @@ Options:
                         will be performed. All locations, for all errors
                         will be listed. Disabled by default
 
+  -file-parallelism=n   Limit the number of test files executed concurrently.
+                        Defaults to 1, which executes the test files one after
+                        another.
+
   -filter=testfile      If specified, OpenTofu will only execute the test files
                         specified by this flag. You can use this option multiple
                         times to execute more than one test file.
@@ -77,6 +87,9 @@ Options:
   -json                 If specified, machine readable output will be printed in
                         JSON format
 
+  -junit-xml=path       Saves a test report in JUnit XML format to the given
+                        path, in addition to the normal output.
+
   -no-color             If specified, output won't contain any color.
 
   -test-directory=path  Set the OpenTofu test directory, defaults to "tests". When set, the
@@ -126,6 +139,12 @@ func (c *TestCommand) Run(rawArgs []string) int {
 	}
 
 	view := views.NewTest(args.ViewType, c.View)
+	if args.JUnitXMLFile != "" {
+		view = views.TestMulti{
+			view,
+			views.NewTestJUnitXMLFile(args.JUnitXMLFile, c.View),
+		}
+	}
 
 	// Users can also specify variables via the command line, so we'll parse
 	// all that here.
@@ -274,6 +293,9 @@ func (c *TestCommand) Run(rawArgs []string) int {
 		Stopped:   false,
 
 		Verbose: args.Verbose,
+
+		FileParallelism: args.FileParallelism,
+		TestDirectory:   args.TestDirectory,
 	}
 
 	view.Abstract(&suite)
@@ -364,6 +386,18 @@ type TestSuiteRunner struct {
 
 	// Verbose tells the runner to print out plan files during each test run.
 	Verbose bool
+
+	// FileParallelism is the maximum number of test files that execute
+	// concurrently.
+	FileParallelism int
+
+	// TestDirectory is the directory the configuration was loaded with, so
+	// that it can be loaded again for test files that execute concurrently.
+	TestDirectory string
+
+	// viewLock serializes output from test files that execute concurrently,
+	// so that the output for each file stays together.
+	viewLock sync.Mutex
 }
 
 func (runner *TestSuiteRunner) Start(ctx context.Context) {
@@ -373,16 +407,34 @@ func (runner *TestSuiteRunner) Start(ctx context.Context) {
 	}
 	sort.Strings(files) // execute the files in alphabetical order
 
+	// Test files are started in alphabetical order, but up to
+	// FileParallelism of them can be executing at the same time.
+	sem := make(chan struct{}, max(runner.FileParallelism, 1))
+	var wg sync.WaitGroup
+	var statusLock sync.Mutex
+
 	runner.Suite.Status = moduletest.Pass
 	for _, name := range files {
+		sem <- struct{}{}
 		if runner.Cancelled {
-			return
+			break
 		}
 
 		file := runner.Suite.Files[name]
 
+		config, configDiags := runner.configForFile(file)
+		if configDiags.HasErrors() {
+			file.Diagnostics = file.Diagnostics.Append(configDiags)
+			file.Status = moduletest.Error
+			runner.printFile(file)
+			runner.Suite.Status = runner.Suite.Status.Merge(file.Status)
+			<-sem
+			continue
+		}
+
 		fileRunner := &TestFileRunner{
-			Suite: runner,
+			Suite:  runner,
+			Config: config,
 			States: map[string]*TestFileState{
 				MainStateIdentifier: {
 					Run:   nil,
@@ -391,28 +443,96 @@ func (runner *TestSuiteRunner) Start(ctx context.Context) {
 			},
 		}
 
-		fileRunner.ExecuteTestFile(ctx, file)
-		fileRunner.Cleanup(ctx, file)
-		runner.Suite.Status = runner.Suite.Status.Merge(file.Status)
+		wg.Add(1)
+		panicHandler := logging.PanicHandlerWithTraceFn()
+		go func() {
+			defer panicHandler()
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			fileRunner.ExecuteTestFile(ctx, file)
+			fileRunner.Cleanup(ctx, file)
+			file.Duration = time.Since(start)
+
+			statusLock.Lock()
+			defer statusLock.Unlock()
+			runner.Suite.Status = runner.Suite.Status.Merge(file.Status)
+		}()
+	}
+	wg.Wait()
+}
+
+// configForFile returns the configuration that the given test file should
+// execute against.
+//
+// Executing a run block temporarily modifies the configuration, so when test
+// files execute concurrently each of them needs its own copy. In that case we
+// load the configuration again and switch the file and its run blocks over to
+// the freshly loaded test file, which also holds its own copies of any
+// alternate modules used by the run blocks.
+func (runner *TestSuiteRunner) configForFile(file *moduletest.File) (*configs.Config, tfdiags.Diagnostics) {
+	if runner.FileParallelism <= 1 {
+		return runner.Config, nil
+	}
+
+	var diags tfdiags.Diagnostics
+	config, configDiags := runner.command.loadConfigWithTests(".", runner.TestDirectory)
+	if configDiags.HasErrors() {
+		return nil, diags.Append(configDiags)
+	}
+
+	testFile, ok := config.Module.Tests[file.Name]
+	if !ok || len(testFile.Runs) != len(file.Config.Runs) {
+		return nil, diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Inconsistent test file",
+			fmt.Sprintf("The test file %s changed while OpenTofu was executing the tests.", file.Name)))
+	}
+
+	file.Config = testFile
+	for _, run := range file.Runs {
+		run.Config = testFile.Runs[run.Index]
+	}
+	return config, diags
+}
+
+// printFile prints the results of a test file and its run blocks. The output
+// for the whole file is written at once, so it stays together even when
+// other test files are executing concurrently.
+func (runner *TestSuiteRunner) printFile(file *moduletest.File) {
+	runner.viewLock.Lock()
+	defer runner.viewLock.Unlock()
+
+	runner.View.File(file)
+	for _, run := range file.Runs {
+		runner.View.Run(run, file)
 	}
 }
 
 type TestFileRunner struct {
 	Suite *TestSuiteRunner
 
+	// Config is the main configuration under test for this file.
+	Config *configs.Config
+
 	States map[string]*TestFileState
 }
 
 type TestFileState struct {
 	Run   *moduletest.Run
 	State *states.State
+
+	// CreatedBy is the first run block that updated this state. The states
+	// are destroyed in the reverse order they were created in.
+	CreatedBy *moduletest.Run
 }
 
 func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *moduletest.File) {
 	log.Printf("[TRACE] TestFileRunner: executing test file %s", file.Name)
 
 	file.Status = file.Status.Merge(moduletest.Pass)
-	for _, run := range file.Runs {
+	for _, group := range groupTestRuns(file) {
 		if runner.Suite.Cancelled {
 			// This means a hard stop has been requested, in this case we don't
 			// even stop to mark future tests as having been skipped. They'll
@@ -420,6 +540,28 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 			return
 		}
 
+		runner.executeTestRunGroup(ctx, group, file)
+	}
+
+	runner.Suite.printFile(file)
+}
+
+// executeTestRunGroup executes a group of run blocks, as produced by
+// groupTestRuns, concurrently. The tracked states are only updated once every
+// run block in the group has completed, as the run blocks within a group
+// never share a state.
+func (runner *TestFileRunner) executeTestRunGroup(ctx context.Context, group []*moduletest.Run, file *moduletest.File) {
+	type pendingRun struct {
+		run    *moduletest.Run
+		key    string
+		config *configs.Config
+
+		state        *states.State
+		updatedState bool
+	}
+
+	var pending []*pendingRun
+	for _, run := range group {
 		if runner.Suite.Stopped {
 			// Then the test was requested to be stopped, so we just mark each
 			// following test as skipped and move on.
@@ -435,13 +577,12 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 			continue
 		}
 
-		key := MainStateIdentifier
-		config := runner.Suite.Config
+		key := testRunStateKey(run)
+		config := runner.Config
 		if run.Config.ConfigUnderTest != nil {
 			config = run.Config.ConfigUnderTest
 			// Then we need to load an alternate state and not the main one.
 
-			key = run.Config.Module.Source.String()
 			if key == MainStateIdentifier {
 				// This is bad. It means somehow the module we're loading has
 				// the same key as main state and we're about to corrupt things.
@@ -457,32 +598,117 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 				file.Status = moduletest.Error
 				continue // Abort!
 			}
+		}
 
-			if _, exists := runner.States[key]; !exists {
-				runner.States[key] = &TestFileState{
-					Run:   nil,
-					State: states.NewState(),
-				}
+		if _, exists := runner.States[key]; !exists {
+			runner.States[key] = &TestFileState{
+				Run:   nil,
+				State: states.NewState(),
 			}
 		}
 
-		state, updatedState := runner.ExecuteTestRun(ctx, run, file, runner.States[key].State, config)
-		if updatedState {
+		pending = append(pending, &pendingRun{
+			run:    run,
+			key:    key,
+			config: config,
+		})
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range pending {
+		wg.Add(1)
+		panicHandler := logging.PanicHandlerWithTraceFn()
+		go func() {
+			defer panicHandler()
+			defer wg.Done()
+
+			start := time.Now()
+			p.state, p.updatedState = runner.ExecuteTestRun(ctx, p.run, file, runner.States[p.key].State, p.config)
+			p.run.Duration = time.Since(start)
+		}()
+	}
+	wg.Wait()
+
+	for _, p := range pending {
+		if p.updatedState {
 			// Only update the most recent run and state if the state was
 			// actually updated by this change. We want to use the run that
 			// most recently updated the tracked state as the cleanup
 			// configuration.
-			runner.States[key].State = state
-			runner.States[key].Run = run
+			runner.States[p.key].State = p.state
+			runner.States[p.key].Run = p.run
+			if runner.States[p.key].CreatedBy == nil {
+				runner.States[p.key].CreatedBy = p.run
+			}
 		}
 
-		file.Status = file.Status.Merge(run.Status)
+		file.Status = file.Status.Merge(p.run.Status)
 	}
+}
 
-	runner.Suite.View.File(file)
+// groupTestRuns splits the run blocks within the given file into groups. The
+// groups execute one after another, and the run blocks within each group
+// execute concurrently.
+//
+// A run block joins the group of the run blocks before it only if all of them
+// are marked as parallel, none of them use the same state, and neither the run
+// block nor the file level variables refer to any of them. Otherwise, it
+// starts a new group.
+//
+// Executing a run block temporarily modifies the configuration it executes
+// against, so at most one run block per group can execute against the main
+// configuration even if they use different states. Each run block that loads
+// an alternate module has its own copy of that module.
+func groupTestRuns(file *moduletest.File) [][]*moduletest.Run {
+	fileRefs := file.GetRunReferences()
+
+	var groups [][]*moduletest.Run
+	var current []*moduletest.Run
+	var mainConfig bool
+	keys := make(map[string]bool)
+	names := make(map[string]bool)
 	for _, run := range file.Runs {
-		runner.Suite.View.Run(run, file)
+		key := testRunStateKey(run)
+		usesMainConfig := run.Config.ConfigUnderTest == nil
+
+		join := len(current) > 0 && current[0].Config.Parallel && run.Config.Parallel && !keys[key] && !(mainConfig && usesMainConfig)
+		for _, ref := range append(run.GetRunReferences(), fileRefs...) {
+			join = join && !names[ref]
+		}
+
+		if !join && len(current) > 0 {
+			groups = append(groups, current)
+			current = nil
+			mainConfig = false
+			keys = make(map[string]bool)
+			names = make(map[string]bool)
+		}
+
+		current = append(current, run)
+		mainConfig = mainConfig || usesMainConfig
+		keys[key] = true
+		names[run.Name] = true
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
+
+// testRunStateKey returns the key of the state the given run block operates
+// on. Run blocks with an explicit state_key share a state with the other run
+// blocks that use the same state_key. Otherwise, run blocks that execute
+// against the main configuration share the main state, while run blocks that
+// load an alternate module share a state with the other run blocks that load
+// the same module.
+func testRunStateKey(run *moduletest.Run) string {
+	if run.Config.StateKey != "" {
+		return testRunExplicitStateKeyPrefix + run.Config.StateKey
+	}
+	if run.Config.ConfigUnderTest == nil {
+		return MainStateIdentifier
 	}
+	return run.Config.Module.Source.String()
 }
 
 //nolint:funlen // Historical function predates our complexity rules
@@ -906,7 +1132,9 @@ func (runner *TestFileRunner) wait(ctx *tofu.Context, runningCtx context.Context
 			}
 			states[module.Run] = module.State
 		}
+		runner.Suite.viewLock.Lock()
 		runner.Suite.View.FatalInterruptSummary(run, file, states, created)
+		runner.Suite.viewLock.Unlock()
 
 		cancelled = true
 		go ctx.Stop()
@@ -979,7 +1207,9 @@ func (runner *TestFileRunner) Cleanup(ctx context.Context, file *moduletest.File
 
 			var diags tfdiags.Diagnostics
 			diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Inconsistent state", fmt.Sprintf("Found inconsistent state while cleaning up %s. This is a bug in OpenTofu - please report it", file.Name)))
+			runner.Suite.viewLock.Lock()
 			runner.Suite.View.DestroySummary(diags, nil, file, state.State)
+			runner.Suite.viewLock.Unlock()
 			continue
 		}
 
@@ -987,12 +1217,15 @@ func (runner *TestFileRunner) Cleanup(ctx context.Context, file *moduletest.File
 	}
 
 	slices.SortFunc(states, func(a, b *TestFileState) int {
-		// We want to clean up later run blocks first. So, we'll sort this in
-		// reverse according to index. This means larger indices first.
-		return b.Run.Index - a.Run.Index
+		// We want to clean up the states created by later run blocks first,
+		// as they may depend on infrastructure created by earlier ones. So,
+		// we'll sort this in reverse according to the index of the run block
+		// that created each state. This means larger indices first.
+		return b.CreatedBy.Index - a.CreatedBy.Index
 	})
 
-	// Clean up all the states (for main and custom modules) in reverse order.
+	// Clean up all the states (for main and custom modules, and for each
+	// state_key) in reverse order of creation.
 	for _, state := range states {
 		log.Printf("[DEBUG] TestStateManager: cleaning up state for %s/%s", file.Name, state.Run.Name)
 
@@ -1008,7 +1241,7 @@ func (runner *TestFileRunner) Cleanup(ctx context.Context, file *moduletest.File
 
 		isMainState := state.Run.Config.Module == nil
 		if isMainState {
-			runConfig = runner.Suite.Config
+			runConfig = runner.Config
 		} else {
 			runConfig = state.Run.Config.ConfigUnderTest
 		}
@@ -1022,11 +1255,13 @@ func (runner *TestFileRunner) Cleanup(ctx context.Context, file *moduletest.File
 			updated, destroyDiags = runner.destroy(ctx, runConfig, state.State, state.Run, file)
 			diags = diags.Append(destroyDiags)
 		}
+		runner.Suite.viewLock.Lock()
 		runner.Suite.View.DestroySummary(diags, state.Run, file, updated)
 
 		if updated.HasManagedResourceInstanceObjects() {
 			views.SaveErroredTestStateFile(updated, state.Run, file, runner.Suite.View)
 		}
+		runner.Suite.viewLock.Unlock()
 		reset()
 	}
 }
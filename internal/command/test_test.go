@@ -6,18 +6,23 @@
 package command
 
 import (
+	"os"
 	"path"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/mitchellh/cli"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	testing_command "github.com/opentofu/opentofu/internal/command/testing"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/moduletest"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/terminal"
 )
@@ -106,6 +111,16 @@ func TestTest(t *testing.T) {
 			expected: "2 passed, 0 failed",
 			code:     0,
 		},
+		"multiple_files_in_parallel": {
+			override: "multiple_files",
+			args:     []string{"-file-parallelism=2"},
+			expected: "2 passed, 0 failed",
+			code:     0,
+		},
+		"state_key": {
+			expected: "3 passed, 0 failed",
+			code:     0,
+		},
 		"multiple_files_with_filter": {
 			override: "multiple_files",
 			args:     []string{"-filter=one.tftest.hcl"},
@@ -303,6 +318,49 @@ func TestTest_Full_Output(t *testing.T) {
 	}
 }
 
+func TestTest_JUnitXML(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "simple_fail")), td)
+	defer testChdir(t, td)()
+
+	provider := testing_command.NewProvider(nil)
+	view, done := testView(t)
+
+	c := &TestCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(provider.Provider),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-junit-xml=report.xml", "-no-color"})
+	output := done(t)
+
+	if code != 1 {
+		t.Errorf("expected status code 1 but got %d", code)
+	}
+
+	// The normal output is still produced alongside the report.
+	if !strings.Contains(output.Stdout(), "0 passed, 1 failed.") {
+		t.Errorf("output didn't contain expected string:\n\n%s", output.All())
+	}
+
+	report, err := os.ReadFile("report.xml")
+	if err != nil {
+		t.Fatalf("failed to read report: %s", err)
+	}
+	for _, want := range []string{
+		`<testsuites tests="1" failures="1" errors="0" skipped="0">`,
+		`<testsuite name="main.tftest.hcl" tests="1" failures="1" errors="0" skipped="0"`,
+		`<testcase name="validate_test_resource" classname="main.tftest.hcl"`,
+		`<failure message="Test assertion failed">`,
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report didn't contain %q:\n%s", want, report)
+		}
+	}
+}
+
 func TestTest_Interrupt(t *testing.T) {
 	td := t.TempDir()
 	testCopyDir(t, testFixturePath(path.Join("test", "with_interrupt")), td)
@@ -897,6 +955,137 @@ func TestTest_Modules(t *testing.T) {
 	}
 }
 
+func TestTest_ParallelRuns(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "parallel_runs")), td)
+	defer testChdir(t, td)()
+
+	provider := testing_command.NewProvider(nil)
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0"},
+	})
+	defer close()
+
+	streams, done := terminal.StreamsForTesting(t)
+	view := views.NewView(streams)
+	ui := new(cli.MockUi)
+
+	meta := Meta{
+		testingOverrides: metaOverridesForProvider(provider.Provider),
+		Ui:               ui,
+		View:             view,
+		Streams:          streams,
+		ProviderSource:   providerSource,
+	}
+
+	init := &InitCommand{
+		Meta: meta,
+	}
+
+	if code := init.Run(nil); code != 0 {
+		t.Fatalf("expected status code 0 but got %d: %s", code, ui.ErrorWriter)
+	}
+
+	c := &TestCommand{
+		Meta: meta,
+	}
+
+	code := c.Run([]string{"-no-color"})
+	output := done(t)
+
+	if code != 0 {
+		t.Errorf("expected status code 0 but got %d: %s", code, output.All())
+	}
+
+	// The run blocks are always reported in order, regardless of the order
+	// they completed in.
+	expected := `main.tftest.hcl... pass
+  run "setup"... pass
+  run "other"... pass
+  run "main"... pass
+
+Success! 3 passed, 0 failed.
+`
+	actual := output.All()
+	if diff := cmp.Diff(expected, actual); len(diff) > 0 {
+		t.Errorf("output didn't match expected:\nexpected:\n%s\nactual:\n%s\ndiff:\n%s", expected, actual, diff)
+	}
+
+	if provider.ResourceCount() > 0 {
+		t.Errorf("should have deleted all resources on completion but left %v", provider.ResourceString())
+	}
+}
+
+func TestGroupTestRuns(t *testing.T) {
+	parse := func(src string) hcl.Expression {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "main.tftest.hcl", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("failed to parse %q: %s", src, diags.Error())
+		}
+		return expr
+	}
+	module := func(source string) (*configs.TestRunModuleCall, *configs.Config) {
+		return &configs.TestRunModuleCall{Source: addrs.ModuleSourceLocal(source)}, &configs.Config{}
+	}
+
+	newRun := func(name string, parallel bool, source string, variables map[string]string) *moduletest.Run {
+		config := &configs.TestRun{
+			Name:      name,
+			Parallel:  parallel,
+			Variables: make(map[string]hcl.Expression),
+		}
+		if source != "" {
+			config.Module, config.ConfigUnderTest = module(source)
+		}
+		for name, src := range variables {
+			config.Variables[name] = parse(src)
+		}
+		return &moduletest.Run{Name: name, Config: config}
+	}
+
+	file := &moduletest.File{
+		Config: &configs.TestFile{},
+		Runs: []*moduletest.Run{
+			newRun("first", false, "", nil),
+			newRun("setup", true, "./setup", nil),
+			newRun("other", true, "./other", nil),
+			newRun("main", true, "", nil),
+			newRun("same_state", true, "./setup", nil),
+			newRun("uses_main", true, "./other", map[string]string{"input": "run.main.value"}),
+			newRun("sequential", false, "", nil),
+			newRun("last", true, "./last", nil),
+			newRun("blue", true, "", nil),
+			newRun("green", true, "", nil),
+		},
+	}
+	// Run blocks with different state keys still can't execute concurrently
+	// against the main configuration.
+	file.Runs[8].Config.StateKey = "blue"
+	file.Runs[9].Config.StateKey = "green"
+
+	var got [][]string
+	for _, group := range groupTestRuns(file) {
+		var names []string
+		for _, run := range group {
+			names = append(names, run.Name)
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{
+		{"first"},
+		{"setup", "other", "main"},
+		{"same_state", "uses_main"},
+		{"sequential"},
+		{"last", "blue"},
+		{"green"},
+	}
+	if diff := cmp.Diff(want, got); len(diff) > 0 {
+		t.Errorf("wrong groups\n%s", diff)
+	}
+}
+
 func TestTest_StatePropagation(t *testing.T) {
 	td := t.TempDir()
 	testCopyDir(t, testFixturePath(path.Join("test", "state_propagation")), td)
@@ -0,0 +1,11 @@
+function "slug" {
+  description = "Converts a name into a slug."
+
+  parameter "value" {
+    type        = string
+    description = "The name to convert."
+    nullable    = false
+  }
+
+  result = replace(lower(value), " ", "-")
+}
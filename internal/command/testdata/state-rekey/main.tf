@@ -0,0 +1,20 @@
+terraform {
+  encryption {
+    method "unencrypted" "migrate" {}
+
+    key_provider "pbkdf2" "mykey" {
+      passphrase = "26281afb-83f1-47ec-9b2d-2aebf6417167"
+    }
+
+    method "aes_gcm" "new_method" {
+      keys = key_provider.pbkdf2.mykey
+    }
+
+    state {
+      method = method.aes_gcm.new_method
+      fallback {
+        method = method.unencrypted.migrate
+      }
+    }
+  }
+}
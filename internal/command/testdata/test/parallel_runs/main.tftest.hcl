@@ -0,0 +1,40 @@
+# The setup and other run blocks use different states, so they can execute
+# concurrently. The main run block refers to the setup run block so it has to
+# wait for it to complete.
+
+run "setup" {
+  parallel = true
+
+  module {
+    source = "./setup"
+  }
+
+  variables {
+    input = "setup"
+  }
+}
+
+run "other" {
+  parallel = true
+
+  module {
+    source = "./other"
+  }
+
+  variables {
+    input = "other"
+  }
+}
+
+run "main" {
+  parallel = true
+
+  variables {
+    input = run.setup.value
+  }
+
+  assert {
+    condition     = test_resource.foo.value == "setup"
+    error_message = "invalid value"
+  }
+}
@@ -0,0 +1,38 @@
+# The blue and green run blocks execute against the same configuration, but
+# keep independent states so neither replaces the resource of the other.
+
+run "blue" {
+  state_key = "blue"
+
+  variables {
+    input = "blue"
+  }
+}
+
+run "green" {
+  state_key = "green"
+
+  variables {
+    input = "green"
+  }
+}
+
+# A refresh-only plan reports the existing state, so this only passes if the
+# green run block didn't touch the blue state.
+run "check_blue" {
+  command   = plan
+  state_key = "blue"
+
+  plan_options {
+    mode = refresh-only
+  }
+
+  variables {
+    input = "unused"
+  }
+
+  assert {
+    condition     = test_resource.foo.value == "blue"
+    error_message = "blue state was modified"
+  }
+}
@@ -89,6 +89,67 @@ func NewTest(vt arguments.ViewType, view *View) Test {
 	}
 }
 
+// TestMulti is a Test view that forwards everything to each of the views it
+// contains, in order. It allows a report file to be produced alongside the
+// normal output.
+type TestMulti []Test
+
+var _ Test = (TestMulti)(nil)
+
+func (t TestMulti) Abstract(suite *moduletest.Suite) {
+	for _, view := range t {
+		view.Abstract(suite)
+	}
+}
+
+func (t TestMulti) Conclusion(suite *moduletest.Suite) {
+	for _, view := range t {
+		view.Conclusion(suite)
+	}
+}
+
+func (t TestMulti) File(file *moduletest.File) {
+	for _, view := range t {
+		view.File(file)
+	}
+}
+
+func (t TestMulti) Run(run *moduletest.Run, file *moduletest.File) {
+	for _, view := range t {
+		view.Run(run, file)
+	}
+}
+
+func (t TestMulti) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
+	for _, view := range t {
+		view.DestroySummary(diags, run, file, state)
+	}
+}
+
+func (t TestMulti) Diagnostics(run *moduletest.Run, file *moduletest.File, diags tfdiags.Diagnostics) {
+	for _, view := range t {
+		view.Diagnostics(run, file, diags)
+	}
+}
+
+func (t TestMulti) Interrupted() {
+	for _, view := range t {
+		view.Interrupted()
+	}
+}
+
+func (t TestMulti) FatalInterrupt() {
+	for _, view := range t {
+		view.FatalInterrupt()
+	}
+}
+
+func (t TestMulti) FatalInterruptSummary(run *moduletest.Run, file *moduletest.File, states map[*moduletest.Run]*states.State, created []*plans.ResourceInstanceChangeSrc) {
+	for _, view := range t {
+		view.FatalInterruptSummary(run, file, states, created)
+	}
+}
+
 type TestHuman struct {
 	view *View
 }
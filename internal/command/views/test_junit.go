@@ -0,0 +1,329 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/format"
+	"github.com/opentofu/opentofu/internal/moduletest"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// TestJUnitXMLFile is a Test view that writes a JUnit XML report of the test
+// results into a file once the tests have completed.
+//
+// Each test file is reported as a testsuite, and each run block within the
+// file as a testcase. It produces no output of its own while the tests are
+// executing, so it is intended to be combined with one of the other Test views
+// using TestMulti.
+type TestJUnitXMLFile struct {
+	filename string
+
+	// view is used to report problems writing the report, and to find the
+	// configuration sources when rendering diagnostics.
+	view *View
+
+	// destroys holds the results of cleaning up the states of each test
+	// file, by file name, which are reported on the testsuite of the file.
+	destroys map[string][]junitDestroy
+}
+
+// junitDestroy is the result of destroying one of the states of a test file.
+type junitDestroy struct {
+	identifier string
+	diags      tfdiags.Diagnostics
+	leftover   []string
+}
+
+var _ Test = (*TestJUnitXMLFile)(nil)
+
+// NewTestJUnitXMLFile returns a Test view that writes a JUnit XML report into
+// the given file.
+func NewTestJUnitXMLFile(filename string, view *View) *TestJUnitXMLFile {
+	return &TestJUnitXMLFile{
+		filename: filename,
+		view:     view,
+		destroys: make(map[string][]junitDestroy),
+	}
+}
+
+func (t *TestJUnitXMLFile) Abstract(_ *moduletest.Suite) {}
+
+func (t *TestJUnitXMLFile) Conclusion(suite *moduletest.Suite) {
+	report, err := t.render(suite)
+	if err == nil {
+		err = os.WriteFile(t.filename, report, 0644)
+	}
+	if err != nil {
+		var diags tfdiags.Diagnostics
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to write JUnit XML report",
+			fmt.Sprintf("OpenTofu could not write the test report to %s: %s.", t.filename, err)))
+		t.view.Diagnostics(diags)
+	}
+}
+
+func (t *TestJUnitXMLFile) File(_ *moduletest.File) {}
+
+func (t *TestJUnitXMLFile) Run(_ *moduletest.Run, _ *moduletest.File) {}
+
+func (t *TestJUnitXMLFile) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
+	identifier := file.Name
+	if run != nil {
+		identifier = fmt.Sprintf("%s/%s", identifier, run.Name)
+	}
+
+	var leftover []string
+	if state.HasManagedResourceInstanceObjects() {
+		for _, resource := range state.AllResourceInstanceObjectAddrs() {
+			if resource.DeposedKey != states.NotDeposed {
+				leftover = append(leftover, fmt.Sprintf("%s (%s)", resource.Instance, resource.DeposedKey))
+				continue
+			}
+			leftover = append(leftover, resource.Instance.String())
+		}
+	}
+
+	if len(diags) == 0 && len(leftover) == 0 {
+		return
+	}
+	t.destroys[file.Name] = append(t.destroys[file.Name], junitDestroy{
+		identifier: identifier,
+		diags:      diags,
+		leftover:   leftover,
+	})
+}
+
+func (t *TestJUnitXMLFile) Diagnostics(_ *moduletest.Run, _ *moduletest.File, _ tfdiags.Diagnostics) {
+}
+
+func (t *TestJUnitXMLFile) Interrupted() {}
+
+func (t *TestJUnitXMLFile) FatalInterrupt() {}
+
+func (t *TestJUnitXMLFile) FatalInterruptSummary(_ *moduletest.Run, _ *moduletest.File, _ map[*moduletest.Run]*states.State, _ []*plans.ResourceInstanceChangeSrc) {
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Cases     []*junitTestCase `xml:"testcase"`
+	Error     *junitMessage    `xml:"error,omitempty"`
+	SystemErr *junitText       `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemErr *junitText    `xml:"system-err,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+type junitText struct {
+	Body string `xml:",cdata"`
+}
+
+// render builds the JUnit XML report for the given suite. The test files are
+// reported in alphabetical order, which is also the order they are started
+// in, and the run blocks in the order they are declared.
+func (t *TestJUnitXMLFile) render(suite *moduletest.Suite) ([]byte, error) {
+	var names []string
+	for name := range suite.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := junitTestSuites{}
+	for _, name := range names {
+		file := suite.Files[name]
+
+		testSuite := &junitTestSuite{
+			Name:      file.Name,
+			Time:      junitDuration(file.Duration),
+			SystemErr: t.systemErr(file.Diagnostics),
+		}
+
+		for _, run := range file.Runs {
+			testCase := &junitTestCase{
+				Name:      run.Name,
+				Classname: file.Name,
+				Time:      junitDuration(run.Duration),
+			}
+
+			switch run.Status {
+			case moduletest.Pass:
+				// Any diagnostics attached to a passing run block are
+				// warnings, which we still want to be visible in the report.
+				testCase.SystemErr = t.systemErr(run.Diagnostics)
+			case moduletest.Fail:
+				testCase.Failure = &junitMessage{
+					Message: junitSummary(run.Diagnostics, "Test assertions failed"),
+					Body:    t.diagnostics(run.Diagnostics),
+				}
+				testSuite.Failures++
+			case moduletest.Error:
+				testCase.Error = &junitMessage{
+					Message: junitSummary(run.Diagnostics, "Encountered an error"),
+					Body:    t.diagnostics(run.Diagnostics),
+				}
+				testSuite.Errors++
+			default:
+				// Run blocks are skipped if an earlier run block in the same
+				// file errored, or if the test execution was stopped.
+				testCase.Skipped = &junitMessage{
+					Message: "Test run was skipped",
+					Body:    t.diagnostics(run.Diagnostics),
+				}
+				testSuite.Skipped++
+			}
+
+			testSuite.Cases = append(testSuite.Cases, testCase)
+			testSuite.Tests++
+		}
+
+		// Problems with cleaning up after the test file are not part of any
+		// of its run blocks, so they are reported on the testsuite. Failing
+		// to destroy the resources counts as an error, while any warnings
+		// are only included in the output.
+		destroyErr, destroyOut := t.destroyReport(t.destroys[file.Name])
+		if destroyErr != nil {
+			testSuite.Error = destroyErr
+			testSuite.Errors++
+		}
+		if destroyOut != "" {
+			if testSuite.SystemErr == nil {
+				testSuite.SystemErr = &junitText{Body: destroyOut}
+			} else {
+				testSuite.SystemErr.Body += "\n\n" + destroyOut
+			}
+		}
+
+		report.Suites = append(report.Suites, testSuite)
+		report.Tests += testSuite.Tests
+		report.Failures += testSuite.Failures
+		report.Errors += testSuite.Errors
+		report.Skipped += testSuite.Skipped
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// destroyReport returns the results of cleaning up the states of a test file
+// as an error if any of the states could not be destroyed completely, and as
+// output for the system-err element otherwise.
+func (t *TestJUnitXMLFile) destroyReport(destroys []junitDestroy) (*junitMessage, string) {
+	var errs, out []string
+	for _, destroy := range destroys {
+		if !destroy.diags.HasErrors() && len(destroy.leftover) == 0 {
+			out = append(out, t.diagnostics(destroy.diags))
+			continue
+		}
+
+		var sb strings.Builder
+		if destroy.diags.HasErrors() {
+			fmt.Fprintf(&sb, "OpenTofu encountered an error destroying resources created while executing %s.\n\n", destroy.identifier)
+		}
+		if len(destroy.diags) > 0 {
+			sb.WriteString(t.diagnostics(destroy.diags))
+			sb.WriteString("\n\n")
+		}
+		if len(destroy.leftover) > 0 {
+			fmt.Fprintf(&sb, "OpenTofu left the following resources in state after executing %s, and they need to be cleaned up manually:\n", destroy.identifier)
+			for _, addr := range destroy.leftover {
+				fmt.Fprintf(&sb, "  - %s\n", addr)
+			}
+		}
+		errs = append(errs, strings.TrimSpace(sb.String()))
+	}
+
+	var destroyErr *junitMessage
+	if len(errs) > 0 {
+		destroyErr = &junitMessage{
+			Message: "Failed to destroy the resources created by the tests",
+			Body:    strings.Join(errs, "\n\n"),
+		}
+	}
+	return destroyErr, strings.Join(out, "\n\n")
+}
+
+// diagnostics renders the given diagnostics as plain text, without any
+// terminal formatting sequences.
+func (t *TestJUnitXMLFile) diagnostics(diags tfdiags.Diagnostics) string {
+	if len(diags) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, diag := range diags {
+		sb.WriteString(format.DiagnosticPlain(diag, t.view.configSources(), 0))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// systemErr returns the given diagnostics as the content of a system-err
+// element, or nil if there are no diagnostics.
+func (t *TestJUnitXMLFile) systemErr(diags tfdiags.Diagnostics) *junitText {
+	if len(diags) == 0 {
+		return nil
+	}
+	return &junitText{Body: t.diagnostics(diags)}
+}
+
+// junitSummary returns the summary of the first error in the given
+// diagnostics, for use as the message of a failure or an error.
+func junitSummary(diags tfdiags.Diagnostics, fallback string) string {
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Error {
+			return diag.Description().Summary
+		}
+	}
+	return fallback
+}
+
+// junitDuration formats a duration as the number of seconds, which is the
+// format JUnit uses for the time attributes.
+func junitDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
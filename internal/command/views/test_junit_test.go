@@ -0,0 +1,194 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/moduletest"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/terminal"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestTestJUnitXMLFile(t *testing.T) {
+	var failDiags, errorDiags, warnDiags tfdiags.Diagnostics
+	failDiags = failDiags.Append(tfdiags.Sourceless(tfdiags.Error, "Test assertion failed", "bad value"))
+	errorDiags = errorDiags.Append(tfdiags.Sourceless(tfdiags.Error, "Invalid reference", "no such output"))
+	warnDiags = warnDiags.Append(tfdiags.Sourceless(tfdiags.Warning, "Deprecated", "old argument"))
+
+	suite := &moduletest.Suite{
+		Status: moduletest.Error,
+		Files: map[string]*moduletest.File{
+			"b.tftest.hcl": {
+				Name:     "b.tftest.hcl",
+				Status:   moduletest.Error,
+				Duration: 3 * time.Second,
+				Runs: []*moduletest.Run{
+					{Name: "errored", Status: moduletest.Error, Duration: time.Second, Diagnostics: errorDiags},
+					{Name: "skipped", Status: moduletest.Skip},
+				},
+			},
+			"a.tftest.hcl": {
+				Name:     "a.tftest.hcl",
+				Status:   moduletest.Fail,
+				Duration: 2500 * time.Millisecond,
+				Runs: []*moduletest.Run{
+					{Name: "passed", Status: moduletest.Pass, Duration: 1500 * time.Millisecond, Diagnostics: warnDiags},
+					{Name: "failed", Status: moduletest.Fail, Duration: 250 * time.Millisecond, Diagnostics: failDiags},
+				},
+			},
+		},
+	}
+
+	streams, done := terminal.StreamsForTesting(t)
+	filename := filepath.Join(t.TempDir(), "report.xml")
+	view := NewTestJUnitXMLFile(filename, NewView(streams))
+	view.Conclusion(suite)
+
+	if output := done(t).All(); output != "" {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="1" errors="1" skipped="1">
+  <testsuite name="a.tftest.hcl" tests="2" failures="1" errors="0" skipped="0" time="2.500">
+    <testcase name="passed" classname="a.tftest.hcl" time="1.500">
+      <system-err><![CDATA[Warning: Deprecated
+
+old argument]]></system-err>
+    </testcase>
+    <testcase name="failed" classname="a.tftest.hcl" time="0.250">
+      <failure message="Test assertion failed"><![CDATA[Error: Test assertion failed
+
+bad value]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="b.tftest.hcl" tests="2" failures="0" errors="1" skipped="1" time="3.000">
+    <testcase name="errored" classname="b.tftest.hcl" time="1.000">
+      <error message="Invalid reference"><![CDATA[Error: Invalid reference
+
+no such output]]></error>
+    </testcase>
+    <testcase name="skipped" classname="b.tftest.hcl" time="0.000">
+      <skipped message="Test run was skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if diff := cmp.Diff(want, string(got)); len(diff) > 0 {
+		t.Errorf("wrong report\n%s", diff)
+	}
+}
+
+func TestTestJUnitXMLFile_destroy(t *testing.T) {
+	var destroyDiags, warnDiags tfdiags.Diagnostics
+	destroyDiags = destroyDiags.Append(tfdiags.Sourceless(tfdiags.Error, "Failed to destroy", "resource is protected"))
+	warnDiags = warnDiags.Append(tfdiags.Sourceless(tfdiags.Warning, "Deprecated", "old argument"))
+
+	leftover := states.BuildState(func(state *states.SyncState) {
+		state.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test",
+				Name: "foo",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				Status: states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Module:   addrs.RootModule,
+				Provider: addrs.NewDefaultProvider("test"),
+			}, addrs.NoKey)
+	})
+
+	run := &moduletest.Run{Name: "setup", Status: moduletest.Pass, Duration: time.Second}
+	failed := &moduletest.File{
+		Name:     "a.tftest.hcl",
+		Status:   moduletest.Pass,
+		Duration: time.Second,
+		Runs:     []*moduletest.Run{run},
+	}
+	warned := &moduletest.File{
+		Name:     "b.tftest.hcl",
+		Status:   moduletest.Pass,
+		Duration: time.Second,
+		Runs:     []*moduletest.Run{{Name: "main", Status: moduletest.Pass, Duration: time.Second}},
+	}
+	suite := &moduletest.Suite{
+		Status: moduletest.Pass,
+		Files: map[string]*moduletest.File{
+			failed.Name: failed,
+			warned.Name: warned,
+		},
+	}
+
+	streams, done := terminal.StreamsForTesting(t)
+	filename := filepath.Join(t.TempDir(), "report.xml")
+	view := NewTestJUnitXMLFile(filename, NewView(streams))
+	view.DestroySummary(destroyDiags, run, failed, leftover)
+	view.DestroySummary(nil, nil, failed, states.NewState())
+	view.DestroySummary(warnDiags, nil, warned, states.NewState())
+	view.Conclusion(suite)
+
+	if output := done(t).All(); output != "" {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="0" errors="1" skipped="0">
+  <testsuite name="a.tftest.hcl" tests="1" failures="0" errors="1" skipped="0" time="1.000">
+    <testcase name="setup" classname="a.tftest.hcl" time="1.000"></testcase>
+    <error message="Failed to destroy the resources created by the tests"><![CDATA[OpenTofu encountered an error destroying resources created while executing a.tftest.hcl/setup.
+
+Error: Failed to destroy
+
+resource is protected
+
+OpenTofu left the following resources in state after executing a.tftest.hcl/setup, and they need to be cleaned up manually:
+  - test.foo]]></error>
+  </testsuite>
+  <testsuite name="b.tftest.hcl" tests="1" failures="0" errors="0" skipped="0" time="1.000">
+    <testcase name="main" classname="b.tftest.hcl" time="1.000"></testcase>
+    <system-err><![CDATA[Warning: Deprecated
+
+old argument]]></system-err>
+  </testsuite>
+</testsuites>
+`
+	if diff := cmp.Diff(want, string(got)); len(diff) > 0 {
+		t.Errorf("wrong report\n%s", diff)
+	}
+}
+
+func TestTestJUnitXMLFile_writeError(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	filename := filepath.Join(t.TempDir(), "missing", "report.xml")
+	view := NewTestJUnitXMLFile(filename, NewView(streams))
+	view.Conclusion(&moduletest.Suite{})
+
+	output := done(t).All()
+	if want := "Failed to write JUnit XML report"; !strings.Contains(output, want) {
+		t.Errorf("output didn't contain %q:\n%s", want, output)
+	}
+}
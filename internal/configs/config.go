@@ -431,6 +431,14 @@ func (c *Config) addProviderRequirements(reqs getproviders.Requirements, recurse
 		}
 		reqs[fqn] = nil
 	}
+	for _, rc := range c.Module.EphemeralResources {
+		fqn := rc.Provider
+		if _, exists := reqs[fqn]; exists {
+			// Explicit dependency already present
+			continue
+		}
+		reqs[fqn] = nil
+	}
 	for _, i := range c.Module.Import {
 		implied, err := addrs.ParseProviderPart(i.StaticTo.Resource.ImpliedProvider())
 		if err == nil {
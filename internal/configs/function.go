@@ -0,0 +1,400 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang"
+)
+
+// Function represents a user-defined function declared in a "function" block.
+//
+// The function is callable from anywhere in the module that declares it,
+// using the "module::" namespace, e.g. module::slug("Hello World").
+type Function struct {
+	Name        string
+	Description string
+
+	// Parameters are the positional parameters of the function, in the
+	// order they are declared.
+	Parameters []*FunctionParameter
+
+	// Result is the expression that produces the return value of the
+	// function. It can refer only to the function's parameters, by name.
+	Result hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+// FunctionParameter represents a single "parameter" block within a
+// "function" block.
+type FunctionParameter struct {
+	Name        string
+	Description string
+
+	// ConstraintType is used for argument conversion, while Type includes
+	// no optional attributes.
+	Type           cty.Type
+	ConstraintType cty.Type
+	TypeDefaults   *typeexpr.Defaults
+
+	Nullable bool
+
+	DeclRange hcl.Range
+}
+
+func decodeFunctionBlock(block *hcl.Block, override bool) (*Function, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	f := &Function{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	if override {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cannot override 'function' blocks",
+			Detail:   "Function blocks can appear only in normal files, not in override files.",
+			Subject:  f.DeclRange.Ptr(),
+		})
+		return nil, diags
+	}
+
+	if !hclsyntax.ValidIdentifier(f.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid function name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	content, moreDiags := block.Body.Content(functionBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &f.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	params := map[string]*FunctionParameter{}
+	for _, block := range content.Blocks {
+		p, pDiags := decodeFunctionParameterBlock(block)
+		diags = append(diags, pDiags...)
+		if p == nil {
+			continue
+		}
+		if existing, exists := params[p.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate function parameter",
+				Detail:   fmt.Sprintf("A parameter named %q was already declared at %s. Parameter names must be unique within a function.", existing.Name, existing.DeclRange),
+				Subject:  &p.DeclRange,
+			})
+			continue
+		}
+		params[p.Name] = p
+		f.Parameters = append(f.Parameters, p)
+	}
+
+	if attr, exists := content.Attributes["result"]; exists {
+		f.Result = attr.Expr
+
+		// The result is evaluated with only the parameters in scope, so
+		// any other reference can never be valid.
+		for _, traversal := range f.Result.Variables() {
+			name := traversal.RootName()
+			if _, ok := params[name]; ok {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid reference in function result",
+				Detail:   fmt.Sprintf("The result of function %q can refer only to the function's parameters, and %q is not a parameter.", f.Name, name),
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+		}
+
+		for _, traversal := range f.functionCalls() {
+			fn := addrs.ParseFunction(traversal.RootName())
+			if fn.IsNamespace(addrs.FunctionNamespaceProvider) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid function call in function result",
+					Detail:   fmt.Sprintf("The result of function %q cannot call provider functions.", f.Name),
+					Subject:  traversal.SourceRange().Ptr(),
+				})
+			}
+		}
+	}
+
+	return f, diags
+}
+
+func decodeFunctionParameterBlock(block *hcl.Block) (*FunctionParameter, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	p := &FunctionParameter{
+		Name:           block.Labels[0],
+		Type:           cty.DynamicPseudoType,
+		ConstraintType: cty.DynamicPseudoType,
+		Nullable:       true,
+		DeclRange:      block.DefRange,
+	}
+
+	if !hclsyntax.ValidIdentifier(p.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid parameter name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+		return nil, diags
+	}
+
+	content, moreDiags := block.Body.Content(functionParameterBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &p.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["type"]; exists {
+		ty, tyDefaults, _, tyDiags := decodeVariableType(attr.Expr)
+		diags = append(diags, tyDiags...)
+		p.ConstraintType = ty
+		p.TypeDefaults = tyDefaults
+		p.Type = ty.WithoutOptionalAttributesDeep()
+	}
+
+	if attr, exists := content.Attributes["nullable"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &p.Nullable)
+		diags = append(diags, valDiags...)
+	}
+
+	return p, diags
+}
+
+// functionCalls returns the traversals of all of the function calls in the
+// result expression of the function.
+func (f *Function) functionCalls() []hcl.Traversal {
+	if fexpr, ok := f.Result.(hcl.ExpressionWithFunctions); ok {
+		return fexpr.Functions()
+	}
+	return nil
+}
+
+// moduleFunctionCalls returns the names of the other functions of the module
+// that the result expression of the function calls, without their namespace.
+func (f *Function) moduleFunctionCalls() map[string]hcl.Range {
+	calls := map[string]hcl.Range{}
+	for _, traversal := range f.functionCalls() {
+		name, ok := strings.CutPrefix(traversal.RootName(), lang.ModuleNamespace)
+		if !ok {
+			continue
+		}
+		if _, exists := calls[name]; !exists {
+			calls[name] = traversal.SourceRange()
+		}
+	}
+	return calls
+}
+
+// Function returns the implementation of the function. The result
+// expression is evaluated with the functions returned by funcs, which
+// should be all of the functions available in the module.
+func (f *Function) Function(funcs func() map[string]function.Function) function.Function {
+	params := make([]function.Parameter, len(f.Parameters))
+	for i, p := range f.Parameters {
+		// Arguments for a type with optional attributes are converted by
+		// call instead, so that the type defaults can be applied first.
+		ty := p.Type
+		if !p.ConstraintType.Equals(p.Type) {
+			ty = cty.DynamicPseudoType
+		}
+		params[i] = function.Parameter{
+			Name:             p.Name,
+			Description:      p.Description,
+			Type:             ty,
+			AllowNull:        p.Nullable,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+			AllowMarked:      true,
+		}
+	}
+
+	return function.New(&function.Spec{
+		Description: f.Description,
+		Params:      params,
+		// The result type is only known once the result expression is
+		// evaluated, which is left to Impl so that the expression is not
+		// evaluated twice for every call.
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return f.call(args, funcs)
+		},
+	})
+}
+
+func (f *Function) call(args []cty.Value, funcs func() map[string]function.Function) (cty.Value, error) {
+	vars := make(map[string]cty.Value, len(f.Parameters))
+	for i, p := range f.Parameters {
+		arg := args[i]
+		if p.TypeDefaults != nil && !arg.IsNull() {
+			arg = p.TypeDefaults.Apply(arg)
+		}
+		arg, err := convert.Convert(arg, p.ConstraintType)
+		if err != nil {
+			return cty.DynamicVal, function.NewArgErrorf(i, "Invalid value for %q parameter: %s.", p.Name, err)
+		}
+		vars[p.Name] = arg
+	}
+
+	val, diags := f.Result.Value(&hcl.EvalContext{
+		Variables: vars,
+		Functions: funcs(),
+	})
+	if diags.HasErrors() {
+		return cty.DynamicVal, fmt.Errorf("in function %q: %w", f.Name, diags)
+	}
+	return val, nil
+}
+
+// checkModuleFunctions validates the calls between the functions of the
+// given module, which can only be done once all of its files are merged.
+func checkModuleFunctions(mod *Module) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	names := make([]string, 0, len(mod.Functions))
+	for name := range mod.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := mod.Functions[name]
+		for called, rng := range f.moduleFunctionCalls() {
+			if _, exists := mod.Functions[called]; !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Call to unknown function",
+					Detail:   fmt.Sprintf("There is no function named %q declared in this module.", called),
+					Subject:  rng.Ptr(),
+				})
+			}
+		}
+	}
+
+	// Recursion is not allowed, because the result of a function is a
+	// single expression with no way to stop it.
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(mod.Functions))
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case visited:
+			return
+		case visiting:
+			start := 0
+			for i, n := range path {
+				if n == name {
+					start = i
+				}
+			}
+			cycle := append(path[start:len(path):len(path)], name)
+			f := mod.Functions[name]
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Recursive function call",
+				Detail:   fmt.Sprintf("Function %q calls itself through %s. Functions cannot be recursive.", name, strings.Join(cycle, " -> ")),
+				Subject:  f.DeclRange.Ptr(),
+			})
+			return
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		calls := mod.Functions[name].moduleFunctionCalls()
+		called := make([]string, 0, len(calls))
+		for n := range calls {
+			if _, exists := mod.Functions[n]; exists {
+				called = append(called, n)
+			}
+		}
+		sort.Strings(called)
+		for _, n := range called {
+			visit(n)
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+	for _, name := range names {
+		visit(name)
+	}
+
+	return diags
+}
+
+// ScopeFunctions returns the user-defined functions of the module in the
+// form expected by lang.Scope.
+func (m *Module) ScopeFunctions() map[string]lang.ModuleFunction {
+	if m == nil || len(m.Functions) == 0 {
+		return nil
+	}
+	ret := make(map[string]lang.ModuleFunction, len(m.Functions))
+	for name, f := range m.Functions {
+		ret[name] = f.Function
+	}
+	return ret
+}
+
+var functionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "description",
+		},
+		{
+			Name:     "result",
+			Required: true,
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "parameter",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+var functionParameterBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "description",
+		},
+		{
+			Name: "type",
+		},
+		{
+			Name: "nullable",
+		},
+	},
+}
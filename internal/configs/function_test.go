@@ -0,0 +1,173 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestFunction_call(t *testing.T) {
+	f, diags := NewParser(nil).LoadConfigFile("testdata/valid-files/functions.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	mod, diags := NewModule([]*File{f}, nil, RootModuleCallForTesting(), "testdata/valid-files", SelectiveLoadAll)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	tests := map[string]struct {
+		expr    string
+		want    cty.Value
+		wantErr string
+	}{
+		"simple": {
+			expr: `module::slug("Hello World")`,
+			want: cty.StringVal("hello-world"),
+		},
+		"calls another function": {
+			expr: `module::prefixed("app", ["Web Server", "DB"])`,
+			want: cty.TupleVal([]cty.Value{
+				cty.StringVal("app-web-server"),
+				cty.StringVal("app-db"),
+			}),
+		},
+		"type defaults": {
+			expr: `module::settings({ name = "x" })`,
+			want: cty.StringVal("x"),
+		},
+		"unknown argument": {
+			expr: `module::slug(var.unknown)`,
+			want: cty.UnknownVal(cty.String).RefineNotNull(),
+		},
+		"sensitive argument": {
+			expr: `module::slug(var.sensitive)`,
+			want: cty.StringVal("secret-name").Mark(marks.Sensitive),
+		},
+		"argument conversion": {
+			expr: `module::slug(12)`,
+			want: cty.StringVal("12"),
+		},
+		"invalid argument type": {
+			expr:    `module::slug(["a"])`,
+			wantErr: `Invalid value for "value" parameter: string required.`,
+		},
+		"invalid object argument": {
+			expr:    `module::settings({ enabled = false })`,
+			wantErr: `Invalid value for "config" parameter: attribute "name" is required.`,
+		},
+		"null argument": {
+			expr:    `module::slug(null)`,
+			wantErr: `Invalid value for "value" parameter: argument must not be null.`,
+		},
+		"wrong number of arguments": {
+			expr:    `module::slug("a", "b")`,
+			wantErr: `Function "module::slug" expects only 1 argument(s).`,
+		},
+		"unknown function": {
+			expr:    `module::nope("a")`,
+			wantErr: `There is no function named "nope"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, hclDiags := hclsyntax.ParseExpression([]byte(test.expr), "test.tf", hcl.InitialPos)
+			if hclDiags.HasErrors() {
+				t.Fatal(hclDiags.Error())
+			}
+
+			scope := &lang.Scope{
+				ModuleFunctions: mod.ScopeFunctions(),
+			}
+			ctx, ctxDiags := scope.EvalContext(nil)
+			if ctxDiags.HasErrors() {
+				t.Fatal(ctxDiags.Err())
+			}
+			ctx.Variables = map[string]cty.Value{
+				"var": cty.ObjectVal(map[string]cty.Value{
+					"unknown":   cty.UnknownVal(cty.String),
+					"sensitive": cty.StringVal("Secret Name").Mark(marks.Sensitive),
+				}),
+			}
+
+			got, hclDiags := expr.Value(ctx)
+			if test.wantErr != "" {
+				if !hclDiags.HasErrors() {
+					t.Fatalf("unexpected success\ngot: %#v", got)
+				}
+				if !strings.Contains(hclDiags.Error(), test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", hclDiags.Error(), test.wantErr)
+				}
+				return
+			}
+			if hclDiags.HasErrors() {
+				t.Fatal(hclDiags.Error())
+			}
+			if !got.RawEquals(test.want) {
+				t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFunction_evaluatedOnce(t *testing.T) {
+	calls := 0
+	funcs := map[string]function.Function{
+		"count": function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "value", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				calls++
+				return args[0], nil
+			},
+		}),
+	}
+
+	result, hclDiags := hclsyntax.ParseExpression([]byte(`count(value)`), "test.tf", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatal(hclDiags.Error())
+	}
+	f := &Function{
+		Name: "counted",
+		Parameters: []*FunctionParameter{
+			{Name: "value", Type: cty.String, ConstraintType: cty.String},
+		},
+		Result: result,
+	}
+
+	got, err := f.Function(func() map[string]function.Function { return funcs }).Call([]cty.Value{cty.StringVal("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.RawEquals(cty.StringVal("a")) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, cty.StringVal("a"))
+	}
+	if calls != 1 {
+		t.Fatalf("result expression was evaluated %d times, want 1", calls)
+	}
+}
+
+func TestFunction_recursion(t *testing.T) {
+	_, diags := testModuleFromDir("testdata/invalid-modules/function-recursion")
+	if !diags.HasErrors() {
+		t.Fatal("unexpected success")
+	}
+
+	want := `Function "even" calls itself through even -> odd -> even. Functions cannot be recursive.`
+	if got := diags.Error(); !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
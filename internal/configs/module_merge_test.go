@@ -305,6 +305,42 @@ func TestModuleOverrideSensitiveVariable(t *testing.T) {
 	}
 }
 
+func TestModuleOverrideEphemeral(t *testing.T) {
+	mod, diags := testModuleFromDir("testdata/valid-modules/override-variable-ephemeral")
+
+	assertNoDiagnostics(t, diags)
+
+	if mod == nil {
+		t.Fatalf("module is nil")
+	}
+
+	varCases := map[string]bool{
+		"false_true": true,
+		"true_false": false,
+		"unset_true": true,
+		"true_unset": true,
+	}
+	for name, want := range varCases {
+		t.Run(fmt.Sprintf("variable %s", name), func(t *testing.T) {
+			if got := mod.Variables[name].Ephemeral; got != want {
+				t.Errorf("wrong result for ephemeral\ngot: %t want: %t", got, want)
+			}
+		})
+	}
+
+	outputCases := map[string]bool{
+		"false_true": true,
+		"true_false": false,
+	}
+	for name, want := range outputCases {
+		t.Run(fmt.Sprintf("output %s", name), func(t *testing.T) {
+			if got := mod.Outputs[name].Ephemeral; got != want {
+				t.Errorf("wrong result for ephemeral\ngot: %t want: %t", got, want)
+			}
+		})
+	}
+}
+
 func TestModuleOverrideResourceFQNs(t *testing.T) {
 	mod, diags := testModuleFromDir("testdata/valid-modules/override-resource-provider")
 	assertNoDiagnostics(t, diags)
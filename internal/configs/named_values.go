@@ -7,6 +7,7 @@ package configs
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/typeexpr"
@@ -38,8 +39,20 @@ type Variable struct {
 	Validations []*CheckRule
 	Sensitive   bool
 
+	// Ephemeral indicates that values of this variable must never be
+	// persisted in state or plan files. An ephemeral root module variable
+	// must therefore be set again when applying a saved plan.
+	Ephemeral bool
+
+	// Deprecated, if not empty, is a message explaining that this variable
+	// is deprecated. Callers that set the variable in a module block will
+	// get a warning including this message.
+	Deprecated string
+
 	DescriptionSet bool
 	SensitiveSet   bool
+	EphemeralSet   bool
+	DeprecatedSet  bool
 
 	// Nullable indicates that null is a valid value for this variable. Setting
 	// Nullable to false means that the module can expect this variable to
@@ -122,6 +135,18 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		v.SensitiveSet = true
 	}
 
+	if attr, exists := content.Attributes["ephemeral"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.Ephemeral)
+		diags = append(diags, valDiags...)
+		v.EphemeralSet = true
+	}
+
+	if attr, exists := content.Attributes["deprecated"]; exists {
+		valDiags := decodeDeprecatedMessage(attr, &v.Deprecated)
+		diags = append(diags, valDiags...)
+		v.DeprecatedSet = true
+	}
+
 	if attr, exists := content.Attributes["nullable"]; exists {
 		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.Nullable)
 		diags = append(diags, valDiags...)
@@ -194,6 +219,24 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 	return v, diags
 }
 
+// decodeDeprecatedMessage decodes the "deprecated" argument of a variable or
+// output block, which must be a non-empty string explaining the deprecation.
+func decodeDeprecatedMessage(attr *hcl.Attribute, msg *string) hcl.Diagnostics {
+	diags := gohcl.DecodeExpression(attr.Expr, nil, msg)
+	if diags.HasErrors() {
+		return diags
+	}
+	if strings.TrimSpace(*msg) == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid deprecation message",
+			Detail:   "The deprecated argument must be a non-empty string explaining what to use instead.",
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	}
+	return diags
+}
+
 func decodeVariableType(expr hcl.Expression) (cty.Type, *typeexpr.Defaults, VariableParsingMode, hcl.Diagnostics) {
 	if exprIsNativeQuotedString(expr) {
 		// If a user provides the pre-0.12 form of variable type argument where
@@ -400,10 +443,22 @@ type Output struct {
 	DependsOn   []hcl.Traversal
 	Sensitive   bool
 
+	// Ephemeral indicates that the output value may be derived from
+	// ephemeral values, and so must never be persisted in state or plan
+	// files. Only outputs of child modules may be ephemeral.
+	Ephemeral bool
+
+	// Deprecated, if not empty, is a message explaining that this output
+	// is deprecated. Callers that refer to the output of a module call will
+	// get a warning including this message.
+	Deprecated string
+
 	Preconditions []*CheckRule
 
 	DescriptionSet bool
 	SensitiveSet   bool
+	EphemeralSet   bool
+	DeprecatedSet  bool
 
 	DeclRange hcl.Range
 
@@ -457,6 +512,18 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 		o.SensitiveSet = true
 	}
 
+	if attr, exists := content.Attributes["ephemeral"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Ephemeral)
+		diags = append(diags, valDiags...)
+		o.EphemeralSet = true
+	}
+
+	if attr, exists := content.Attributes["deprecated"]; exists {
+		valDiags := decodeDeprecatedMessage(attr, &o.Deprecated)
+		diags = append(diags, valDiags...)
+		o.DeprecatedSet = true
+	}
+
 	if attr, exists := content.Attributes["depends_on"]; exists {
 		deps, depsDiags := decodeDependsOn(attr)
 		diags = append(diags, depsDiags...)
@@ -548,6 +615,12 @@ var variableBlockSchema = &hcl.BodySchema{
 		{
 			Name: "sensitive",
 		},
+		{
+			Name: "ephemeral",
+		},
+		{
+			Name: "deprecated",
+		},
 		{
 			Name: "nullable",
 		},
@@ -574,6 +647,12 @@ var outputBlockSchema = &hcl.BodySchema{
 		{
 			Name: "sensitive",
 		},
+		{
+			Name: "ephemeral",
+		},
+		{
+			Name: "deprecated",
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "precondition"},
@@ -104,11 +104,36 @@ func TestParserLoadConfigFileFailureMessages(t *testing.T) {
 			hcl.DiagError,
 			"Invalid data resource lifecycle argument",
 		},
+		{
+			"invalid-files/ephemeral-resource-lifecycle.tf",
+			hcl.DiagError,
+			"Invalid ephemeral resource lifecycle argument",
+		},
 		{
 			"invalid-files/variable-type-unknown.tf",
 			hcl.DiagError,
 			"Invalid type specification",
 		},
+		{
+			"invalid-files/variable-deprecated-empty.tf",
+			hcl.DiagError,
+			"Invalid deprecation message",
+		},
+		{
+			"invalid-files/enabled-with-count.tf",
+			hcl.DiagError,
+			`Invalid combination of "enabled" and "count" or "for_each"`,
+		},
+		{
+			"invalid-files/removed-provisioner-create.tf",
+			hcl.DiagError,
+			"Invalid provisioner in removed block",
+		},
+		{
+			"invalid-files/removed-provisioner-forget.tf",
+			hcl.DiagError,
+			"Invalid provisioner in removed block",
+		},
 		{
 			"invalid-files/unexpected-attr.tf",
 			hcl.DiagError,
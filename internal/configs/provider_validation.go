@@ -291,14 +291,18 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 	for name, child := range cfg.Children {
 		mc := mod.ModuleCalls[name]
 		childNoProviderConfigRange := noProviderConfigRange
-		// if the module call has any of count, for_each or depends_on,
-		// providers are prohibited from being configured in this module, or
-		// any module beneath this module.
+		// if the module call has any of count, for_each, enabled or
+		// depends_on, providers are prohibited from being configured in this
+		// module, or any module beneath this module.
 		switch {
 		case mc.Count != nil:
 			childNoProviderConfigRange = mc.Count.Range().Ptr()
 		case mc.ForEach != nil:
 			childNoProviderConfigRange = mc.ForEach.Range().Ptr()
+		case mc.Enabled != nil:
+			// A disabled module would leave the resources it already
+			// created without a provider configuration to destroy them.
+			childNoProviderConfigRange = mc.Enabled.Range().Ptr()
 		case mc.DependsOn != nil:
 			if len(mc.DependsOn) > 0 {
 				childNoProviderConfigRange = mc.DependsOn[0].SourceRange().Ptr()
@@ -455,6 +459,7 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 	}
 	checkImpliedProviderNames(mod.ManagedResources)
 	checkImpliedProviderNames(mod.DataResources)
+	checkImpliedProviderNames(mod.EphemeralResources)
 
 	// collect providers passed from the parent
 	if parentCall != nil {
@@ -504,6 +509,7 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 	}
 	checkProviderKeys(mod.ManagedResources)
 	checkProviderKeys(mod.DataResources)
+	checkProviderKeys(mod.EphemeralResources)
 
 	// Verify that any module calls only refer to named providers, and that
 	// those providers will have a configuration at runtime. This way we can
@@ -568,15 +574,15 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 	// there cannot be any configurations if no provider config is allowed
 	if len(configured) > 0 && noProviderConfigRange != nil {
 		// We report this from the perspective of the use of count, for_each,
-		// or depends_on rather than from inside the module, because the
+		// enabled, or depends_on rather than from inside the module, because the
 		// recipient of this message is more likely to be the author of the
 		// calling module (trying to use an older module that hasn't been
 		// updated yet) than of the called module.
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
-			Summary:  "Module is incompatible with count, for_each, and depends_on",
+			Summary:  "Module is incompatible with count, for_each, enabled, and depends_on",
 			Detail: fmt.Sprintf(
-				"The module at %s is a legacy module which contains its own local provider configurations, and so calls to it may not use the count, for_each, or depends_on arguments.\n\nIf you also control the module %q, consider updating this module to instead expect provider configurations to be passed by its caller.",
+				"The module at %s is a legacy module which contains its own local provider configurations, and so calls to it may not use the count, for_each, enabled, or depends_on arguments.\n\nIf you also control the module %q, consider updating this module to instead expect provider configurations to be passed by its caller.",
 				cfg.Path, cfg.SourceAddr,
 			),
 			Subject: noProviderConfigRange,
@@ -6,7 +6,10 @@
 package configs
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/opentofu/opentofu/internal/addrs"
 )
 
@@ -14,6 +17,16 @@ import (
 type Removed struct {
 	From *addrs.RemoveEndpoint
 
+	// Destroy is true if the objects matched by From should be destroyed,
+	// rather than only forgotten from the state.
+	Destroy    bool
+	DestroySet bool
+
+	// Provisioners are the destroy-time provisioners to run before the
+	// matched resource instances are destroyed. These are only allowed
+	// when Destroy is true and From refers to a resource.
+	Provisioners []*Provisioner
+
 	DeclRange hcl.Range
 }
 
@@ -36,6 +49,71 @@ func decodeRemovedBlock(block *hcl.Block) (*Removed, hcl.Diagnostics) {
 		}
 	}
 
+	var seenLifecycle *hcl.Block
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "lifecycle":
+			if seenLifecycle != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate lifecycle block",
+					Detail:   fmt.Sprintf("This removed block already has a lifecycle block at %s.", seenLifecycle.DefRange),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			seenLifecycle = block
+
+			lcContent, lcDiags := block.Body.Content(removedLifecycleBlockSchema)
+			diags = append(diags, lcDiags...)
+
+			if attr, exists := lcContent.Attributes["destroy"]; exists {
+				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &removed.Destroy)
+				diags = append(diags, valDiags...)
+				removed.DestroySet = true
+			}
+
+		case "provisioner":
+			pv, pvDiags := decodeProvisionerBlock(block)
+			diags = append(diags, pvDiags...)
+			if pv == nil {
+				continue
+			}
+			if pv.When != ProvisionerWhenDestroy {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid provisioner in removed block",
+					Detail:   `Provisioners in a removed block must be destroy-time provisioners, declared with when = destroy.`,
+					Subject:  &pv.DeclRange,
+				})
+				continue
+			}
+			removed.Provisioners = append(removed.Provisioners, pv)
+
+		}
+	}
+
+	if len(removed.Provisioners) != 0 {
+		switch {
+		case !removed.Destroy:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provisioner in removed block",
+				Detail:   "Provisioners can only be used in a removed block that destroys its objects. Add a lifecycle block with destroy = true, or remove the provisioners.",
+				Subject:  &removed.Provisioners[0].DeclRange,
+			})
+		case removed.From != nil:
+			if _, ok := removed.From.RelSubject.(addrs.ConfigResource); !ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid provisioner in removed block",
+					Detail:   "Provisioners can only be used in a removed block that refers to a resource, not a whole module.",
+					Subject:  &removed.Provisioners[0].DeclRange,
+				})
+			}
+		}
+	}
+
 	return removed, diags
 }
 
@@ -46,4 +124,16 @@ var removedBlockSchema = &hcl.BodySchema{
 			Required: true,
 		},
 	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "lifecycle"},
+		{Type: "provisioner", LabelNames: []string{"type"}},
+	},
+}
+
+var removedLifecycleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "destroy",
+		},
+	},
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hcltest"
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestRemovedBlock_decode(t *testing.T) {
@@ -68,6 +69,40 @@ func TestRemovedBlock_decode(t *testing.T) {
 			},
 			``,
 		},
+		"destroy": {
+			&hcl.Block{
+				Type: "removed",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"from": {
+							Name: "from",
+							Expr: foo_expr,
+						},
+					},
+					Blocks: hcl.Blocks{
+						{
+							Type: "lifecycle",
+							Body: hcltest.MockBody(&hcl.BodyContent{
+								Attributes: hcl.Attributes{
+									"destroy": {
+										Name: "destroy",
+										Expr: hcltest.MockExprLiteral(cty.True),
+									},
+								},
+							}),
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			&Removed{
+				From:       mustRemoveEndpointFromExpr(foo_expr),
+				Destroy:    true,
+				DestroySet: true,
+				DeclRange:  blockRange,
+			},
+			``,
+		},
 		"error: missing argument": {
 			&hcl.Block{
 				Type: "removed",
@@ -177,6 +212,7 @@ func TestRemovedBlock_inModule(t *testing.T) {
 		`module.a`,
 		`test.foo`,
 		`test.boop`,
+		`test.bar`,
 	}
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("wrong addresses\n%s", diff)
@@ -28,6 +28,8 @@ func newStaticScope(eval *StaticEvaluator, stack ...StaticIdentifier) *lang.Scop
 		BaseDir:     ".", // Always current working directory for now. (same as Evaluator.Scope())
 		PureOnly:    false,
 		ConsoleMode: false,
+
+		ModuleFunctions: eval.cfg.ScopeFunctions(),
 	}
 }
 
@@ -88,6 +88,8 @@ func (file *TestFile) Validate() tfdiags.Diagnostics {
 	diags = diags.Append(checkForDuplicatedOverrideResources(file.OverrideResources))
 	diags = diags.Append(checkForDuplicatedOverrideModules(file.OverrideModules))
 
+	diags = diags.Append(checkForInconsistentStateKeys(file.Runs))
+
 	return diags
 }
 
@@ -183,8 +185,20 @@ type TestRun struct {
 	// Underlying modules shouldn't be called.
 	OverrideModules []*OverrideModule
 
+	// StateKey, if set, names the state this run block operates on. Run blocks
+	// with the same StateKey share a state, so they must execute against the
+	// same module. When unset, run blocks share a state with the other run
+	// blocks that execute against the same module.
+	StateKey string
+
+	// Parallel indicates that this run block may execute concurrently with
+	// the adjacent run blocks that are also marked as parallel, as long as
+	// they don't share a state or refer to each other.
+	Parallel bool
+
 	NameDeclRange      hcl.Range
 	VariablesDeclRange hcl.Range
+	StateKeyDeclRange  hcl.Range
 	DeclRange          hcl.Range
 }
 
@@ -596,6 +610,25 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 		r.ExpectFailures = failures
 	}
 
+	if attr, exists := content.Attributes["state_key"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.StateKey)
+		diags = append(diags, valDiags...)
+		r.StateKeyDeclRange = attr.Expr.Range()
+		if !valDiags.HasErrors() && r.StateKey == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"state_key\" argument",
+				Detail:   "The \"state_key\" argument must not be empty.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	if attr, exists := content.Attributes["parallel"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.Parallel)
+		diags = append(diags, valDiags...)
+	}
+
 	return &r, diags
 }
 
@@ -1002,6 +1035,45 @@ func checkForDuplicatedOverrideModules(modules []*OverrideModule) hcl.Diagnostic
 	return diags
 }
 
+// checkForInconsistentStateKeys ensures that the run blocks sharing a state
+// through the same state_key execute against the same module, since the state
+// of one module can't be applied with the configuration of another.
+func checkForInconsistentStateKeys(runs []*TestRun) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	firstRuns := make(map[string]*TestRun)
+	for _, run := range runs {
+		if run.StateKey == "" {
+			continue
+		}
+
+		first, ok := firstRuns[run.StateKey]
+		if !ok {
+			firstRuns[run.StateKey] = run
+			continue
+		}
+
+		if source, firstSource := run.moduleSourceForDisplay(), first.moduleSourceForDisplay(); source != firstSource {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"state_key\" argument",
+				Detail:   fmt.Sprintf("The run block %q executes against %s, but the run block %q with the same state_key %q executes against %s. Run blocks that share a state must execute against the same module.", run.Name, source, first.Name, run.StateKey, firstSource),
+				Subject:  run.StateKeyDeclRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// moduleSourceForDisplay describes the module the run block executes against.
+func (run *TestRun) moduleSourceForDisplay() string {
+	if run.Module == nil {
+		return "the main configuration"
+	}
+	return fmt.Sprintf("the module %q", run.Module.Source.String())
+}
+
 // testFileSchema defines the structure of test file configuration for tofu tests.
 var testFileSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
@@ -1045,6 +1117,11 @@ var testRunBlockSchema = &hcl.BodySchema{
 		{Name: "providers"},
 		// expect_failures indicates whether test failures are expected.
 		{Name: "expect_failures"},
+		// state_key names the state the run block operates on.
+		{Name: "state_key"},
+		// parallel allows the run block to execute concurrently with the
+		// adjacent parallel run blocks.
+		{Name: "parallel"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
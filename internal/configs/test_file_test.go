@@ -97,3 +97,128 @@ func parseTraversal(t *testing.T, addr string) hcl.Traversal {
 	}
 	return traversal
 }
+
+func TestDecodeTestRunBlock_stateKey(t *testing.T) {
+	tcs := map[string]struct {
+		src        string
+		want       string
+		diagnostic string
+	}{
+		"unset": {
+			src:  `run "test" {}`,
+			want: "",
+		},
+		"set": {
+			src:  `run "test" { state_key = "blue" }`,
+			want: "blue",
+		},
+		"empty": {
+			src:        `run "test" { state_key = "" }`,
+			diagnostic: `The "state_key" argument must not be empty.`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(tc.src), "main.tftest.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected parse errors: %s", diags.Error())
+			}
+			content, diags := file.Body.Content(testFileSchema)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			run, diags := decodeTestRunBlock(content.Blocks[0])
+			if len(tc.diagnostic) > 0 {
+				if len(diags) != 1 || diags[0].Detail != tc.diagnostic {
+					t.Fatalf("expected diagnostic %q, got %s", tc.diagnostic, diags.Error())
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if run.StateKey != tc.want {
+				t.Errorf("wrong state key\ngot:  %q\nwant: %q", run.StateKey, tc.want)
+			}
+		})
+	}
+}
+
+func TestTestFile_Validate_stateKey(t *testing.T) {
+	tcs := map[string]struct {
+		src        string
+		diagnostic string
+	}{
+		"same module": {
+			src: `
+run "a" {
+  state_key = "blue"
+  module {
+    source = "./setup"
+  }
+}
+run "b" {
+  state_key = "blue"
+  module {
+    source = "./setup"
+  }
+}
+`,
+		},
+		"different keys": {
+			src: `
+run "a" {
+  state_key = "blue"
+}
+run "b" {
+  state_key = "green"
+  module {
+    source = "./setup"
+  }
+}
+`,
+		},
+		"different modules": {
+			src: `
+run "a" {
+  state_key = "blue"
+}
+run "b" {
+  state_key = "blue"
+  module {
+    source = "./setup"
+  }
+}
+`,
+			diagnostic: `The run block "b" executes against the module "./setup", but the run block "a" with the same state_key "blue" executes against the main configuration. Run blocks that share a state must execute against the same module.`,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(tc.src), "main.tftest.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected parse errors: %s", diags.Error())
+			}
+			testFile, diags := loadTestFile(file.Body)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			validateDiags := testFile.Validate()
+			if len(tc.diagnostic) == 0 {
+				if len(validateDiags) != 0 {
+					t.Fatalf("expected no diags but got: %s", validateDiags.Err())
+				}
+				return
+			}
+			if len(validateDiags) != 1 {
+				t.Fatalf("expected one diag but got: %s", validateDiags.Err())
+			}
+			if diff := cmp.Diff(tc.diagnostic, validateDiags[0].Description().Detail); len(diff) > 0 {
+				t.Fatalf("unexpected diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+variable "create" {
+  type    = bool
+  default = true
+}
+
+module "child" {
+  source = "./child"
+
+  lifecycle {
+    enabled = var.create
+  }
+}
@@ -0,0 +1,27 @@
+variable "suffix" {
+  type = string
+}
+
+function "bad reference" { # ERROR: Invalid function name
+  parameter "value" {
+    type = string
+  }
+
+  result = "${value}-${var.suffix}" # ERROR: Invalid reference in function result
+}
+
+function "duplicate_parameter" {
+  parameter "value" {
+  }
+  parameter "value" { # ERROR: Duplicate function parameter
+  }
+
+  result = value
+}
+
+function "provider_call" {
+  parameter "value" {
+  }
+
+  result = provider::test::echo(value) # ERROR: Invalid function call in function result
+}
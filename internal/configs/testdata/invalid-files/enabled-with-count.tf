@@ -0,0 +1,7 @@
+resource "aws_instance" "web" {
+  count = 2
+
+  lifecycle {
+    enabled = true
+  }
+}
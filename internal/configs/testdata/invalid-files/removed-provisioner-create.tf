@@ -0,0 +1,11 @@
+removed {
+  from = test.foo
+
+  lifecycle {
+    destroy = true
+  }
+
+  provisioner "local-exec" {
+    command = "echo hello"
+  }
+}
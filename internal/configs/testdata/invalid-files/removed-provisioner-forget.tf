@@ -0,0 +1,8 @@
+removed {
+  from = test.foo
+
+  provisioner "local-exec" {
+    when    = destroy
+    command = "echo ${self.id}"
+  }
+}
@@ -0,0 +1,4 @@
+variable "foo" {
+  # The deprecation message must explain what to use instead.
+  deprecated = ""
+}
@@ -0,0 +1,15 @@
+function "even" {
+  parameter "n" {
+    type = number
+  }
+
+  result = n == 0 ? true : module::odd(n - 1)
+}
+
+function "odd" {
+  parameter "n" {
+    type = number
+  }
+
+  result = n == 0 ? false : module::even(n - 1)
+}
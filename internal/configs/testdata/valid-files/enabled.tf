@@ -0,0 +1,26 @@
+variable "create" {
+  type    = bool
+  default = true
+}
+
+resource "aws_instance" "web" {
+  ami = "ami-1234"
+
+  lifecycle {
+    enabled = var.create
+  }
+}
+
+data "aws_ami" "web" {
+  lifecycle {
+    enabled = var.create
+  }
+}
+
+module "child" {
+  source = "./child"
+
+  lifecycle {
+    enabled = !var.create
+  }
+}
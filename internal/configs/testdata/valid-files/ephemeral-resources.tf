@@ -0,0 +1,18 @@
+ephemeral "vault_secret" "example1" {
+}
+
+ephemeral "vault_secret" "example2" {
+  path = "secret/example"
+
+  count = 5
+  depends_on = [
+    ephemeral.vault_secret.example1,
+  ]
+
+  lifecycle {
+    postcondition {
+      condition     = self.data != null
+      error_message = "The secret must have data."
+    }
+  }
+}
@@ -0,0 +1,34 @@
+
+function "slug" {
+  description = "Converts a name into a lowercase, dash-separated slug."
+
+  parameter "value" {
+    type        = string
+    description = "The name to convert."
+    nullable    = false
+  }
+
+  result = replace(lower(value), " ", "-")
+}
+
+function "prefixed" {
+  parameter "prefix" {
+    type = string
+  }
+  parameter "names" {
+    type = list(string)
+  }
+
+  result = [for name in names : "${prefix}-${module::slug(name)}"]
+}
+
+function "settings" {
+  parameter "config" {
+    type = object({
+      name    = string
+      enabled = optional(bool, true)
+    })
+  }
+
+  result = config.enabled ? config.name : null
+}
@@ -23,3 +23,13 @@ output "π" {
     pizza.cheese,
   ]
 }
+
+output "ephemeral" {
+  value     = var.token
+  ephemeral = true
+}
+
+output "deprecated" {
+  value      = "old"
+  deprecated = "Use the ephemeral output instead."
+}
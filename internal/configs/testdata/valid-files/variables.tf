@@ -42,3 +42,14 @@ variable "nullable_default_null" {
   nullable = true
   default = null
 }
+
+variable "ephemeral_value" {
+  type      = string
+  ephemeral = true
+}
+
+variable "deprecated_value" {
+  type       = string
+  default    = ""
+  deprecated = "Use ephemeral_value instead."
+}
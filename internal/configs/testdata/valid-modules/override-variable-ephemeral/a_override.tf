@@ -0,0 +1,23 @@
+variable "false_true" {
+  ephemeral = true
+}
+
+variable "true_false" {
+  ephemeral = false
+}
+
+variable "unset_true" {
+  ephemeral = true
+}
+
+variable "true_unset" {
+  description = "Overridden without changing ephemerality"
+}
+
+output "false_true" {
+  ephemeral = true
+}
+
+output "true_false" {
+  ephemeral = false
+}
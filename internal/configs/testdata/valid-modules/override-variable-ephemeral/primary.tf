@@ -0,0 +1,24 @@
+variable "false_true" {
+  ephemeral = false
+}
+
+variable "true_false" {
+  ephemeral = true
+}
+
+variable "unset_true" {
+}
+
+variable "true_unset" {
+  ephemeral = true
+}
+
+output "false_true" {
+  value     = var.false_true
+  ephemeral = false
+}
+
+output "true_false" {
+  value     = var.true_false
+  ephemeral = true
+}
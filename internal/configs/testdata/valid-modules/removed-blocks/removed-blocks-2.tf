@@ -3,3 +3,16 @@
 removed {
   from = test.boop
 }
+
+removed {
+  from = test.bar
+
+  lifecycle {
+    destroy = true
+  }
+
+  provisioner "local-exec" {
+    when    = destroy
+    command = "echo ${self.id}"
+  }
+}
@@ -1,5 +1,7 @@
 # test_run_one does a complete apply
 run "test_run_one" {
+  parallel = true
+
   variables {
     input = "test_run_one"
   }
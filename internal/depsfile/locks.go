@@ -37,6 +37,12 @@ type Locks struct {
 	// settings, environment variables, or whatever similar sources.
 	overriddenProviders map[addrs.Provider]struct{}
 
+	// backendPlugins are the locks for the backend plugins that the "plugin"
+	// backend installs. Backend plugins are distributed in the same way as
+	// providers, but they are tracked separately so that the provider
+	// installer doesn't consider them as unused providers.
+	backendPlugins map[addrs.Provider]*ProviderLock
+
 	// TODO: In future we'll also have module locks, but the design of that
 	// still needs some more work and we're deferring that to get the
 	// provider locking capability out sooner, because it's more common to
@@ -120,6 +126,39 @@ func (l *Locks) RemoveProvider(addr addrs.Provider) {
 	delete(l.providers, addr)
 }
 
+// BackendPlugin returns the stored lock for the given backend plugin, or nil
+// if that backend plugin currently has no lock.
+func (l *Locks) BackendPlugin(addr addrs.Provider) *ProviderLock {
+	return l.backendPlugins[addr]
+}
+
+// AllBackendPlugins returns a map describing all of the backend plugin locks
+// in the receiver.
+func (l *Locks) AllBackendPlugins() map[addrs.Provider]*ProviderLock {
+	ret := make(map[addrs.Provider]*ProviderLock, len(l.backendPlugins))
+	for k, v := range l.backendPlugins {
+		ret[k] = v
+	}
+	return ret
+}
+
+// SetBackendPlugin creates a new lock or replaces the existing lock for the
+// given backend plugin, with the same rules as SetProvider.
+func (l *Locks) SetBackendPlugin(addr addrs.Provider, version getproviders.Version, constraints getproviders.VersionConstraints, hashes []getproviders.Hash) *ProviderLock {
+	if l.backendPlugins == nil {
+		l.backendPlugins = make(map[addrs.Provider]*ProviderLock)
+	}
+	new := NewProviderLock(addr, version, constraints, hashes)
+	l.backendPlugins[new.addr] = new
+	return new
+}
+
+// RemoveBackendPlugin removes any existing lock file entry for the given
+// backend plugin.
+func (l *Locks) RemoveBackendPlugin(addr addrs.Provider) {
+	delete(l.backendPlugins, addr)
+}
+
 // SetProviderOverridden records that this particular OpenTofu process will
 // not pay attention to the recorded lock entry for the given provider, and
 // will instead access that provider's functionality in some other special
@@ -241,11 +280,16 @@ func (l *Locks) Sources() map[string][]byte {
 // explain what's changed between runs, and are never used as part of
 // dependency installation decisions.
 func (l *Locks) Equal(other *Locks) bool {
-	if len(l.providers) != len(other.providers) {
+	return providerLocksEqual(l.providers, other.providers) &&
+		providerLocksEqual(l.backendPlugins, other.backendPlugins)
+}
+
+func providerLocksEqual(this, other map[addrs.Provider]*ProviderLock) bool {
+	if len(this) != len(other) {
 		return false
 	}
-	for addr, thisLock := range l.providers {
-		otherLock, ok := other.providers[addr]
+	for addr, thisLock := range this {
+		otherLock, ok := other[addr]
 		if !ok {
 			return false
 		}
@@ -303,7 +347,7 @@ func (l *Locks) EqualProviderAddress(other *Locks) bool {
 // UI code might wish to use this to distinguish a lock file being
 // written for the first time from subsequent updates to that lock file.
 func (l *Locks) Empty() bool {
-	return len(l.providers) == 0
+	return len(l.providers) == 0 && len(l.backendPlugins) == 0
 }
 
 // DeepCopy creates a new Locks that represents the same information as the
@@ -317,12 +361,10 @@ func (l *Locks) Empty() bool {
 func (l *Locks) DeepCopy() *Locks {
 	ret := NewLocks()
 	for addr, lock := range l.providers {
-		var hashes []getproviders.Hash
-		if len(lock.hashes) > 0 {
-			hashes = make([]getproviders.Hash, len(lock.hashes))
-			copy(hashes, lock.hashes)
-		}
-		ret.SetProvider(addr, lock.version, lock.versionConstraints, hashes)
+		ret.SetProvider(addr, lock.version, lock.versionConstraints, lock.copyHashes())
+	}
+	for addr, lock := range l.backendPlugins {
+		ret.SetBackendPlugin(addr, lock.version, lock.versionConstraints, lock.copyHashes())
 	}
 	return ret
 }
@@ -372,6 +414,15 @@ type ProviderLock struct {
 	hashes []getproviders.Hash
 }
 
+func (l *ProviderLock) copyHashes() []getproviders.Hash {
+	if len(l.hashes) == 0 {
+		return nil
+	}
+	hashes := make([]getproviders.Hash, len(l.hashes))
+	copy(hashes, l.hashes)
+	return hashes
+}
+
 // Provider returns the address of the provider this lock applies to.
 func (l *ProviderLock) Provider() addrs.Provider {
 	return l.addr
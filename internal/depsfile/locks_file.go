@@ -140,8 +140,17 @@ func SaveLocksToBytes(locks *Locks) ([]byte, tfdiags.Diagnostics) {
 		},
 	})
 
-	providers := make([]addrs.Provider, 0, len(locks.providers))
-	for provider := range locks.providers {
+	appendProviderLockBlocks(rootBody, "provider", locks.providers)
+	appendProviderLockBlocks(rootBody, "backend_plugin", locks.backendPlugins)
+
+	return f.Bytes(), diags
+}
+
+// appendProviderLockBlocks appends a block of the given type for each of the
+// given locks, in a consistent order.
+func appendProviderLockBlocks(rootBody *hclwrite.Body, blockType string, locks map[addrs.Provider]*ProviderLock) {
+	providers := make([]addrs.Provider, 0, len(locks))
+	for provider := range locks {
 		providers = append(providers, provider)
 	}
 	sort.Slice(providers, func(i, j int) bool {
@@ -149,9 +158,9 @@ func SaveLocksToBytes(locks *Locks) ([]byte, tfdiags.Diagnostics) {
 	})
 
 	for _, provider := range providers {
-		lock := locks.providers[provider]
+		lock := locks[provider]
 		rootBody.AppendNewline()
-		block := rootBody.AppendNewBlock("provider", []string{lock.addr.String()})
+		block := rootBody.AppendNewBlock(blockType, []string{lock.addr.String()})
 		body := block.Body()
 		body.SetAttributeValue("version", cty.StringVal(lock.version.String()))
 		if constraintsStr := getproviders.VersionConstraintsString(lock.versionConstraints); constraintsStr != "" {
@@ -162,8 +171,6 @@ func SaveLocksToBytes(locks *Locks) ([]byte, tfdiags.Diagnostics) {
 			body.SetAttributeRaw("hashes", hashToks)
 		}
 	}
-
-	return f.Bytes(), diags
 }
 
 func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
@@ -175,6 +182,10 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 				Type:       "provider",
 				LabelNames: []string{"source_addr"},
 			},
+			{
+				Type:       "backend_plugin",
+				LabelNames: []string{"source_addr"},
+			},
 
 			// "module" is just a placeholder for future enhancement, so we
 			// can mostly-ignore the this block type we intend to add in
@@ -189,6 +200,7 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 	diags = diags.Append(hclDiags)
 
 	seenProviders := make(map[addrs.Provider]hcl.Range)
+	seenBackendPlugins := make(map[addrs.Provider]hcl.Range)
 	seenModule := false
 	for _, block := range content.Blocks {
 
@@ -211,6 +223,27 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 			locks.providers[lock.addr] = lock
 			seenProviders[lock.addr] = block.DefRange
 
+		case "backend_plugin":
+			lock, moreDiags := decodeProviderLockFromHCL(block)
+			diags = diags.Append(moreDiags)
+			if lock == nil {
+				continue
+			}
+			if previousRng, exists := seenBackendPlugins[lock.addr]; exists {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate backend plugin lock",
+					Detail:   fmt.Sprintf("This lockfile already declared a lock for backend plugin %s at %s.", lock.addr.String(), previousRng.String()),
+					Subject:  block.TypeRange.Ptr(),
+				})
+				continue
+			}
+			if locks.backendPlugins == nil {
+				locks.backendPlugins = make(map[addrs.Provider]*ProviderLock)
+			}
+			locks.backendPlugins[lock.addr] = lock
+			seenBackendPlugins[lock.addr] = block.DefRange
+
 		case "module":
 			// We'll just take the first module block to use for a single warning,
 			// because that's sufficient to get the point across without swamping
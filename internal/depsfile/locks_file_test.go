@@ -102,6 +102,21 @@ func TestLoadLocksFromFile(t *testing.T) {
 			// Please keep these in alphabetical order so the list is easy
 			// to scan!
 
+			case "backend-plugin-locks.hcl":
+				foo := addrs.MustParseProviderSourceString("test/foo")
+				if got, want := len(locks.providers), 1; got != want {
+					t.Errorf("wrong number of providers %d; want %d", got, want)
+				}
+				if got, want := len(locks.backendPlugins), 1; got != want {
+					t.Errorf("wrong number of backend plugins %d; want %d", got, want)
+				}
+				if lock := locks.Provider(foo); lock == nil || lock.Version().String() != "1.0.0" {
+					t.Errorf("wrong provider lock %#v", lock)
+				}
+				if lock := locks.BackendPlugin(foo); lock == nil || lock.Version().String() != "2.0.0" {
+					t.Errorf("wrong backend plugin lock %#v", lock)
+				}
+
 			case "empty.hcl":
 				if got, want := len(locks.providers), 0; got != want {
 					t.Errorf("wrong number of providers %d; want %d", got, want)
@@ -223,6 +238,7 @@ func TestSaveLocksToFile(t *testing.T) {
 	locks.SetProvider(barProvider, oneDotTwo, pessimisticOneDotOh, nil)
 	locks.SetProvider(bazProvider, oneDotTwo, nil, nil)
 	locks.SetProvider(booProvider, oneDotTwo, abbreviatedOneDotTwo, nil)
+	locks.SetBackendPlugin(fooProvider, oneDotTwo, pessimisticOneDotOh, nil)
 
 	dir := t.TempDir()
 
@@ -271,6 +287,11 @@ provider "registry.opentofu.org/test/foo" {
     "test:cccccccccccccccccccccccccccccccccccccccccccccccc",
   ]
 }
+
+backend_plugin "registry.opentofu.org/test/foo" {
+  version     = "1.2.0"
+  constraints = "~> 1.0"
+}
 `
 	if diff := cmp.Diff(wantContent, gotContent); diff != "" {
 		t.Errorf("wrong result\n%s", diff)
@@ -85,6 +85,22 @@ func TestLocksEqual(t *testing.T) {
 		b.SetProvider(boopProvider, v2, v2EqConstraints, hashesB)
 		nonEqualBothWays(t, a, b)
 	})
+	t.Run("an extra backend plugin lock", func(t *testing.T) {
+		a := NewLocks()
+		b := NewLocks()
+		a.SetProvider(boopProvider, v2, v2EqConstraints, nil)
+		b.SetProvider(boopProvider, v2, v2EqConstraints, nil)
+		b.SetBackendPlugin(boopProvider, v2, v2EqConstraints, nil)
+		nonEqualBothWays(t, a, b)
+	})
+	t.Run("both have boop backend plugin with different versions", func(t *testing.T) {
+		a := NewLocks()
+		b := NewLocks()
+		a.SetBackendPlugin(boopProvider, v2, v2EqConstraints, nil)
+		b.SetBackendPlugin(boopProvider, v2LocalBuild, v2EqConstraints, nil)
+		nonEqualBothWays(t, a, b)
+		equalBothWays(t, a.DeepCopy(), a)
+	})
 }
 
 func TestLocksEqualProviderAddress(t *testing.T) {
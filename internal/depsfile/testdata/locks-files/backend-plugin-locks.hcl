@@ -0,0 +1,21 @@
+
+provider "registry.opentofu.org/test/foo" {
+  version = "1.0.0"
+}
+
+backend_plugin "registry.opentofu.org/test/foo" {
+  version = "2.0.0"
+  constraints = "~> 2.0"
+
+  hashes = [
+    "test:placeholder-hash-1",
+  ]
+}
+
+backend_plugin "registry.opentofu.org/test/foo" { # ERROR: Duplicate backend plugin lock
+  version = "2.1.0"
+}
+
+backend_plugin "test/bar" { # ERROR: Non-normalized provider source address
+  version = "1.0.0"
+}
@@ -28,6 +28,7 @@ type baseEncryption struct {
 	enforced      bool
 	name          string
 	encMethods    []method.Method
+	encAddrs      []method.Addr
 	inputEncMeta  map[keyprovider.MetaStorageKey][]byte
 	outputEncMeta map[keyprovider.MetaStorageKey][]byte
 	staticEval    *configs.StaticEvaluator
@@ -71,8 +72,9 @@ func newBaseEncryption(enc *encryption, target *config.TargetConfig, enforced bo
 	//   This performs a e2e validation run of the config -> methods flow. It serves as a validation step and allows us to return detailed
 	//   diagnostics here and simple errors in the decrypt function below.
 	//
-	methods, diags := base.buildTargetMethods(base.inputEncMeta, base.outputEncMeta)
+	methods, addrs, diags := base.buildTargetMethods(base.inputEncMeta, base.outputEncMeta)
 	base.encMethods = methods
+	base.encAddrs = addrs
 
 	return base, diags
 }
@@ -121,8 +123,23 @@ func (base *baseEncryption) encrypt(data []byte, enhance func(basedata) interfac
 	return jsond, nil
 }
 
+// DecryptionStatus describes which of the configured methods was able to read a payload.
+type DecryptionStatus int
+
+const (
+	// DecryptedWithPrimary indicates that the payload was read with the primary method. Encrypting it again would
+	// not change how it is stored.
+	DecryptedWithPrimary DecryptionStatus = iota
+	// DecryptedWithFallback indicates that the payload could only be read with a fallback method, including the case
+	// where an unencrypted payload was read because of an unencrypted fallback. The payload should be encrypted again
+	// to move it to the primary method.
+	DecryptedWithFallback
+)
+
 // TODO Find a way to make these errors actionable / clear
-func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) ([]byte, error) {
+//
+// The returned index identifies the method in the target that was able to read the data.
+func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) ([]byte, int, error) {
 	inputData := basedata{}
 	err := json.Unmarshal(data, &inputData)
 
@@ -134,20 +151,20 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 
 			// Return the outer json error if we have one
 			if err != nil {
-				return nil, fmt.Errorf("invalid data format for decryption: %w, %w", err, verr)
+				return nil, 0, fmt.Errorf("invalid data format for decryption: %w, %w", err, verr)
 			}
 
 			// Must have been invalid json payload
-			return nil, fmt.Errorf("unable to determine data structure during decryption: %w", verr)
+			return nil, 0, fmt.Errorf("unable to determine data structure during decryption: %w", verr)
 		}
 
 		// Yep, it's already decrypted
-		for _, method := range base.encMethods {
+		for i, method := range base.encMethods {
 			if unencrypted.Is(method) {
-				return data, nil
+				return data, i, nil
 			}
 		}
-		return nil, fmt.Errorf("encountered unencrypted payload without unencrypted method configured")
+		return nil, 0, fmt.Errorf("encountered unencrypted payload without unencrypted method configured")
 	}
 	// This is not actually used, only the map inside the Meta parameter is. This is because we are passing the map
 	// around.
@@ -156,19 +173,19 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 	}
 
 	if inputData.Version != encryptionVersion {
-		return nil, fmt.Errorf("invalid encrypted payload version: %s != %s", inputData.Version, encryptionVersion)
+		return nil, 0, fmt.Errorf("invalid encrypted payload version: %s != %s", inputData.Version, encryptionVersion)
 	}
 
 	// TODO Discuss if we should potentially cache this based on a json-encoded version of inputData.Meta and reduce overhead dramatically
-	methods, diags := base.buildTargetMethods(inputData.Meta, outputData.Meta)
+	methods, _, diags := base.buildTargetMethods(inputData.Meta, outputData.Meta)
 	if diags.HasErrors() {
 		// This cast to error here is safe as we know that at least one error exists
 		// This is also quite unlikely to happen as the constructor already has checked this code path
-		return nil, diags
+		return nil, 0, diags
 	}
 
 	errs := make([]error, 0)
-	for _, method := range methods {
+	for i, method := range methods {
 		if unencrypted.Is(method) {
 			// Not applicable
 			continue
@@ -176,7 +193,7 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 		uncd, err := method.Decrypt(inputData.Data)
 		if err == nil {
 			// Success
-			return uncd, nil
+			return uncd, i, nil
 		}
 		// Record the failure
 		errs = append(errs, fmt.Errorf("attempted decryption failed for %s: %w", base.name, err))
@@ -189,5 +206,13 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 		errMessage += err.Error() + sep
 		sep = "\n"
 	}
-	return nil, fmt.Errorf(errMessage)
+	return nil, 0, fmt.Errorf(errMessage)
+}
+
+// decryptionStatus returns the status for a payload read with the method at the given index of the target methods.
+func decryptionStatus(methodIndex int) DecryptionStatus {
+	if methodIndex == 0 {
+		return DecryptedWithPrimary
+	}
+	return DecryptedWithFallback
 }
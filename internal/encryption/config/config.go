@@ -45,10 +45,22 @@ func (c *EncryptionConfig) GetKeyProvider(kpType, kpName string) (KeyProviderCon
 // encryption. The Body field will contain the remaining undeclared fields the key provider can consume.
 type KeyProviderConfig struct {
 	// EncryptedMetadataAlias contains the key to identify the metadata by.
-	EncryptedMetadataAlias string   `hcl:"encrypted_metadata_alias,optional"`
-	Type                   string   `hcl:"type,label"`
-	Name                   string   `hcl:"name,label"`
-	Body                   hcl.Body `hcl:",remain"`
+	EncryptedMetadataAlias string `hcl:"encrypted_metadata_alias,optional"`
+	// Cache enables caching the keys of this key provider in memory.
+	Cache *KeyProviderCacheConfig `hcl:"cache,block"`
+	Type  string                  `hcl:"type,label"`
+	Name  string                  `hcl:"name,label"`
+	Body  hcl.Body                `hcl:",remain"`
+}
+
+// KeyProviderCacheConfig describes the terraform.encryption.key_provider.*.cache block you can use to keep the keys of
+// a key provider in memory instead of calling it for every state or plan operation.
+type KeyProviderCacheConfig struct {
+	// TTL is the duration string after which a cached key expires. If empty, keys are cached for the lifetime of the
+	// process.
+	TTL string `hcl:"ttl,optional"`
+	// RotationThreshold is the number of times an encryption key is reused before a new one is requested.
+	RotationThreshold int `hcl:"rotation_threshold,optional"`
 }
 
 // Addr returns a keyprovider.Addr from the current configuration.
@@ -77,6 +89,9 @@ type RemoteConfig struct {
 
 // TargetConfig describes the target.encryption.state, target.encryption.plan, etc blocks.
 type TargetConfig struct {
+	// Mode is only supported for state files. In a fallback block it describes how the state files read by the
+	// fallback were written, and defaults to the mode of the block it belongs to.
+	Mode     string         `hcl:"mode,optional"`
 	Method   hcl.Expression `hcl:"method,optional"`
 	Fallback *TargetConfig  `hcl:"fallback,block"`
 }
@@ -86,13 +101,23 @@ type TargetConfig struct {
 // Note: This struct is copied because gohcl does not support embedding.
 type EnforceableTargetConfig struct {
 	Enforced bool           `hcl:"enforced,optional"`
+	Mode     string         `hcl:"mode,optional"`
 	Method   hcl.Expression `hcl:"method,optional"`
 	Fallback *TargetConfig  `hcl:"fallback,block"`
 }
 
+const (
+	// StateModeFull encrypts the entire state file. This is the default if no mode is specified.
+	StateModeFull = "full"
+	// StateModeSensitiveAttributes only encrypts the values of sensitive attributes and sensitive outputs, leaving the
+	// rest of the state file readable.
+	StateModeSensitiveAttributes = "sensitive_attributes"
+)
+
 // AsTargetConfig converts the struct into its parent TargetConfig.
 func (e EnforceableTargetConfig) AsTargetConfig() *TargetConfig {
 	return &TargetConfig{
+		Mode:     e.Mode,
 		Method:   e.Method,
 		Fallback: e.Fallback,
 	}
@@ -104,6 +129,7 @@ func (e EnforceableTargetConfig) AsTargetConfig() *TargetConfig {
 // Note: This struct is copied because gohcl does not support embedding.
 type NamedTargetConfig struct {
 	Name     string         `hcl:"name,label"`
+	Mode     string         `hcl:"mode,optional"`
 	Method   hcl.Expression `hcl:"method,optional"`
 	Fallback *TargetConfig  `hcl:"fallback,block"`
 }
@@ -111,6 +137,7 @@ type NamedTargetConfig struct {
 // AsTargetConfig converts the struct into its parent TargetConfig.
 func (n NamedTargetConfig) AsTargetConfig() *TargetConfig {
 	return &TargetConfig{
+		Mode:     n.Mode,
 		Method:   n.Method,
 		Fallback: n.Fallback,
 	}
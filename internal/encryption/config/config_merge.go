@@ -67,6 +67,9 @@ func mergeKeyProviderConfigs(configs []KeyProviderConfig, overrides []KeyProvide
 			if keyProvider.Type == override.Type && keyProvider.Name == override.Name {
 				// Override the existing key provider.
 				merged[i].Body = mergeBody(keyProvider.Body, override.Body)
+				if override.Cache != nil {
+					merged[i].Cache = override.Cache
+				}
 				wasOverridden = true
 				break
 			}
@@ -90,6 +93,12 @@ func mergeTargetConfigs(cfg *TargetConfig, override *TargetConfig) *TargetConfig
 
 	merged := &TargetConfig{}
 
+	if override.Mode != "" {
+		merged.Mode = override.Mode
+	} else {
+		merged.Mode = cfg.Mode
+	}
+
 	if override.Method != nil {
 		merged.Method = override.Method
 	} else {
@@ -116,6 +125,7 @@ func mergeEnforceableTargetConfigs(cfg *EnforceableTargetConfig, override *Enfor
 	mergeTarget := mergeTargetConfigs(cfg.AsTargetConfig(), override.AsTargetConfig())
 	return &EnforceableTargetConfig{
 		Enforced: cfg.Enforced || override.Enforced,
+		Mode:     mergeTarget.Mode,
 		Method:   mergeTarget.Method,
 		Fallback: mergeTarget.Fallback,
 	}
@@ -144,6 +154,7 @@ func mergeRemoteConfigs(cfg *RemoteConfig, override *RemoteConfig) *RemoteConfig
 				mergeTarget := mergeTargetConfigs(t.AsTargetConfig(), overrideTarget.AsTargetConfig())
 				merged.Targets[i] = NamedTargetConfig{
 					Name:     t.Name,
+					Mode:     mergeTarget.Mode,
 					Method:   mergeTarget.Method,
 					Fallback: mergeTarget.Fallback,
 				}
@@ -52,8 +52,30 @@ func DecodeConfig(body hcl.Body, rng hcl.Range) (*EncryptionConfig, hcl.Diagnost
 		}
 	}
 
+	if cfg.State != nil {
+		diags = append(diags, validateStateModes(cfg.State.AsTargetConfig(), rng)...)
+	}
+
+	if cfg.Plan != nil {
+		for target := cfg.Plan.AsTargetConfig(); target != nil; target = target.Fallback {
+			if target.Mode != "" {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Unsupported plan encryption mode",
+					Detail:   "Plan files are always encrypted in full, the mode attribute is only supported for state files",
+					Subject:  rng.Ptr(),
+				})
+				break
+			}
+		}
+	}
+
 	if cfg.Remote != nil {
+		if cfg.Remote.Default != nil {
+			diags = append(diags, validateStateModes(cfg.Remote.Default, rng)...)
+		}
 		for i, t := range cfg.Remote.Targets {
+			diags = append(diags, validateStateModes(t.AsTargetConfig(), rng)...)
 			for j, ot := range cfg.Remote.Targets {
 				if i != j && t.Name == ot.Name {
 					diags = append(diags, &hcl.Diagnostic{
@@ -74,3 +96,21 @@ func DecodeConfig(body hcl.Body, rng hcl.Range) (*EncryptionConfig, hcl.Diagnost
 
 	return cfg, diags
 }
+
+// validateStateModes checks the mode of a state target and all of its fallbacks.
+func validateStateModes(target *TargetConfig, rng hcl.Range) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for ; target != nil; target = target.Fallback {
+		switch target.Mode {
+		case "", StateModeFull, StateModeSensitiveAttributes:
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid state encryption mode",
+				Detail:   fmt.Sprintf("The state encryption mode must be %q or %q, got %q", StateModeFull, StateModeSensitiveAttributes, target.Mode),
+				Subject:  rng.Ptr(),
+			})
+		}
+	}
+	return diags
+}
@@ -0,0 +1,134 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/pbkdf2"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestDecryptStateWithStatus(t *testing.T) {
+	const keyProviders = `
+		key_provider "pbkdf2" "old" {
+			passphrase = "Hello world! 123"
+		}
+		key_provider "pbkdf2" "new" {
+			passphrase = "Goodbye world! 456"
+		}
+		method "aes_gcm" "old" {
+			keys = key_provider.pbkdf2.old
+		}
+		method "aes_gcm" "new" {
+			keys = key_provider.pbkdf2.new
+		}
+		method "unencrypted" "migrate" {}
+	`
+
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(unencrypted.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	stateEncryption := func(t *testing.T, state string) StateEncryption {
+		t.Helper()
+
+		cfg, diags := config.LoadConfigFromString("test", keyProviders+state)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		return enc.State()
+	}
+
+	oldEnc := stateEncryption(t, `state {
+		method = method.aes_gcm.old
+	}`)
+	rotatedEnc := stateEncryption(t, `state {
+		method = method.aes_gcm.new
+		fallback {
+			method = method.aes_gcm.old
+		}
+	}`)
+	migrateEnc := stateEncryption(t, `state {
+		method = method.aes_gcm.new
+		fallback {
+			method = method.unencrypted.migrate
+		}
+	}`)
+
+	testData := []byte(`{"terraform_version": "1.8.0", "serial": 42, "lineage": "magic"}`)
+	oldData, err := oldEnc.EncryptState(testData)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	newData, err := rotatedEnc.EncryptState(testData)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testCases := map[string]struct {
+		enc      StateEncryption
+		data     []byte
+		expected DecryptionStatus
+	}{
+		"primary": {
+			enc:      oldEnc,
+			data:     oldData,
+			expected: DecryptedWithPrimary,
+		},
+		"rotated-new": {
+			enc:      rotatedEnc,
+			data:     newData,
+			expected: DecryptedWithPrimary,
+		},
+		"rotated-old": {
+			enc:      rotatedEnc,
+			data:     oldData,
+			expected: DecryptedWithFallback,
+		},
+		"unencrypted-fallback": {
+			enc:      migrateEnc,
+			data:     testData,
+			expected: DecryptedWithFallback,
+		},
+		"disabled": {
+			enc:      StateEncryptionDisabled(),
+			data:     testData,
+			expected: DecryptedWithPrimary,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decrypted, status, err := tc.enc.DecryptStateWithStatus(tc.data)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if string(decrypted) != string(testData) {
+				t.Fatalf("unexpected decrypted state: %s", decrypted)
+			}
+			if status != tc.expected {
+				t.Fatalf("expected status %d, got %d", tc.expected, status)
+			}
+		})
+	}
+}
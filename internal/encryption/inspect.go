@@ -0,0 +1,126 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// PayloadInfo describes how a state or plan file is stored, as far as it can be determined without decrypting it.
+type PayloadInfo struct {
+	// Encrypted is false if the file is stored in plain text.
+	Encrypted bool
+	// Mode is config.StateModeFull or config.StateModeSensitiveAttributes for encrypted files.
+	Mode string
+	// Version is the version of the encrypted payload format.
+	Version string
+	// KeyProviderMeta contains the storage keys of the key provider metadata in the file, in lexical order.
+	KeyProviderMeta []keyprovider.MetaStorageKey
+}
+
+// InspectPayload reads the envelope of a state or plan file without decrypting it. It returns an error if the data is
+// neither an encrypted payload, nor a plain state or plan file.
+func InspectPayload(data []byte) (*PayloadInfo, error) {
+	if len(data) >= 2 && string(data[:2]) == "PK" {
+		// Plan files are zip archives
+		return &PayloadInfo{}, nil
+	}
+
+	var envelope struct {
+		basedata
+		Encryption *sensitiveStateHeader `json:"encryption"`
+		// Plain state files always have a version
+		StateVersion *json.Number `json:"version"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("the file is neither an encrypted payload, nor a state or plan file: %w", err)
+	}
+
+	var info *PayloadInfo
+	var meta map[keyprovider.MetaStorageKey][]byte
+	switch {
+	case envelope.Version != "":
+		info = &PayloadInfo{Encrypted: true, Mode: config.StateModeFull, Version: envelope.Version}
+		meta = envelope.Meta
+	case envelope.Encryption != nil:
+		info = &PayloadInfo{Encrypted: true, Mode: envelope.Encryption.Mode, Version: envelope.Encryption.Version}
+		meta = envelope.Encryption.Meta
+	case envelope.StateVersion != nil:
+		return &PayloadInfo{}, nil
+	default:
+		return nil, fmt.Errorf("the file is neither an encrypted payload, nor a state or plan file")
+	}
+
+	for key := range meta {
+		info.KeyProviderMeta = append(info.KeyProviderMeta, key)
+	}
+	sort.Slice(info.KeyProviderMeta, func(i, j int) bool {
+		return info.KeyProviderMeta[i] < info.KeyProviderMeta[j]
+	})
+	return info, nil
+}
+
+// DecryptionResult describes whether the current configuration is able to decrypt a state or plan file.
+type DecryptionResult struct {
+	// Method is the address of the method that read the file. It is empty if no encryption is configured or the
+	// decryption failed.
+	Method method.Addr
+	// Status tells if the file was read with the primary or a fallback method.
+	Status DecryptionStatus
+	// Err is the reason the file could not be decrypted, if any.
+	Err error
+}
+
+func (s *stateEncryption) TryDecryptState(encryptedState []byte) DecryptionResult {
+	_, methodIndex, status, err := s.decryptState(encryptedState)
+	if err != nil {
+		return DecryptionResult{Err: err}
+	}
+	return DecryptionResult{
+		Method: s.base.encAddrs[methodIndex],
+		Status: status,
+	}
+}
+
+func (s *stateDisabled) TryDecryptState(encryptedState []byte) DecryptionResult {
+	return tryDecryptDisabled(encryptedState)
+}
+
+func (p planEncryption) TryDecryptPlan(encryptedPlan []byte) DecryptionResult {
+	_, methodIndex, err := p.decryptPlan(encryptedPlan)
+	if err != nil {
+		return DecryptionResult{Err: err}
+	}
+	return DecryptionResult{
+		Method: p.base.encAddrs[methodIndex],
+		Status: decryptionStatus(methodIndex),
+	}
+}
+
+func (p *planDisabled) TryDecryptPlan(encryptedPlan []byte) DecryptionResult {
+	return tryDecryptDisabled(encryptedPlan)
+}
+
+// tryDecryptDisabled reports whether a file can be read without any encryption configuration.
+func tryDecryptDisabled(data []byte) DecryptionResult {
+	info, err := InspectPayload(data)
+	if err != nil {
+		return DecryptionResult{Err: err}
+	}
+	if info.Encrypted {
+		return DecryptionResult{Err: fmt.Errorf("the file is encrypted, but no encryption is configured")}
+	}
+	return DecryptionResult{Status: DecryptedWithPrimary}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/pbkdf2"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestInspectPayload(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	stateEncryption := func(t *testing.T, passphrase string, mode string) StateEncryption {
+		t.Helper()
+
+		cfg, diags := config.LoadConfigFromString("test", `
+			key_provider "pbkdf2" "basic" {
+				passphrase = "`+passphrase+`"
+			}
+			method "aes_gcm" "example" {
+				keys = key_provider.pbkdf2.basic
+			}
+			state {
+				mode   = "`+mode+`"
+				method = method.aes_gcm.example
+			}
+		`)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		return enc.State()
+	}
+
+	fullEnc := stateEncryption(t, "Hello world! 123", config.StateModeFull)
+	fullEncrypted, err := fullEnc.EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	sensitiveEncrypted, err := stateEncryption(t, "Hello world! 123", config.StateModeSensitiveAttributes).EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testCases := map[string]struct {
+		data     []byte
+		expected *PayloadInfo
+	}{
+		"plain-state": {
+			data:     []byte(sensitiveTestState),
+			expected: &PayloadInfo{},
+		},
+		"plain-plan": {
+			data:     []byte("PK\x03\x04"),
+			expected: &PayloadInfo{},
+		},
+		"full": {
+			data: fullEncrypted,
+			expected: &PayloadInfo{
+				Encrypted:       true,
+				Mode:            config.StateModeFull,
+				Version:         "v0",
+				KeyProviderMeta: []keyprovider.MetaStorageKey{"key_provider.pbkdf2.basic"},
+			},
+		},
+		"sensitive-attributes": {
+			data: sensitiveEncrypted,
+			expected: &PayloadInfo{
+				Encrypted:       true,
+				Mode:            config.StateModeSensitiveAttributes,
+				Version:         "v0",
+				KeyProviderMeta: []keyprovider.MetaStorageKey{"key_provider.pbkdf2.basic"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			info, err := InspectPayload(tc.data)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if !reflect.DeepEqual(info, tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, info)
+			}
+		})
+	}
+
+	if _, err := InspectPayload([]byte(`{"foo": "bar"}`)); err == nil {
+		t.Fatalf("expected an error for an unknown payload")
+	}
+
+	// The method is only known after decrypting the file
+	result := fullEnc.TryDecryptState(fullEncrypted)
+	if result.Err != nil || result.Method != "method.aes_gcm.example" || result.Status != DecryptedWithPrimary {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+	result = stateEncryption(t, "wrong passphrase!", config.StateModeFull).TryDecryptState(fullEncrypted)
+	if result.Err == nil || result.Method != "" {
+		t.Fatalf("expected the decryption to fail, got %#v", result)
+	}
+	result = StateEncryptionDisabled().TryDecryptState(fullEncrypted)
+	if result.Err == nil {
+		t.Fatalf("expected the decryption to fail without encryption configuration")
+	}
+	result = StateEncryptionDisabled().TryDecryptState([]byte(sensitiveTestState))
+	if result.Err != nil || result.Method != "" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/cache"
 	"github.com/opentofu/opentofu/internal/encryption/registry"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -222,6 +224,13 @@ func (e *targetBuilder) setupKeyProvider(cfg config.KeyProviderConfig, stack []c
 		})
 	}
 
+	if cfg.Cache != nil {
+		keyProvider, diags = e.setupKeyProviderCache(cfg, tmpMetaKey, keyProviderConfig, keyProvider, diags)
+		if diags.HasErrors() {
+			return diags
+		}
+	}
+
 	// Add the metadata
 	if meta, ok := e.inputKeyProviderMetadata[metaKey]; ok {
 		err := json.Unmarshal(meta, keyMetaIn)
@@ -267,3 +276,31 @@ func (e *targetBuilder) setupKeyProvider(cfg config.KeyProviderConfig, stack []c
 	return nil
 
 }
+
+// setupKeyProviderCache wraps the key provider in a cache as configured in the cache block.
+func (e *targetBuilder) setupKeyProviderCache(cfg config.KeyProviderConfig, addr keyprovider.Addr, keyProviderConfig keyprovider.Config, keyProvider keyprovider.KeyProvider, diags hcl.Diagnostics) (keyprovider.KeyProvider, hcl.Diagnostics) {
+	opts := cache.Options{
+		RotationThreshold: cfg.Cache.RotationThreshold,
+	}
+	if cfg.Cache.TTL != "" {
+		ttl, err := time.ParseDuration(cfg.Cache.TTL)
+		if err != nil {
+			return nil, append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid key provider cache ttl",
+				Detail:   fmt.Sprintf("Unable to parse the cache ttl of %s: %s", addr, err.Error()),
+			})
+		}
+		opts.TTL = ttl
+	}
+
+	cached, err := cache.New(string(addr), keyProviderConfig, keyProvider, opts)
+	if err != nil {
+		return nil, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid key provider cache configuration",
+			Detail:   fmt.Sprintf("Unable to set up the cache for %s: %s", addr, err.Error()),
+		})
+	}
+	return cached, diags
+}
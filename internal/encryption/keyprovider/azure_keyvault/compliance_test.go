@@ -0,0 +1,191 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azure_keyvault
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/compliancetest"
+)
+
+// getKey returns the vault URI and key name to run the tests against a real Key Vault, or empty strings if the mock
+// should be used.
+func getKey(t *testing.T) (string, string) {
+	if os.Getenv("TF_ACC") == "" && os.Getenv("TF_KMS_TEST") == "" {
+		return "", ""
+	}
+	return os.Getenv("TF_AZURE_KEYVAULT_URI"), os.Getenv("TF_AZURE_KEYVAULT_KEY_NAME")
+}
+
+func TestKeyProvider(t *testing.T) {
+	vaultURI, keyName := getKey(t)
+
+	if vaultURI == "" {
+		keyName = "my-mock-key"
+		vaultURI = newMockKeyVault(t, keyName).URI()
+		injectMockAuthorizer(t)
+	}
+
+	compliancetest.ComplianceTest(
+		t,
+		compliancetest.TestConfiguration[*descriptor, *Config, *keyMeta, *keyProvider]{
+			Descriptor: New().(*descriptor),
+			HCLParseTestCases: map[string]compliancetest.HCLParseTestCase[*Config, *keyProvider]{
+				"success": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = "%s"
+						}`, vaultURI, keyName),
+					ValidHCL:   true,
+					ValidBuild: true,
+					Validate: func(config *Config, keyProvider *keyProvider) error {
+						if config.KeyName != keyName {
+							return fmt.Errorf("incorrect key name returned")
+						}
+						if keyProvider.keyLength != defaultKeyLength {
+							return fmt.Errorf("incorrect default key length: %d", keyProvider.keyLength)
+						}
+						if keyProvider.algorithm != defaultAlgorithm {
+							return fmt.Errorf("incorrect default algorithm: %s", keyProvider.algorithm)
+						}
+						return nil
+					},
+				},
+				"success-full": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = "%s"
+							key_length = 16
+							algorithm = "RSA-OAEP"
+							tenant_id = "00000000-0000-0000-0000-000000000000"
+							client_id = "00000000-0000-0000-0000-000000000000"
+							client_secret = "secret"
+							use_msi = false
+							use_oidc = false
+						}`, vaultURI, keyName),
+					ValidHCL:   true,
+					ValidBuild: true,
+					Validate: func(config *Config, keyProvider *keyProvider) error {
+						if keyProvider.keyLength != 16 {
+							return fmt.Errorf("incorrect key length: %d", keyProvider.keyLength)
+						}
+						if keyProvider.algorithm != "RSA-OAEP" {
+							return fmt.Errorf("incorrect algorithm: %s", keyProvider.algorithm)
+						}
+						return nil
+					},
+				},
+				"empty": {
+					HCL:        `key_provider "azure_keyvault" "foo" {}`,
+					ValidHCL:   false,
+					ValidBuild: false,
+				},
+				"empty-vault-uri": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = ""
+							key_name = "%s"
+						}`, keyName),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"invalid-vault-uri": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "example.vault.azure.net"
+							key_name = "%s"
+						}`, keyName),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"empty-key-name": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = ""
+						}`, vaultURI),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"invalid-key-length": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = "%s"
+							key_length = 17
+						}`, vaultURI, keyName),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"invalid-algorithm": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = "%s"
+							algorithm = "RSA1_5"
+						}`, vaultURI, keyName),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"unknown-property": {
+					HCL: fmt.Sprintf(`key_provider "azure_keyvault" "foo" {
+							vault_uri = "%s"
+							key_name = "%s"
+							unknown_property = "foo"
+						}`, vaultURI, keyName),
+					ValidHCL:   false,
+					ValidBuild: false,
+				},
+			},
+			ConfigStructTestCases: map[string]compliancetest.ConfigStructTestCase[*Config, *keyProvider]{
+				"success": {
+					Config: &Config{
+						VaultURI: vaultURI,
+						KeyName:  keyName,
+					},
+					ValidBuild: true,
+					Validate:   nil,
+				},
+				"empty": {
+					Config:     &Config{},
+					ValidBuild: false,
+					Validate:   nil,
+				},
+			},
+			MetadataStructTestCases: map[string]compliancetest.MetadataStructTestCase[*Config, *keyMeta]{
+				"empty": {
+					ValidConfig: &Config{
+						VaultURI: vaultURI,
+						KeyName:  keyName,
+					},
+					Meta:      &keyMeta{},
+					IsPresent: false,
+					IsValid:   false,
+				},
+			},
+			ProvideTestCase: compliancetest.ProvideTestCase[*Config, *keyMeta]{
+				ValidConfig: &Config{
+					VaultURI: vaultURI,
+					KeyName:  keyName,
+				},
+				ValidateKeys: func(dec []byte, enc []byte) error {
+					if len(dec) == 0 {
+						return fmt.Errorf("decryption key is empty")
+					}
+					if len(enc) == 0 {
+						return fmt.Errorf("encryption key is empty")
+					}
+					return nil
+				},
+				ValidateMetadata: func(meta *keyMeta) error {
+					if len(meta.WrappedKey) == 0 {
+						return fmt.Errorf("wrapped key is empty")
+					}
+					if meta.KeyID == "" {
+						return fmt.Errorf("key ID is empty")
+					}
+					return nil
+				},
+			},
+		})
+}
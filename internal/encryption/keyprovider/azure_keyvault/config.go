@@ -0,0 +1,254 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azure_keyvault
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/opentofu/opentofu/internal/collections"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/version"
+)
+
+// validKeyLengths holds the data key lengths this key provider can generate.
+var validKeyLengths = collections.NewSet[int](16, 24, 32)
+
+// validAlgorithms holds the Key Vault key wrapping algorithms supported by this key provider. RSA1_5 is deliberately
+// not supported because it is vulnerable to padding oracle attacks.
+var validAlgorithms = collections.NewSet[keyvault.JSONWebKeyEncryptionAlgorithm](
+	keyvault.RSAOAEP,
+	keyvault.RSAOAEP256,
+)
+
+const (
+	defaultKeyLength = 32
+	defaultAlgorithm = keyvault.RSAOAEP256
+)
+
+// authorizerInit obtains an authorizer for the Key Vault data plane. The passed config always has its defaults applied.
+type authorizerInit func(ctx context.Context, c Config) (autorest.Authorizer, error)
+
+// Can be overridden for test mocking
+var newAuthorizer authorizerInit = func(ctx context.Context, c Config) (autorest.Authorizer, error) {
+	env, err := authentication.AzureEnvironmentByNameFromEndpoint(ctx, c.MetadataHost, c.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// This mirrors the authentication of the azurerm remote state backend, except for the access key and SAS token,
+	// which only apply to storage accounts.
+	builder := authentication.Builder{
+		ClientID:             c.ClientID,
+		SubscriptionID:       c.SubscriptionID,
+		TenantID:             c.TenantID,
+		MetadataHost:         c.MetadataHost,
+		Environment:          c.Environment,
+		ClientSecretDocsLink: "https://registry.opentofu.org/providers/hashicorp/azurerm/latest/docs/guides/service_principal_client_secret",
+
+		// Service Principal (Client Certificate)
+		ClientCertPassword: c.ClientCertificatePassword,
+		ClientCertPath:     c.ClientCertificatePath,
+
+		// Service Principal (Client Secret)
+		ClientSecret: c.ClientSecret,
+
+		// Managed Service Identity
+		MsiEndpoint: c.MSIEndpoint,
+
+		// OIDC
+		IDToken:             c.OIDCToken,
+		IDTokenFilePath:     c.OIDCTokenFilePath,
+		IDTokenRequestURL:   c.OIDCRequestURL,
+		IDTokenRequestToken: c.OIDCRequestToken,
+
+		// Feature Toggles
+		SupportsAzureCliToken:          true,
+		SupportsClientCertAuth:         true,
+		SupportsClientSecretAuth:       true,
+		SupportsManagedServiceIdentity: *c.UseMSI,
+		SupportsOIDCAuth:               *c.UseOIDC,
+		UseMicrosoftGraph:              true,
+	}
+	armConfig, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("error building ARM config: %w", err)
+	}
+
+	oauthConfig, err := armConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	hamiltonEnv, err := environments.EnvironmentFromString(c.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return armConfig.GetMSALToken(
+		ctx,
+		hamiltonEnv.KeyVault,
+		sender.BuildSender("encryption/keyprovider/azure_keyvault"),
+		oauthConfig,
+		env.ResourceIdentifiers.KeyVault,
+	)
+}
+
+type Config struct {
+	VaultURI   string `hcl:"vault_uri"`
+	KeyName    string `hcl:"key_name"`
+	KeyVersion string `hcl:"key_version,optional"`
+	Algorithm  string `hcl:"algorithm,optional"`
+	KeyLength  int    `hcl:"key_length,optional"`
+
+	Environment  string `hcl:"environment,optional"`
+	MetadataHost string `hcl:"metadata_host,optional"`
+
+	SubscriptionID            string `hcl:"subscription_id,optional"`
+	TenantID                  string `hcl:"tenant_id,optional"`
+	ClientID                  string `hcl:"client_id,optional"`
+	ClientSecret              string `hcl:"client_secret,optional"`
+	ClientCertificatePath     string `hcl:"client_certificate_path,optional"`
+	ClientCertificatePassword string `hcl:"client_certificate_password,optional"`
+
+	UseMSI      *bool  `hcl:"use_msi,optional"`
+	MSIEndpoint string `hcl:"msi_endpoint,optional"`
+
+	UseOIDC           *bool  `hcl:"use_oidc,optional"`
+	OIDCToken         string `hcl:"oidc_token,optional"`
+	OIDCTokenFilePath string `hcl:"oidc_token_file_path,optional"`
+	OIDCRequestURL    string `hcl:"oidc_request_url,optional"`
+	OIDCRequestToken  string `hcl:"oidc_request_token,optional"`
+}
+
+func stringAttrEnvFallback(val string, env ...string) string {
+	if val != "" {
+		return val
+	}
+	for _, e := range env {
+		if v := os.Getenv(e); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func boolAttrEnvFallback(val *bool, env string) (*bool, error) {
+	if val != nil {
+		return val, nil
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		return new(bool), nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", env, err)
+	}
+	return &b, nil
+}
+
+// withDefaults returns a copy of the config with the environment variable and default values of the azurerm backend
+// applied.
+func (c Config) withDefaults() (Config, error) {
+	c.Environment = stringAttrEnvFallback(c.Environment, "ARM_ENVIRONMENT")
+	if c.Environment == "" {
+		c.Environment = "public"
+	}
+	c.MetadataHost = stringAttrEnvFallback(c.MetadataHost, "ARM_METADATA_HOST")
+	c.SubscriptionID = stringAttrEnvFallback(c.SubscriptionID, "ARM_SUBSCRIPTION_ID")
+	c.TenantID = stringAttrEnvFallback(c.TenantID, "ARM_TENANT_ID")
+	c.ClientID = stringAttrEnvFallback(c.ClientID, "ARM_CLIENT_ID")
+	c.ClientSecret = stringAttrEnvFallback(c.ClientSecret, "ARM_CLIENT_SECRET")
+	c.ClientCertificatePath = stringAttrEnvFallback(c.ClientCertificatePath, "ARM_CLIENT_CERTIFICATE_PATH")
+	c.ClientCertificatePassword = stringAttrEnvFallback(c.ClientCertificatePassword, "ARM_CLIENT_CERTIFICATE_PASSWORD")
+	c.MSIEndpoint = stringAttrEnvFallback(c.MSIEndpoint, "ARM_MSI_ENDPOINT")
+	c.OIDCToken = stringAttrEnvFallback(c.OIDCToken, "ARM_OIDC_TOKEN")
+	c.OIDCTokenFilePath = stringAttrEnvFallback(c.OIDCTokenFilePath, "ARM_OIDC_TOKEN_FILE_PATH")
+	c.OIDCRequestURL = stringAttrEnvFallback(c.OIDCRequestURL, "ARM_OIDC_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_URL")
+	c.OIDCRequestToken = stringAttrEnvFallback(c.OIDCRequestToken, "ARM_OIDC_REQUEST_TOKEN", "ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+
+	var err error
+	if c.UseMSI, err = boolAttrEnvFallback(c.UseMSI, "ARM_USE_MSI"); err != nil {
+		return c, err
+	}
+	if c.UseOIDC, err = boolAttrEnvFallback(c.UseOIDC, "ARM_USE_OIDC"); err != nil {
+		return c, err
+	}
+
+	if c.Algorithm == "" {
+		c.Algorithm = string(defaultAlgorithm)
+	}
+	if c.KeyLength == 0 {
+		c.KeyLength = defaultKeyLength
+	}
+	return c, nil
+}
+
+func (c Config) Build() (keyprovider.KeyProvider, keyprovider.KeyMeta, error) {
+	c, err := c.withDefaults()
+	if err != nil {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{Cause: err}
+	}
+
+	if c.VaultURI == "" {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{Message: "vault_uri must be provided"}
+	}
+	vaultURI, err := url.Parse(c.VaultURI)
+	if err != nil || (vaultURI.Scheme != "https" && vaultURI.Scheme != "http") || vaultURI.Host == "" {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: fmt.Sprintf("vault_uri must be a URL, such as https://example.vault.azure.net/, got %q", c.VaultURI),
+		}
+	}
+
+	if c.KeyName == "" {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{Message: "key_name must be provided"}
+	}
+
+	algorithm := keyvault.JSONWebKeyEncryptionAlgorithm(c.Algorithm)
+	if !validAlgorithms.Has(algorithm) {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: fmt.Sprintf("algorithm must be one of %s, got %q", validAlgorithms.String(), c.Algorithm),
+		}
+	}
+
+	if !validKeyLengths.Has(c.KeyLength) {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: fmt.Sprintf("key_length must be one of %s, got %d", validKeyLengths.String(), c.KeyLength),
+		}
+	}
+
+	ctx := context.Background()
+
+	auth, err := newAuthorizer(ctx, c)
+	if err != nil {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{Message: "failed to authenticate to Azure", Cause: err}
+	}
+
+	client := keyvault.New()
+	client.Authorizer = auth
+	client.Sender = sender.BuildSender("encryption/keyprovider/azure_keyvault")
+	client.UserAgent = httpclient.OpenTofuUserAgent(version.Version)
+
+	return &keyProvider{
+		svc:        client,
+		ctx:        ctx,
+		vaultURI:   c.VaultURI,
+		keyName:    c.KeyName,
+		keyVersion: c.KeyVersion,
+		algorithm:  algorithm,
+		keyLength:  c.KeyLength,
+	}, new(keyMeta), nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azure_keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// mockKeyVault is a minimal implementation of the Key Vault wrapkey and unwrapkey REST endpoints. The "wrapped" key
+// is the key version and the plaintext key concatenated, so unwrapping with the wrong version fails.
+type mockKeyVault struct {
+	server  *httptest.Server
+	keyName string
+
+	lock           sync.Mutex
+	currentVersion string
+	requests       []string
+}
+
+func newMockKeyVault(t *testing.T, keyName string) *mockKeyVault {
+	m := &mockKeyVault{
+		keyName:        keyName,
+		currentVersion: "v1",
+	}
+	m.server = httptest.NewServer(http.HandlerFunc(m.handle))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockKeyVault) URI() string {
+	return m.server.URL + "/"
+}
+
+func (m *mockKeyVault) rotate(version string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.currentVersion = version
+}
+
+func (m *mockKeyVault) handle(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requests = append(m.requests, r.Method+" "+r.URL.Path)
+
+	// Path format: /keys/{key-name}/{key-version}/{operation}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodPost || len(parts) != 4 || parts[0] != "keys" || parts[1] != m.keyName {
+		writeMockError(w, http.StatusNotFound, "KeyNotFound")
+		return
+	}
+	version := parts[2]
+	if version == "" {
+		version = m.currentVersion
+	}
+
+	var params struct {
+		Algorithm string `json:"alg"`
+		Value     string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil || params.Algorithm == "" {
+		writeMockError(w, http.StatusBadRequest, "BadParameter")
+		return
+	}
+	value, err := base64.RawURLEncoding.DecodeString(params.Value)
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "BadParameter")
+		return
+	}
+
+	var result []byte
+	switch parts[3] {
+	case "wrapkey":
+		result = append([]byte(version), value...)
+	case "unwrapkey":
+		if !strings.HasPrefix(string(value), version) {
+			writeMockError(w, http.StatusBadRequest, "BadParameter")
+			return
+		}
+		result = value[len(version):]
+	default:
+		writeMockError(w, http.StatusNotFound, "NotFound")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"kid":   m.server.URL + "/keys/" + m.keyName + "/" + version,
+		"value": base64.RawURLEncoding.EncodeToString(result),
+	})
+}
+
+func writeMockError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"error":{"code":"` + code + `","message":"mock error"}}`))
+}
+
+func injectMockAuthorizer(t *testing.T) {
+	original := newAuthorizer
+	newAuthorizer = func(ctx context.Context, c Config) (autorest.Authorizer, error) {
+		return autorest.NullAuthorizer{}, nil
+	}
+	t.Cleanup(func() {
+		newAuthorizer = original
+	})
+}
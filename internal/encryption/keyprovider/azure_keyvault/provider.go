@@ -0,0 +1,163 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azure_keyvault
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+type keyMeta struct {
+	// KeyID is the full identifier of the Key Vault key, including the version, that was used to wrap the data key.
+	KeyID      string `json:"key_id"`
+	Algorithm  string `json:"algorithm"`
+	WrappedKey []byte `json:"wrapped_key"`
+}
+
+func (m keyMeta) isPresent() bool {
+	return len(m.WrappedKey) != 0
+}
+
+type keyVaultClient interface {
+	WrapKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string, parameters keyvault.KeyOperationsParameters) (keyvault.KeyOperationResult, error)
+	UnwrapKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string, parameters keyvault.KeyOperationsParameters) (keyvault.KeyOperationResult, error)
+}
+
+type keyProvider struct {
+	svc        keyVaultClient
+	ctx        context.Context
+	vaultURI   string
+	keyName    string
+	keyVersion string
+	algorithm  keyvault.JSONWebKeyEncryptionAlgorithm
+	keyLength  int
+}
+
+func (p keyProvider) Provide(rawMeta keyprovider.KeyMeta) (keyprovider.Output, keyprovider.KeyMeta, error) {
+	if rawMeta == nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{Message: "bug: no metadata struct provided"}
+	}
+	inMeta, ok := rawMeta.(*keyMeta)
+	if !ok {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{Message: "bug: invalid metadata struct type"}
+	}
+
+	outMeta := &keyMeta{}
+	out := keyprovider.Output{}
+
+	// Generate new key
+	out.EncryptionKey = make([]byte, p.keyLength)
+	_, err := rand.Read(out.EncryptionKey)
+	if err != nil {
+		return out, outMeta, &keyprovider.ErrKeyProviderFailure{
+			Message: "failed to generate key",
+			Cause:   err,
+		}
+	}
+
+	// Wrap the new encryption key using the Key Vault key
+	wrapped, err := p.svc.WrapKey(p.ctx, p.vaultURI, p.keyName, p.keyVersion, keyvault.KeyOperationsParameters{
+		Algorithm: p.algorithm,
+		Value:     encodeValue(out.EncryptionKey),
+	})
+	if err != nil {
+		return out, outMeta, &keyprovider.ErrKeyProviderFailure{
+			Message: "failed to wrap key",
+			Cause:   err,
+		}
+	}
+	outMeta.WrappedKey, err = decodeValue(wrapped.Result)
+	if err != nil {
+		return out, outMeta, &keyprovider.ErrKeyProviderFailure{
+			Message: "invalid wrapped key returned by Key Vault",
+			Cause:   err,
+		}
+	}
+	if wrapped.Kid != nil {
+		outMeta.KeyID = *wrapped.Kid
+	}
+	outMeta.Algorithm = string(p.algorithm)
+
+	// We do not set the DecryptionKey here as we should only be setting the decryption key if we are decrypting
+	// and that is handled below when we check if the inMeta has a WrappedKey
+
+	if inMeta.isPresent() {
+		// The data key must be unwrapped with the same key version and algorithm it was wrapped with, which may
+		// differ from the current configuration if the key was rotated since.
+		// The metadata is stored alongside the encrypted data, so it must not be able to select a key outside the
+		// configured one, or an algorithm this key provider deliberately does not support.
+		keyVersion := p.keyVersion
+		if inMeta.KeyID != "" {
+			keyVersion, err = p.keyVersionFromID(inMeta.KeyID)
+			if err != nil {
+				return out, outMeta, err
+			}
+		}
+		algorithm := p.algorithm
+		if inMeta.Algorithm != "" {
+			algorithm = keyvault.JSONWebKeyEncryptionAlgorithm(inMeta.Algorithm)
+			if !validAlgorithms.Has(algorithm) {
+				return out, outMeta, &keyprovider.ErrInvalidMetadata{
+					Message: fmt.Sprintf("unsupported key wrapping algorithm %q, must be one of %s", inMeta.Algorithm, validAlgorithms.String()),
+				}
+			}
+		}
+
+		unwrapped, unwrapErr := p.svc.UnwrapKey(p.ctx, p.vaultURI, p.keyName, keyVersion, keyvault.KeyOperationsParameters{
+			Algorithm: algorithm,
+			Value:     encodeValue(inMeta.WrappedKey),
+		})
+		if unwrapErr != nil {
+			return out, outMeta, &keyprovider.ErrKeyProviderFailure{
+				Message: "failed to unwrap key",
+				Cause:   unwrapErr,
+			}
+		}
+
+		// Set decryption key on the output
+		out.DecryptionKey, err = decodeValue(unwrapped.Result)
+		if err != nil {
+			return out, outMeta, &keyprovider.ErrKeyProviderFailure{
+				Message: "invalid unwrapped key returned by Key Vault",
+				Cause:   err,
+			}
+		}
+	}
+
+	return out, outMeta, nil
+}
+
+// keyVersionFromID returns the version of a key identifier stored in the metadata, checking that it identifies a
+// version of the configured key in the configured vault.
+func (p keyProvider) keyVersionFromID(keyID string) (string, error) {
+	prefix := strings.TrimSuffix(p.vaultURI, "/") + "/keys/" + p.keyName + "/"
+	version, ok := strings.CutPrefix(keyID, prefix)
+	if !ok || version == "" || strings.Contains(version, "/") {
+		return "", &keyprovider.ErrInvalidMetadata{
+			Message: fmt.Sprintf("key ID %q is not a version of the configured key %s", keyID, prefix),
+		}
+	}
+	return version, nil
+}
+
+// encodeValue encodes a value as unpadded base64url, which is the encoding the Key Vault API uses for binary data.
+func encodeValue(value []byte) *string {
+	encoded := base64.RawURLEncoding.EncodeToString(value)
+	return &encoded
+}
+
+func decodeValue(value *string) ([]byte, error) {
+	if value == nil {
+		return nil, fmt.Errorf("no value returned")
+	}
+	return base64.RawURLEncoding.DecodeString(*value)
+}
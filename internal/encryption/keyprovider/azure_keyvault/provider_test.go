@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azure_keyvault
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+func TestKeyProvider_keyRotation(t *testing.T) {
+	mock := newMockKeyVault(t, "my-mock-key")
+	injectMockAuthorizer(t)
+
+	providerConfig := Config{
+		VaultURI: mock.URI(),
+		KeyName:  "my-mock-key",
+	}
+
+	provider, metaIn, err := providerConfig.Build()
+	if err != nil {
+		t.Fatalf("Error building provider: %s", err)
+	}
+
+	output, meta, err := provider.Provide(metaIn)
+	if err != nil {
+		t.Fatalf("Error providing keys: %s", err)
+	}
+	if len(output.DecryptionKey) != 0 {
+		t.Fatalf("Decryption key provided and should not be")
+	}
+	encryptionKey := output.EncryptionKey
+
+	// After the key is rotated in the vault, the data key must still be unwrapped with the key version it was
+	// wrapped with.
+	mock.rotate("v2")
+
+	output, meta, err = provider.Provide(meta)
+	if err != nil {
+		t.Fatalf("Error providing keys: %s", err)
+	}
+	if !bytes.Equal(output.DecryptionKey, encryptionKey) {
+		t.Fatalf("Incorrect decryption key provided: %x (expected %x)", output.DecryptionKey, encryptionKey)
+	}
+	if kid := meta.(*keyMeta).KeyID; kid != mock.URI()+"keys/my-mock-key/v2" {
+		t.Fatalf("Incorrect key ID in the new metadata: %s", kid)
+	}
+
+	expectedRequests := []string{
+		"POST /keys/my-mock-key//wrapkey",
+		"POST /keys/my-mock-key//wrapkey",
+		"POST /keys/my-mock-key/v1/unwrapkey",
+	}
+	if len(mock.requests) != len(expectedRequests) {
+		t.Fatalf("Incorrect requests sent: %v", mock.requests)
+	}
+	for i, req := range expectedRequests {
+		if mock.requests[i] != req {
+			t.Fatalf("Incorrect request %d: %s (expected %s)", i, mock.requests[i], req)
+		}
+	}
+}
+
+func TestKeyProvider_invalidMetadata(t *testing.T) {
+	mock := newMockKeyVault(t, "my-mock-key")
+	injectMockAuthorizer(t)
+
+	providerConfig := Config{
+		VaultURI: mock.URI(),
+		KeyName:  "my-mock-key",
+	}
+
+	provider, metaIn, err := providerConfig.Build()
+	if err != nil {
+		t.Fatalf("Error building provider: %s", err)
+	}
+	_, meta, err := provider.Provide(metaIn)
+	if err != nil {
+		t.Fatalf("Error providing keys: %s", err)
+	}
+	valid := *meta.(*keyMeta)
+
+	tests := map[string]func(m *keyMeta){
+		"unsupported algorithm": func(m *keyMeta) {
+			m.Algorithm = "RSA1_5"
+		},
+		"unknown algorithm": func(m *keyMeta) {
+			m.Algorithm = "bogus"
+		},
+		"other vault": func(m *keyMeta) {
+			m.KeyID = "https://other.vault.azure.net/keys/my-mock-key/v1"
+		},
+		"other key": func(m *keyMeta) {
+			m.KeyID = mock.URI() + "keys/other-key/v1"
+		},
+		"no version": func(m *keyMeta) {
+			m.KeyID = mock.URI() + "keys/my-mock-key/"
+		},
+		"nested path": func(m *keyMeta) {
+			m.KeyID = mock.URI() + "keys/my-mock-key/v1/../../other-key/v1"
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := valid
+			modify(&m)
+
+			requests := len(mock.requests)
+			_, _, err := provider.Provide(&m)
+			var metaErr *keyprovider.ErrInvalidMetadata
+			if !errors.As(err, &metaErr) {
+				t.Fatalf("Expected invalid metadata error, got: %v", err)
+			}
+			for _, req := range mock.requests[requests:] {
+				if strings.HasSuffix(req, "/unwrapkey") {
+					t.Fatalf("Key Vault was asked to unwrap the key: %s", req)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cache provides a wrapper for key providers that keeps the provided keys in memory, so that reading and
+// writing the same state or plan file repeatedly doesn't call the key-management system every time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// DefaultRotationThreshold is the number of times an encryption key is reused before the wrapped key provider is
+// asked for a new one, if no threshold is configured.
+const DefaultRotationThreshold = 100
+
+// Options describes how long keys are kept in the cache.
+type Options struct {
+	// TTL is the duration after which a cached key is no longer used. If it is zero, keys are cached for the
+	// lifetime of the process.
+	TTL time.Duration
+	// RotationThreshold is the number of times the same encryption key is provided before a new one is requested
+	// from the wrapped key provider. If it is zero, DefaultRotationThreshold is used.
+	RotationThreshold int
+}
+
+// Validate checks if the options are valid.
+func (o Options) Validate() error {
+	if o.TTL < 0 {
+		return &keyprovider.ErrInvalidConfiguration{Message: fmt.Sprintf("the cache ttl must not be negative, got %s", o.TTL)}
+	}
+	if o.RotationThreshold < 0 {
+		return &keyprovider.ErrInvalidConfiguration{Message: fmt.Sprintf("the cache rotation_threshold must not be negative, got %d", o.RotationThreshold)}
+	}
+	return nil
+}
+
+// New wraps the key provider built from the given configuration in a cache. Key providers are built again every time
+// a state or plan file is read or written, so the cached keys are shared between all key providers built from an
+// identical configuration. The name is only used in log messages.
+func New(name string, config keyprovider.Config, inner keyprovider.KeyProvider, opts Options) (keyprovider.KeyProvider, error) {
+	return newCachedKeyProvider(defaultStore, name, config, inner, opts)
+}
+
+func newCachedKeyProvider(store *store, name string, config keyprovider.Config, inner keyprovider.KeyProvider, opts Options) (keyprovider.KeyProvider, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.RotationThreshold == 0 {
+		opts.RotationThreshold = DefaultRotationThreshold
+	}
+
+	// The configuration may contain secrets, so we only keep its hash.
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, &keyprovider.ErrInvalidConfiguration{
+			Message: "the key provider configuration can not be cached",
+			Cause:   err,
+		}
+	}
+	hash := sha256.Sum256(append([]byte(fmt.Sprintf("%T\x00", config)), configJSON...))
+
+	return &cachedKeyProvider{
+		store: store,
+		name:  name,
+		id:    hex.EncodeToString(hash[:]),
+		inner: inner,
+		opts:  opts,
+	}, nil
+}
+
+type cachedKeyProvider struct {
+	store *store
+	name  string
+	id    string
+	inner keyprovider.KeyProvider
+	opts  Options
+}
+
+func (c *cachedKeyProvider) Provide(decryptionMeta keyprovider.KeyMeta) (keyprovider.Output, keyprovider.KeyMeta, error) {
+	decryptionMetaJSON, err := json.Marshal(decryptionMeta)
+	if err != nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{
+			Message: "failed to encode the decryption metadata for caching",
+			Cause:   err,
+		}
+	}
+
+	if output, encryptionMeta, ok := c.store.lookup(c.id, string(decryptionMetaJSON), c.opts); ok {
+		c.logMetrics("cache hit")
+		return output, encryptionMeta, nil
+	}
+
+	output, encryptionMeta, err := c.inner.Provide(decryptionMeta)
+	if err != nil {
+		return output, encryptionMeta, err
+	}
+
+	encryptionMetaJSON, err := json.Marshal(encryptionMeta)
+	if err != nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{
+			Message: "failed to encode the encryption metadata for caching",
+			Cause:   err,
+		}
+	}
+	c.store.save(c.id, string(decryptionMetaJSON), string(encryptionMetaJSON), output, encryptionMeta)
+	c.logMetrics("cache miss")
+	return output, encryptionMeta, nil
+}
+
+func (c *cachedKeyProvider) logMetrics(event string) {
+	m := c.store.metricsFor(c.id)
+	log.Printf(
+		"[DEBUG] encryption: key provider %s: %s (hits: %d, misses: %d, encryption key rotations: %d)",
+		c.name, event, m.Hits, m.Misses, m.Rotations,
+	)
+}
+
+// Metrics counts how the cache of a key provider configuration was used.
+type Metrics struct {
+	// Hits is the number of times the keys were provided from the cache.
+	Hits int
+	// Misses is the number of times the wrapped key provider was called.
+	Misses int
+	// Rotations is the number of times a cached encryption key was discarded because it reached the rotation
+	// threshold or the TTL.
+	Rotations int
+}
+
+type decryptionEntry struct {
+	key     []byte
+	created time.Time
+}
+
+type encryptionEntry struct {
+	key     []byte
+	meta    keyprovider.KeyMeta
+	uses    int
+	created time.Time
+}
+
+// store holds the cached keys, indexed by the hash of the key provider configuration.
+type store struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	// decryption maps the configuration hash and the JSON-encoded metadata to the decryption key.
+	decryption map[string]map[string]decryptionEntry
+	encryption map[string]*encryptionEntry
+	metrics    map[string]*Metrics
+}
+
+var defaultStore = newStore()
+
+func newStore() *store {
+	return &store{
+		now:        time.Now,
+		decryption: make(map[string]map[string]decryptionEntry),
+		encryption: make(map[string]*encryptionEntry),
+		metrics:    make(map[string]*Metrics),
+	}
+}
+
+func (s *store) expired(created time.Time, opts Options) bool {
+	return opts.TTL > 0 && s.now().Sub(created) >= opts.TTL
+}
+
+// lookup returns the cached keys if both the decryption key for the given metadata and a usable encryption key are
+// present.
+func (s *store) lookup(id string, decryptionMeta string, opts Options) (keyprovider.Output, keyprovider.KeyMeta, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.metricsLocked(id)
+
+	dec, ok := s.decryption[id][decryptionMeta]
+	if ok && s.expired(dec.created, opts) {
+		delete(s.decryption[id], decryptionMeta)
+		ok = false
+	}
+	enc := s.encryption[id]
+	if enc != nil && (enc.uses >= opts.RotationThreshold || s.expired(enc.created, opts)) {
+		delete(s.encryption, id)
+		enc = nil
+		m.Rotations++
+	}
+	if !ok || enc == nil {
+		m.Misses++
+		return keyprovider.Output{}, nil, false
+	}
+
+	enc.uses++
+	m.Hits++
+	return keyprovider.Output{
+		EncryptionKey: enc.key,
+		DecryptionKey: dec.key,
+	}, enc.meta, true
+}
+
+// save caches the keys returned by the wrapped key provider. The encryption key is also cached as the decryption key
+// for its own metadata, so that data encrypted by this process can be decrypted without calling the key provider.
+func (s *store) save(id string, decryptionMeta string, encryptionMeta string, output keyprovider.Output, meta keyprovider.KeyMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.decryption[id] == nil {
+		s.decryption[id] = make(map[string]decryptionEntry)
+	}
+	s.decryption[id][decryptionMeta] = decryptionEntry{key: output.DecryptionKey, created: now}
+	s.decryption[id][encryptionMeta] = decryptionEntry{key: output.EncryptionKey, created: now}
+
+	s.encryption[id] = &encryptionEntry{
+		key:     output.EncryptionKey,
+		meta:    meta,
+		uses:    1,
+		created: now,
+	}
+}
+
+func (s *store) metricsFor(id string) Metrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.metricsLocked(id)
+}
+
+func (s *store) metricsLocked(id string) *Metrics {
+	m, ok := s.metrics[id]
+	if !ok {
+		m = &Metrics{}
+		s.metrics[id] = m
+	}
+	return m
+}
@@ -0,0 +1,215 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+type testConfig struct {
+	Secret string `hcl:"secret"`
+}
+
+func (c testConfig) Build() (keyprovider.KeyProvider, keyprovider.KeyMeta, error) {
+	return nil, nil, nil
+}
+
+type testMeta struct {
+	Generation int `json:"generation"`
+}
+
+// testKeyProvider generates a new key for every call, identified by a generation number stored in the metadata, and
+// counts how often it was called.
+type testKeyProvider struct {
+	secret string
+	calls  int
+}
+
+func (t *testKeyProvider) key(generation int) []byte {
+	return []byte(fmt.Sprintf("%s-%d", t.secret, generation))
+}
+
+func (t *testKeyProvider) Provide(rawMeta keyprovider.KeyMeta) (keyprovider.Output, keyprovider.KeyMeta, error) {
+	t.calls++
+	meta := rawMeta.(*testMeta)
+	var decryptionKey []byte
+	if meta.Generation != 0 {
+		decryptionKey = t.key(meta.Generation)
+	}
+	return keyprovider.Output{
+		EncryptionKey: t.key(t.calls),
+		DecryptionKey: decryptionKey,
+	}, &testMeta{Generation: t.calls}, nil
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestStore() (*store, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := newStore()
+	s.now = clock.Now
+	return s, clock
+}
+
+func provide(t *testing.T, kp keyprovider.KeyProvider, meta *testMeta) (keyprovider.Output, *testMeta) {
+	t.Helper()
+
+	// Simulate reading the metadata from a stored file
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &testMeta{}
+	if err := json.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	output, outMeta, err := kp.Provide(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return output, outMeta.(*testMeta)
+}
+
+func TestCache(t *testing.T) {
+	s, _ := newTestStore()
+	inner := &testKeyProvider{secret: "a"}
+	kp, err := newCachedKeyProvider(s, "key_provider.test.a", testConfig{Secret: "a"}, inner, Options{RotationThreshold: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first call without metadata needs the key provider
+	output, encMeta := provide(t, kp, &testMeta{})
+	if inner.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", inner.calls)
+	}
+
+	// Decrypting what was just encrypted, and encrypting again, is served from the cache
+	for i := 0; i < 2; i++ {
+		next, nextMeta := provide(t, kp, encMeta)
+		if !bytes.Equal(next.DecryptionKey, output.EncryptionKey) {
+			t.Fatalf("expected decryption key %s, got %s", output.EncryptionKey, next.DecryptionKey)
+		}
+		if !bytes.Equal(next.EncryptionKey, output.EncryptionKey) || nextMeta.Generation != encMeta.Generation {
+			t.Fatalf("expected the encryption key to be reused")
+		}
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", inner.calls)
+	}
+
+	// After three uses the encryption key is rotated
+	next, nextMeta := provide(t, kp, encMeta)
+	if inner.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", inner.calls)
+	}
+	if bytes.Equal(next.EncryptionKey, output.EncryptionKey) || nextMeta.Generation == encMeta.Generation {
+		t.Fatalf("expected the encryption key to be rotated")
+	}
+	if !bytes.Equal(next.DecryptionKey, output.EncryptionKey) {
+		t.Fatalf("expected decryption key %s, got %s", output.EncryptionKey, next.DecryptionKey)
+	}
+
+	// The old key is still cached for decryption
+	old, _ := provide(t, kp, encMeta)
+	if inner.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", inner.calls)
+	}
+	if !bytes.Equal(old.DecryptionKey, output.EncryptionKey) {
+		t.Fatalf("expected decryption key %s, got %s", output.EncryptionKey, old.DecryptionKey)
+	}
+
+	m := s.metricsFor(kp.(*cachedKeyProvider).id)
+	expected := Metrics{Hits: 3, Misses: 2, Rotations: 1}
+	if m != expected {
+		t.Fatalf("expected metrics %+v, got %+v", expected, m)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	s, clock := newTestStore()
+	inner := &testKeyProvider{secret: "a"}
+	kp, err := newCachedKeyProvider(s, "key_provider.test.a", testConfig{Secret: "a"}, inner, Options{TTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, encMeta := provide(t, kp, &testMeta{})
+	clock.now = clock.now.Add(30 * time.Second)
+	provide(t, kp, encMeta)
+	if inner.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", inner.calls)
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	provide(t, kp, encMeta)
+	if inner.calls != 2 {
+		t.Fatalf("expected the keys to expire, got %d calls", inner.calls)
+	}
+}
+
+func TestCacheSeparateConfigs(t *testing.T) {
+	s, _ := newTestStore()
+	innerA := &testKeyProvider{secret: "a"}
+	kpA, err := newCachedKeyProvider(s, "key_provider.test.a", testConfig{Secret: "a"}, innerA, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerB := &testKeyProvider{secret: "b"}
+	kpB, err := newCachedKeyProvider(s, "key_provider.test.a", testConfig{Secret: "b"}, innerB, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputA, _ := provide(t, kpA, &testMeta{})
+	outputB, _ := provide(t, kpB, &testMeta{})
+	if innerA.calls != 1 || innerB.calls != 1 {
+		t.Fatalf("expected each key provider to be called once, got %d and %d", innerA.calls, innerB.calls)
+	}
+	if bytes.Equal(outputA.EncryptionKey, outputB.EncryptionKey) {
+		t.Fatalf("different configurations must not share keys")
+	}
+
+	// A key provider built again from the same configuration shares the cache
+	innerA2 := &testKeyProvider{secret: "a"}
+	kpA2, err := newCachedKeyProvider(s, "key_provider.test.a", testConfig{Secret: "a"}, innerA2, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputA2, _ := provide(t, kpA2, &testMeta{})
+	if innerA2.calls != 0 {
+		t.Fatalf("expected the cache to be shared, got %d calls", innerA2.calls)
+	}
+	if !bytes.Equal(outputA.EncryptionKey, outputA2.EncryptionKey) {
+		t.Fatalf("expected the cached encryption key to be reused")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"negative-ttl":       {TTL: -time.Second},
+		"negative-threshold": {RotationThreshold: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := opts.Validate(); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/compliancetest"
+)
+
+func TestKeyProvider(t *testing.T) {
+	testProvider := buildTestProvider(t)
+
+	compliancetest.ComplianceTest(
+		t,
+		compliancetest.TestConfiguration[*descriptor, *Config, *keyMeta, *keyProvider]{
+			Descriptor: New().(*descriptor),
+			HCLParseTestCases: map[string]compliancetest.HCLParseTestCase[*Config, *keyProvider]{
+				"success": {
+					HCL: fmt.Sprintf(`key_provider "external" "foo" {
+							command = [%q]
+						}`, testProvider),
+					ValidHCL:   true,
+					ValidBuild: true,
+					Validate: func(config *Config, keyProvider *keyProvider) error {
+						if keyProvider.timeout != DefaultTimeout {
+							return fmt.Errorf("incorrect default timeout: %s", keyProvider.timeout)
+						}
+						return nil
+					},
+				},
+				"success-arguments-timeout": {
+					HCL: fmt.Sprintf(`key_provider "external" "foo" {
+							command = [%q, "--some-flag"]
+							timeout = "5s"
+						}`, testProvider),
+					ValidHCL:   true,
+					ValidBuild: true,
+					Validate: func(config *Config, keyProvider *keyProvider) error {
+						if len(keyProvider.args) != 1 || keyProvider.args[0] != "--some-flag" {
+							return fmt.Errorf("incorrect arguments: %v", keyProvider.args)
+						}
+						if keyProvider.timeout.Seconds() != 5 {
+							return fmt.Errorf("incorrect timeout: %s", keyProvider.timeout)
+						}
+						return nil
+					},
+				},
+				"empty": {
+					HCL:        `key_provider "external" "foo" {}`,
+					ValidHCL:   false,
+					ValidBuild: false,
+				},
+				"empty-command": {
+					HCL: `key_provider "external" "foo" {
+							command = []
+						}`,
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"missing-program": {
+					HCL: `key_provider "external" "foo" {
+							command = ["this-program-does-not-exist-anywhere"]
+						}`,
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"invalid-timeout": {
+					HCL: fmt.Sprintf(`key_provider "external" "foo" {
+							command = [%q]
+							timeout = "soon"
+						}`, testProvider),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"negative-timeout": {
+					HCL: fmt.Sprintf(`key_provider "external" "foo" {
+							command = [%q]
+							timeout = "-1s"
+						}`, testProvider),
+					ValidHCL:   true,
+					ValidBuild: false,
+				},
+				"unknown-property": {
+					HCL: fmt.Sprintf(`key_provider "external" "foo" {
+							command = [%q]
+							unknown_property = "foo"
+						}`, testProvider),
+					ValidHCL:   false,
+					ValidBuild: false,
+				},
+			},
+			ConfigStructTestCases: map[string]compliancetest.ConfigStructTestCase[*Config, *keyProvider]{
+				"success": {
+					Config: &Config{
+						Command: []string{testProvider},
+					},
+					ValidBuild: true,
+					Validate:   nil,
+				},
+				"empty": {
+					Config:     &Config{},
+					ValidBuild: false,
+					Validate:   nil,
+				},
+			},
+			MetadataStructTestCases: map[string]compliancetest.MetadataStructTestCase[*Config, *keyMeta]{
+				"empty": {
+					ValidConfig: &Config{
+						Command: []string{testProvider},
+					},
+					Meta:      &keyMeta{},
+					IsPresent: false,
+					IsValid:   false,
+				},
+				"null": {
+					ValidConfig: &Config{
+						Command: []string{testProvider},
+					},
+					Meta:      &keyMeta{ExternalData: json.RawMessage("null")},
+					IsPresent: false,
+					IsValid:   false,
+				},
+				"invalid-json": {
+					ValidConfig: &Config{
+						Command: []string{testProvider},
+					},
+					Meta:      &keyMeta{ExternalData: json.RawMessage("{")},
+					IsPresent: true,
+					IsValid:   false,
+				},
+			},
+			ProvideTestCase: compliancetest.ProvideTestCase[*Config, *keyMeta]{
+				ValidConfig: &Config{
+					Command: []string{testProvider},
+				},
+				ValidateKeys: func(dec []byte, enc []byte) error {
+					if len(dec) == 0 {
+						return fmt.Errorf("decryption key is empty")
+					}
+					if len(enc) == 0 {
+						return fmt.Errorf("encryption key is empty")
+					}
+					return nil
+				},
+				ValidateMetadata: func(meta *keyMeta) error {
+					if !meta.isPresent() {
+						return fmt.Errorf("external data is empty")
+					}
+					return nil
+				},
+			},
+		})
+}
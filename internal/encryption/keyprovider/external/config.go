@@ -0,0 +1,62 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// DefaultTimeout is the time the external command has to provide the keys unless configured otherwise.
+const DefaultTimeout = 30 * time.Second
+
+type Config struct {
+	// Command is the program to run, followed by its arguments.
+	Command []string `hcl:"command"`
+	// Timeout is the maximum duration the command may run for, such as "10s" or "1m".
+	Timeout string `hcl:"timeout,optional"`
+}
+
+func (c Config) Build() (keyprovider.KeyProvider, keyprovider.KeyMeta, error) {
+	if len(c.Command) == 0 || c.Command[0] == "" {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: "the command must contain at least the program to run",
+		}
+	}
+
+	program, err := exec.LookPath(c.Command[0])
+	if err != nil {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: fmt.Sprintf("cannot find the external key provider program %q", c.Command[0]),
+			Cause:   err,
+		}
+	}
+
+	timeout := DefaultTimeout
+	if c.Timeout != "" {
+		timeout, err = time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, nil, &keyprovider.ErrInvalidConfiguration{
+				Message: fmt.Sprintf("invalid timeout %q, please specify a duration such as \"30s\"", c.Timeout),
+				Cause:   err,
+			}
+		}
+		if timeout <= 0 {
+			return nil, nil, &keyprovider.ErrInvalidConfiguration{
+				Message: fmt.Sprintf("the timeout must be positive, got %q", c.Timeout),
+			}
+		}
+	}
+
+	return &keyProvider{
+		program: program,
+		args:    c.Command[1:],
+		timeout: timeout,
+	}, new(keyMeta), nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+var (
+	testProviderOnce sync.Once
+	testProviderPath string
+	testProviderErr  error
+	testProviderOut  []byte
+)
+
+// buildTestProvider compiles the fixture program in testdata/testprovider and returns the path to the binary.
+func buildTestProvider(t *testing.T) string {
+	t.Helper()
+
+	testProviderOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "tofu-external-keyprovider-")
+		if err != nil {
+			testProviderErr = err
+			return
+		}
+		name := "testprovider"
+		if runtime.GOOS == "windows" {
+			name += ".exe"
+		}
+		testProviderPath = filepath.Join(dir, name)
+
+		cmd := exec.Command("go", "build", "-o", testProviderPath, "./testdata/testprovider")
+		testProviderOut, testProviderErr = cmd.CombinedOutput()
+	})
+	if testProviderErr != nil {
+		t.Fatalf("Failed to build the test provider: %v\n%s", testProviderErr, testProviderOut)
+	}
+	return testProviderPath
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testProviderPath != "" {
+		_ = os.RemoveAll(filepath.Dir(testProviderPath))
+	}
+	os.Exit(code)
+}
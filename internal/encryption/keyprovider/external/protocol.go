@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// ProtocolVersion is the version of the protocol between OpenTofu and the external command. It must be increased
+// whenever a backwards-incompatible change is made to the Input or Output structures.
+//
+// The protocol works as follows:
+//
+//  1. OpenTofu runs the command and writes an Input document as JSON to its standard input.
+//  2. The command writes an Output document as JSON to its standard output and exits with the exit code 0.
+//  3. If the command fails, it should exit with a non-zero exit code and write a human-readable error message to its
+//     standard error.
+//
+// If Input.Meta is null, the command must only return an encryption key. Otherwise, it must also return the decryption
+// key that belongs to the metadata.
+const ProtocolVersion = 1
+
+// Input is the document OpenTofu writes to the standard input of the external command.
+type Input struct {
+	// ProtocolVersion is the version of the protocol OpenTofu speaks.
+	ProtocolVersion int `json:"protocol_version"`
+	// Meta is the metadata the command returned when the data that is being decrypted was encrypted, or null if there
+	// is no data to decrypt.
+	Meta json.RawMessage `json:"meta"`
+}
+
+// Output is the document the external command must write to its standard output.
+type Output struct {
+	// ProtocolVersion is the version of the protocol the command speaks. It must match the version OpenTofu speaks.
+	ProtocolVersion int `json:"protocol_version"`
+	// Keys contains the encryption key and, if metadata was passed in the input, the decryption key. The keys are
+	// base64-encoded.
+	Keys keyprovider.Output `json:"keys"`
+	// Meta is an arbitrary JSON value that OpenTofu stores alongside the encrypted data. It is passed back to the
+	// command when the data is decrypted, so it must contain everything the command needs to provide the same key
+	// again, such as a key identifier or a wrapped key.
+	Meta json.RawMessage `json:"meta"`
+}
+
+// keyMeta stores the metadata returned by the external command.
+type keyMeta struct {
+	ExternalData json.RawMessage `json:"external_data,omitempty"`
+}
+
+func (m keyMeta) isPresent() bool {
+	trimmed := bytes.TrimSpace(m.ExternalData)
+	return len(trimmed) != 0 && !bytes.Equal(trimmed, []byte("null"))
+}
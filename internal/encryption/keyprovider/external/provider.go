@@ -0,0 +1,140 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// maxStderrLength limits how much of the standard error of a failed command is included in the error message.
+const maxStderrLength = 4096
+
+type keyProvider struct {
+	program string
+	args    []string
+	timeout time.Duration
+}
+
+func (p keyProvider) Provide(rawMeta keyprovider.KeyMeta) (keyprovider.Output, keyprovider.KeyMeta, error) {
+	if rawMeta == nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{Message: "bug: no metadata struct provided"}
+	}
+	inMeta, ok := rawMeta.(*keyMeta)
+	if !ok {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{Message: "bug: invalid metadata struct type"}
+	}
+
+	input := Input{
+		ProtocolVersion: ProtocolVersion,
+		Meta:            json.RawMessage("null"),
+	}
+	if inMeta.isPresent() {
+		if !json.Valid(inMeta.ExternalData) {
+			return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{Message: "the stored metadata is not valid JSON"}
+		}
+		input.Meta = inMeta.ExternalData
+	}
+
+	stdout, err := p.run(input)
+	if err != nil {
+		return keyprovider.Output{}, nil, err
+	}
+
+	var output Output
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf("the external key provider %s returned an invalid output", p.program),
+			Cause:   err,
+		}
+	}
+	if err := p.validateOutput(output, inMeta.isPresent()); err != nil {
+		return keyprovider.Output{}, nil, err
+	}
+
+	outMeta := &keyMeta{ExternalData: output.Meta}
+	return output.Keys, outMeta, nil
+}
+
+// run executes the external command with the given input and returns its standard output.
+func (p keyProvider) run(input Input) ([]byte, error) {
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, &keyprovider.ErrKeyProviderFailure{Message: "failed to encode the input for the external key provider", Cause: err}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.program, p.args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait for child processes that inherited the output pipes after the command itself was killed.
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf("the external key provider %s did not finish within %s", p.program, p.timeout),
+		}
+	}
+	if err != nil {
+		message := fmt.Sprintf("the external key provider %s failed", p.program)
+		if details := formatStderr(stderr.String()); details != "" {
+			message += ": " + details
+		}
+		return nil, &keyprovider.ErrKeyProviderFailure{Message: message, Cause: err}
+	}
+	return stdout.Bytes(), nil
+}
+
+func (p keyProvider) validateOutput(output Output, decrypting bool) error {
+	if output.ProtocolVersion != ProtocolVersion {
+		return &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf(
+				"the external key provider %s uses protocol version %d, but OpenTofu only supports version %d",
+				p.program,
+				output.ProtocolVersion,
+				ProtocolVersion,
+			),
+		}
+	}
+	if len(output.Keys.EncryptionKey) == 0 {
+		return &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf("the external key provider %s did not return an encryption key", p.program),
+		}
+	}
+	if decrypting && len(output.Keys.DecryptionKey) == 0 {
+		return &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf("the external key provider %s did not return a decryption key even though metadata was passed", p.program),
+		}
+	}
+	if !decrypting && len(output.Keys.DecryptionKey) != 0 {
+		return &keyprovider.ErrKeyProviderFailure{
+			Message: fmt.Sprintf("the external key provider %s returned a decryption key even though no metadata was passed", p.program),
+		}
+	}
+	return nil
+}
+
+// formatStderr trims the standard error output of the command for inclusion in an error message.
+func formatStderr(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) > maxStderrLength {
+		stderr = stderr[:maxStderrLength] + "... (truncated)"
+	}
+	return stderr
+}
@@ -0,0 +1,76 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+func TestKeyProvider_failures(t *testing.T) {
+	testProvider := buildTestProvider(t)
+
+	testCases := map[string]struct {
+		mode        string
+		timeout     string
+		withMeta    bool
+		expectedErr string
+	}{
+		"fail": {
+			mode:        "fail",
+			expectedErr: "failed: the HSM is not reachable",
+		},
+		"timeout": {
+			mode:        "sleep",
+			timeout:     "100ms",
+			expectedErr: "did not finish within 100ms",
+		},
+		"invalid-json": {
+			mode:        "invalid-json",
+			expectedErr: "returned an invalid output",
+		},
+		"wrong-version": {
+			mode:        "wrong-version",
+			expectedErr: "uses protocol version 2, but OpenTofu only supports version 1",
+		},
+		"no-decryption-key": {
+			mode:        "no-decryption-key",
+			withMeta:    true,
+			expectedErr: "did not return a decryption key",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{
+				Command: []string{testProvider, tc.mode},
+				Timeout: tc.timeout,
+			}
+			provider, meta, err := config.Build()
+			if err != nil {
+				t.Fatalf("Error building provider: %s", err)
+			}
+			if tc.withMeta {
+				meta = &keyMeta{ExternalData: []byte(`{"wrapped":"AAAA"}`)}
+			}
+
+			_, _, err = provider.Provide(meta)
+			if err == nil {
+				t.Fatalf("Expected an error, got none")
+			}
+			var typedErr *keyprovider.ErrKeyProviderFailure
+			if !errors.As(err, &typedErr) {
+				t.Fatalf("Incorrect error type %T: %v", err, err)
+			}
+			if !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Fatalf("Expected error containing %q, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// testprovider is a minimal implementation of the external key provider protocol for tests. It generates a random key
+// and stores it in the metadata, XOR-ed with a fixed pad. This is, of course, not secure.
+//
+// The first argument can be used to make it misbehave: "fail", "sleep", "invalid-json", "wrong-version" or
+// "no-decryption-key".
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+type input struct {
+	ProtocolVersion int             `json:"protocol_version"`
+	Meta            json.RawMessage `json:"meta"`
+}
+
+type keys struct {
+	EncryptionKey []byte `json:"encryption_key"`
+	DecryptionKey []byte `json:"decryption_key,omitempty"`
+}
+
+type meta struct {
+	Wrapped []byte `json:"wrapped"`
+}
+
+type output struct {
+	ProtocolVersion int  `json:"protocol_version"`
+	Keys            keys `json:"keys"`
+	Meta            meta `json:"meta"`
+}
+
+func xor(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		result[i] = b ^ 0x5a
+	}
+	return result
+}
+
+func main() {
+	mode := ""
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	switch mode {
+	case "fail":
+		_, _ = fmt.Fprintln(os.Stderr, "the HSM is not reachable")
+		os.Exit(1)
+	case "sleep":
+		time.Sleep(time.Minute)
+	case "invalid-json":
+		fmt.Print("this is not JSON")
+		return
+	}
+
+	var in input
+	if err := json.NewDecoder(os.Stdin).Decode(&in); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid input: %v\n", err)
+		os.Exit(1)
+	}
+	if in.ProtocolVersion != 1 {
+		_, _ = fmt.Fprintf(os.Stderr, "unsupported protocol version: %d\n", in.ProtocolVersion)
+		os.Exit(1)
+	}
+
+	out := output{
+		ProtocolVersion: 1,
+	}
+	if mode == "wrong-version" {
+		out.ProtocolVersion = 2
+	}
+
+	out.Keys.EncryptionKey = make([]byte, 32)
+	if _, err := rand.Read(out.Keys.EncryptionKey); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to generate key: %v\n", err)
+		os.Exit(1)
+	}
+	out.Meta.Wrapped = xor(out.Keys.EncryptionKey)
+
+	if string(in.Meta) != "null" && mode != "no-decryption-key" {
+		var m meta
+		if err := json.Unmarshal(in.Meta, &m); err != nil || len(m.Wrapped) == 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid metadata: %s\n", in.Meta)
+			os.Exit(1)
+		}
+		out.Keys.DecryptionKey = xor(m.Wrapped)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to write output: %v\n", err)
+		os.Exit(1)
+	}
+}
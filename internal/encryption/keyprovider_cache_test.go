@@ -0,0 +1,86 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/pbkdf2"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestKeyProviderCache(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	newState := func(t *testing.T, cache string) (StateEncryption, error) {
+		t.Helper()
+
+		cfg, diags := config.LoadConfigFromString("test", `
+			key_provider "pbkdf2" "basic" {
+				passphrase = "Hello world! 123"
+				`+cache+`
+			}
+			method "aes_gcm" "example" {
+				keys = key_provider.pbkdf2.basic
+			}
+			state {
+				method = method.aes_gcm.example
+			}
+		`)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		return enc.State(), nil
+	}
+
+	cached, err := newState(t, `cache {
+		ttl                = "1h"
+		rotation_threshold = 10
+	}`)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	uncached, err := newState(t, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testData := []byte(`{"terraform_version": "1.9.0", "serial": 1, "lineage": "magic"}`)
+	encrypted, err := cached.EncryptState(testData)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	// The data must be readable with or without the cache
+	for name, enc := range map[string]StateEncryption{"cached": cached, "uncached": uncached} {
+		decrypted, err := enc.DecryptState(encrypted)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(decrypted) != string(testData) {
+			t.Fatalf("%s: unexpected decrypted state: %s", name, decrypted)
+		}
+	}
+
+	if _, err := newState(t, `cache {
+		ttl = "forever"
+	}`); err == nil {
+		t.Fatalf("expected an error for an invalid ttl")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcmsiv
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// aesgcmsiv contains the encryption/decryption methods according to AES-GCM-SIV (RFC 8452).
+type aesgcmsiv struct {
+	encryptionKey []byte
+	decryptionKey []byte
+	aad           []byte
+}
+
+// Encrypt encrypts the passed data with AES-GCM-SIV. If the encryption fails, it returns an error.
+func (a aesgcmsiv) Encrypt(data []byte) ([]byte, error) {
+	aead, err := a.getAEAD(a.encryptionKey)
+	if err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: err}
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: &method.ErrCryptoFailure{
+			Message: "could not generate nonce",
+			Cause:   err,
+		}}
+	}
+
+	return aead.Seal(nonce, nonce, data, a.aad), nil
+}
+
+// Decrypt decrypts an AES-GCM-SIV-encrypted data set. If the data set fails decryption, it returns an error.
+func (a aesgcmsiv) Decrypt(data []byte) ([]byte, error) {
+	if len(a.decryptionKey) == 0 {
+		return nil, &method.ErrDecryptionKeyUnavailable{}
+	}
+	if len(data) == 0 {
+		return nil, &method.ErrDecryptionFailed{
+			Cause: method.ErrCryptoFailure{
+				Message: "cannot decrypt empty data",
+			},
+		}
+	}
+
+	aead, err := a.getAEAD(a.decryptionKey)
+	if err != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: err}
+	}
+
+	if len(data) < aead.NonceSize()+aead.Overhead() {
+		return nil, &method.ErrDecryptionFailed{
+			Cause: method.ErrCryptoFailure{
+				Message: "cannot decrypt data because it is too small (likely data corruption)",
+			},
+		}
+	}
+
+	nonce := data[:aead.NonceSize()]
+	data = data[aead.NonceSize():]
+
+	decrypted, err := aead.Open(nil, nonce, data, a.aad)
+	if err != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: err}
+	}
+	return decrypted, nil
+}
+
+func (a aesgcmsiv) getAEAD(key []byte) (cipher.AEAD, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, &method.ErrCryptoFailure{
+			Message: "failed to create AES-GCM-SIV cipher",
+			Cause:   err,
+		}
+	}
+	return aead, nil
+}
+
+// Is returns true if the passed method is an AES-GCM-SIV method.
+func Is(m method.Method) bool {
+	_, ok := m.(*aesgcmsiv)
+	return ok
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcmsiv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcmsiv"
+)
+
+func TestDecryptInvalidData(t *testing.T) {
+	config := &aesgcmsiv.Config{
+		Keys: keyprovider.Output{
+			EncryptionKey: []byte("ahfoo8Aiz2ohngah3ahd7ooQu6oov9Ai"),
+			DecryptionKey: []byte("ahfoo8Aiz2ohngah3ahd7ooQu6oov9Ai"),
+		},
+	}
+	m, err := config.Build()
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	encrypted, err := m.Encrypt([]byte("Hello world!"))
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	testCases := map[string][]byte{
+		"empty":     nil,
+		"short":     []byte("1"),
+		"invalid":   []byte("abcdefghijklmnopqrstuvwxyz0123456789"),
+		"truncated": encrypted[:len(encrypted)-1],
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decrypted, err := m.Decrypt(data)
+			if err == nil {
+				t.Fatalf("Expected error, got: %v", decrypted)
+			}
+			var e *method.ErrDecryptionFailed
+			if !errors.As(err, &e) {
+				t.Fatalf("Incorrect error type returned: %T (%v)", err, err)
+			}
+		})
+	}
+}
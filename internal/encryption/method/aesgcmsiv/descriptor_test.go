@@ -0,0 +1,18 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcmsiv_test
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcmsiv"
+)
+
+func TestDescriptor(t *testing.T) {
+	if id := aesgcmsiv.New().ID(); id != "aes_gcm_siv" {
+		t.Fatalf("Incorrect descriptor ID returned: %s", id)
+	}
+}
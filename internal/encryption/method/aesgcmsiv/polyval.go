@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcmsiv
+
+import (
+	"encoding/binary"
+)
+
+// fieldElement is an element of GF(2^128) in the little-endian representation used by POLYVAL, where the least
+// significant bit of lo is the coefficient of x^0 and the most significant bit of hi is the coefficient of x^127.
+type fieldElement struct {
+	lo, hi uint64
+}
+
+func fieldElementFromBytes(b []byte) fieldElement {
+	return fieldElement{
+		lo: binary.LittleEndian.Uint64(b[:8]),
+		hi: binary.LittleEndian.Uint64(b[8:16]),
+	}
+}
+
+func (f fieldElement) bytes() [16]byte {
+	var out [16]byte
+	binary.LittleEndian.PutUint64(out[:8], f.lo)
+	binary.LittleEndian.PutUint64(out[8:], f.hi)
+	return out
+}
+
+// dot returns a*b*x^-128 in GF(2^128) modulo x^128 + x^127 + x^126 + x^121 + 1, as defined in section 3 of RFC 8452.
+// The implementation is a simple bit-by-bit multiplication without data-dependent branches.
+func dot(a, b fieldElement) fieldElement {
+	var r fieldElement
+	for i := 0; i < 128; i++ {
+		var bit uint64
+		if i < 64 {
+			bit = (b.lo >> i) & 1
+		} else {
+			bit = (b.hi >> (i - 64)) & 1
+		}
+		mask := -bit
+		r.lo ^= a.lo & mask
+		r.hi ^= a.hi & mask
+
+		// Multiply by x^-1: if the constant term is set, add the modulus to make the element divisible by x, then
+		// shift right. The x^128 term of the modulus becomes x^127 after the shift.
+		carry := -(r.lo & 1)
+		r.lo ^= carry & 1
+		r.hi ^= carry & 0xc200000000000000
+		r.lo = r.lo>>1 | r.hi<<63
+		r.hi = r.hi>>1 | carry&(1<<63)
+	}
+	return r
+}
+
+// polyval computes the POLYVAL universal hash function of RFC 8452 over a sequence of inputs, each of which is padded
+// with zeroes to a multiple of 16 bytes.
+type polyval struct {
+	h fieldElement
+	s fieldElement
+}
+
+func newPolyval(key []byte) *polyval {
+	return &polyval{
+		h: fieldElementFromBytes(key),
+	}
+}
+
+// update adds the data, padded to a multiple of 16 bytes, to the hash.
+func (p *polyval) update(data []byte) {
+	for len(data) > 0 {
+		var block [16]byte
+		n := copy(block[:], data)
+		data = data[n:]
+
+		x := fieldElementFromBytes(block[:])
+		p.s.lo ^= x.lo
+		p.s.hi ^= x.hi
+		p.s = dot(p.s, p.h)
+	}
+}
+
+// sum returns the current value of the hash.
+func (p *polyval) sum() [16]byte {
+	return p.s.bytes()
+}
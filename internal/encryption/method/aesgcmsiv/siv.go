@@ -0,0 +1,165 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcmsiv
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	nonceSize = 12
+	tagSize   = 16
+)
+
+// sivAEAD is an implementation of AES-GCM-SIV as described in RFC 8452. The Go standard library and x/crypto don't
+// provide this construction, so it is implemented here on top of crypto/aes.
+//
+// Unlike AES-GCM, a repeated nonce only reveals whether the same plaintext was encrypted twice under the same key and
+// AAD, instead of compromising the confidentiality and integrity of all messages.
+type sivAEAD struct {
+	// keyGenerating is the cipher keyed with the key-generating key, used to derive the per-nonce keys.
+	keyGenerating cipher.Block
+	keySize       int
+}
+
+// newAEAD returns a cipher.AEAD for AES-GCM-SIV using the specified 16 or 32 byte key-generating key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, aes.KeySizeError(len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &sivAEAD{
+		keyGenerating: block,
+		keySize:       len(key),
+	}, nil
+}
+
+func (s *sivAEAD) NonceSize() int {
+	return nonceSize
+}
+
+func (s *sivAEAD) Overhead() int {
+	return tagSize
+}
+
+func (s *sivAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != nonceSize {
+		panic("aesgcmsiv: incorrect nonce length given to AES-GCM-SIV")
+	}
+	authKey, encBlock := s.deriveKeys(nonce)
+
+	var tag [tagSize]byte
+	s.tag(&tag, authKey, encBlock, nonce, plaintext, additionalData)
+
+	ret, out := sliceForAppend(dst, len(plaintext)+tagSize)
+	ctr(encBlock, &tag, out[:len(plaintext)], plaintext)
+	copy(out[len(plaintext):], tag[:])
+	return ret
+}
+
+func (s *sivAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != nonceSize {
+		panic("aesgcmsiv: incorrect nonce length given to AES-GCM-SIV")
+	}
+	if len(ciphertext) < tagSize {
+		return nil, errOpen
+	}
+	authKey, encBlock := s.deriveKeys(nonce)
+
+	var expectedTag [tagSize]byte
+	copy(expectedTag[:], ciphertext[len(ciphertext)-tagSize:])
+	ciphertext = ciphertext[:len(ciphertext)-tagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	ctr(encBlock, &expectedTag, out, ciphertext)
+
+	var tag [tagSize]byte
+	s.tag(&tag, authKey, encBlock, nonce, out, additionalData)
+	if subtle.ConstantTimeCompare(tag[:], expectedTag[:]) != 1 {
+		clear(out)
+		return nil, errOpen
+	}
+	return ret, nil
+}
+
+var errOpen = errors.New("aesgcmsiv: message authentication failed")
+
+// deriveKeys derives the message-authentication key and the message-encryption key for the given nonce as described
+// in section 4 of RFC 8452.
+func (s *sivAEAD) deriveKeys(nonce []byte) ([]byte, cipher.Block) {
+	var input, output [aes.BlockSize]byte
+	copy(input[4:], nonce)
+
+	derived := make([]byte, 0, 16+s.keySize)
+	for i := uint32(0); len(derived) < cap(derived); i++ {
+		binary.LittleEndian.PutUint32(input[:4], i)
+		s.keyGenerating.Encrypt(output[:], input[:])
+		derived = append(derived, output[:8]...)
+	}
+
+	encBlock, err := aes.NewCipher(derived[16:])
+	if err != nil {
+		// This cannot happen because the key size is validated in newAEAD.
+		panic(err)
+	}
+	return derived[:16], encBlock
+}
+
+// tag calculates the authentication tag over the plaintext and the additional data.
+func (s *sivAEAD) tag(tag *[tagSize]byte, authKey []byte, encBlock cipher.Block, nonce, plaintext, additionalData []byte) {
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+
+	p := newPolyval(authKey)
+	p.update(additionalData)
+	p.update(plaintext)
+	p.update(lengths[:])
+
+	sum := p.sum()
+	for i := range nonce {
+		sum[i] ^= nonce[i]
+	}
+	sum[15] &= 0x7f
+	encBlock.Encrypt(tag[:], sum[:])
+}
+
+// ctr runs AES in the counter mode variant of RFC 8452, which uses the tag as the initial counter block and only
+// increments the first 32 bits as a little-endian integer.
+func ctr(block cipher.Block, tag *[tagSize]byte, dst, src []byte) {
+	var counter, keyStream [aes.BlockSize]byte
+	copy(counter[:], tag[:])
+	counter[15] |= 0x80
+
+	for len(src) > 0 {
+		block.Encrypt(keyStream[:], counter[:])
+		binary.LittleEndian.PutUint32(counter[:4], binary.LittleEndian.Uint32(counter[:4])+1)
+
+		n := subtle.XORBytes(dst, src, keyStream[:])
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a slice with the contents of the given
+// slice followed by that many bytes and a second slice that aliases into it and contains only the extra bytes.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
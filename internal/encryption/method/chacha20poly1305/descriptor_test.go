@@ -0,0 +1,18 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chacha20poly1305_test
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/method/chacha20poly1305"
+)
+
+func TestDescriptor(t *testing.T) {
+	if id := chacha20poly1305.New().ID(); id != "chacha20_poly1305" {
+		t.Fatalf("Incorrect descriptor ID returned: %s", id)
+	}
+}
@@ -44,6 +44,10 @@ type PlanEncryption interface {
 	// Pass a potentially encrypted plan file as an input, and you will receive the decrypted plan file or an error as
 	// a result.
 	DecryptPlan([]byte) ([]byte, error)
+
+	// TryDecryptPlan attempts to decrypt a plan file and reports which method was able to read it, or why it could not
+	// be decrypted. It is intended for diagnostic purposes and discards the decrypted plan.
+	TryDecryptPlan([]byte) DecryptionResult
 }
 
 type planEncryption struct {
@@ -60,6 +64,12 @@ func (p planEncryption) EncryptPlan(data []byte) ([]byte, error) {
 }
 
 func (p planEncryption) DecryptPlan(data []byte) ([]byte, error) {
+	decrypted, _, err := p.decryptPlan(data)
+	return decrypted, err
+}
+
+// decryptPlan decrypts the plan file and returns the index of the method that was used to read it.
+func (p planEncryption) decryptPlan(data []byte) ([]byte, int, error) {
 	return p.base.decrypt(data, func(data []byte) error {
 		// Check magic bytes
 		if len(data) < 2 || string(data[:2]) != "PK" {
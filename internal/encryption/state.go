@@ -52,15 +52,40 @@ type StateEncryption interface {
 	// output to any additional functions that require a valid state file as it may not contain the fields typically
 	// present in a state file.
 	EncryptState([]byte) ([]byte, error)
+
+	// DecryptStateWithStatus works like DecryptState, but also reports whether the state file was read with the
+	// primary method or only with a fallback method. In the latter case, the state file should be encrypted again to
+	// move it to the primary method, for example after a key rotation.
+	DecryptStateWithStatus([]byte) ([]byte, DecryptionStatus, error)
+
+	// TryDecryptState attempts to decrypt a state file and reports which method was able to read it, or why it could
+	// not be decrypted. It is intended for diagnostic purposes and discards the decrypted state.
+	TryDecryptState([]byte) DecryptionResult
 }
 
 type stateEncryption struct {
 	base *baseEncryption
+	// mode is either config.StateModeFull or config.StateModeSensitiveAttributes and determines how the state is
+	// written. States encrypted in full can always be read.
+	mode string
+	// modes holds the mode of the target and each of its fallbacks, in the same order as the methods. Encrypted
+	// sensitive values are only read with the methods of targets in the sensitive_attributes mode.
+	modes []string
 }
 
-func newStateEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, staticEval *configs.StaticEvaluator) (StateEncryption, hcl.Diagnostics) {
+func newStateEncryption(enc *encryption, target *config.TargetConfig, enforced bool, mode string, name string, staticEval *configs.StaticEvaluator) (StateEncryption, hcl.Diagnostics) {
 	base, diags := newBaseEncryption(enc, target, enforced, name, staticEval)
-	return &stateEncryption{base}, diags
+	if mode == "" {
+		mode = config.StateModeFull
+	}
+	modes := []string{mode}
+	for fallback := target.Fallback; fallback != nil; fallback = fallback.Fallback {
+		if fallback.Mode != "" {
+			mode = fallback.Mode
+		}
+		modes = append(modes, mode)
+	}
+	return &stateEncryption{base: base, mode: modes[0], modes: modes}, diags
 }
 
 type statedata struct {
@@ -69,6 +94,10 @@ type statedata struct {
 }
 
 func (s *stateEncryption) EncryptState(plainState []byte) ([]byte, error) {
+	if s.mode == config.StateModeSensitiveAttributes {
+		return s.encryptSensitive(plainState)
+	}
+
 	var passthrough statedata
 	err := json.Unmarshal(plainState, &passthrough)
 	if err != nil {
@@ -88,7 +117,27 @@ func (s *stateEncryption) EncryptState(plainState []byte) ([]byte, error) {
 }
 
 func (s *stateEncryption) DecryptState(encryptedState []byte) ([]byte, error) {
-	decryptedState, err := s.base.decrypt(encryptedState, func(data []byte) error {
+	decryptedState, _, err := s.DecryptStateWithStatus(encryptedState)
+	return decryptedState, err
+}
+
+func (s *stateEncryption) DecryptStateWithStatus(encryptedState []byte) ([]byte, DecryptionStatus, error) {
+	decryptedState, _, status, err := s.decryptState(encryptedState)
+	return decryptedState, status, err
+}
+
+// decryptState decrypts the state file and returns the index of the method that was used to read it, in addition to
+// the decryption status.
+func (s *stateEncryption) decryptState(encryptedState []byte) ([]byte, int, DecryptionStatus, error) {
+	// States written in the sensitive_attributes mode are readable JSON with a header describing the encryption
+	var header struct {
+		Encryption *sensitiveStateHeader `json:"encryption"`
+	}
+	if err := json.Unmarshal(encryptedState, &header); err == nil && header.Encryption != nil {
+		return s.decryptSensitive(encryptedState, header.Encryption)
+	}
+
+	decryptedState, methodIndex, err := s.base.decrypt(encryptedState, func(data []byte) error {
 		tmp := struct {
 			FormatVersion string `json:"terraform_version"`
 		}{}
@@ -105,32 +154,41 @@ func (s *stateEncryption) DecryptState(encryptedState []byte) ([]byte, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, 0, DecryptedWithPrimary, err
 	}
+	status := decryptionStatus(methodIndex)
 
 	// Make sure that the state passthrough fields match
 	var encrypted statedata
 	err = json.Unmarshal(encryptedState, &encrypted)
 	if err != nil {
-		return nil, err
+		return nil, 0, DecryptedWithPrimary, err
 	}
 	var state statedata
 	err = json.Unmarshal(decryptedState, &state)
 	if err != nil {
-		return nil, err
+		return nil, 0, DecryptedWithPrimary, err
 	}
 
 	// TODO make encrypted.Serial non-optional.  This is only for supporting alpha1 states!
 	if encrypted.Serial != nil && state.Serial != nil && *state.Serial != *encrypted.Serial {
-		return nil, fmt.Errorf("invalid state metadata, serial field mismatch %v vs %v", *encrypted.Serial, *state.Serial)
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("invalid state metadata, serial field mismatch %v vs %v", *encrypted.Serial, *state.Serial)
 	}
 
 	// TODO make encrypted.Lineage non-optional.  This is only for supporting alpha1 states!
 	if encrypted.Lineage != "" && state.Lineage != encrypted.Lineage {
-		return nil, fmt.Errorf("invalid state metadata, linage field mismatch %v vs %v", encrypted.Lineage, state.Lineage)
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("invalid state metadata, linage field mismatch %v vs %v", encrypted.Lineage, state.Lineage)
 	}
 
-	return decryptedState, nil
+	if s.mode == config.StateModeSensitiveAttributes {
+		isEncrypted, _ := IsEncryptionPayload(encryptedState)
+		if isEncrypted {
+			// The state needs to be rewritten to switch to the sensitive_attributes mode
+			status = DecryptedWithFallback
+		}
+	}
+
+	return decryptedState, methodIndex, status, nil
 }
 
 func StateEncryptionDisabled() StateEncryption {
@@ -145,3 +203,6 @@ func (s *stateDisabled) EncryptState(plainState []byte) ([]byte, error) {
 func (s *stateDisabled) DecryptState(encryptedState []byte) ([]byte, error) {
 	return encryptedState, nil
 }
+func (s *stateDisabled) DecryptStateWithStatus(encryptedState []byte) ([]byte, DecryptionStatus, error) {
+	return encryptedState, DecryptedWithPrimary, nil
+}
@@ -0,0 +1,490 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+)
+
+// sensitiveStateHeaderField is the top level field of a state file that holds the sensitiveStateHeader when the state
+// is written in the sensitive_attributes mode.
+const sensitiveStateHeaderField = "encryption"
+
+// sensitiveStateHeader describes how the sensitive values in a state file were encrypted. The key provider metadata is
+// stored once for the whole file, as all values are encrypted with the same method. The digest is the encrypted
+// SHA-256 hash of the rest of the file, so that the readable parts of the file can't be changed, for example to
+// declare an attribute as not sensitive, without failing to decrypt.
+type sensitiveStateHeader struct {
+	Mode    string                                `json:"mode"`
+	Meta    map[keyprovider.MetaStorageKey][]byte `json:"meta"`
+	Digest  []byte                                `json:"digest"`
+	Version string                                `json:"encryption_version"`
+}
+
+// IsSensitiveAttributesPayload returns true if the given state file was written in the sensitive_attributes mode and
+// still contains encrypted values.
+func IsSensitiveAttributesPayload(data []byte) (bool, error) {
+	var header struct {
+		Encryption *sensitiveStateHeader `json:"encryption"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return false, err
+	}
+	return header.Encryption != nil && header.Encryption.Mode == config.StateModeSensitiveAttributes, nil
+}
+
+// sensitivePathStep is a single step of a path in the sensitive_attributes field of a resource instance, in the same
+// format the state file uses.
+type sensitivePathStep struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// mapKey returns the key this step refers to if it can be applied to an object or a map.
+func (s sensitivePathStep) mapKey() (string, bool) {
+	switch s.Type {
+	case "get_attr":
+		var name string
+		if err := json.Unmarshal(s.Value, &name); err != nil {
+			return "", false
+		}
+		return name, true
+	case "index":
+		var key struct {
+			Value interface{} `json:"value"`
+			Type  string      `json:"type"`
+		}
+		if err := json.Unmarshal(s.Value, &key); err != nil || key.Type != "string" {
+			return "", false
+		}
+		name, ok := key.Value.(string)
+		return name, ok
+	default:
+		return "", false
+	}
+}
+
+// String returns the step in the syntax of the OpenTofu language, like .name or [0].
+func (s sensitivePathStep) String() string {
+	if key, ok := s.mapKey(); ok {
+		if s.Type == "get_attr" {
+			return "." + key
+		}
+		return "[" + strconv.Quote(key) + "]"
+	}
+	if idx, ok := s.listIndex(); ok {
+		return "[" + strconv.Itoa(idx) + "]"
+	}
+	return "[" + string(s.Value) + "]"
+}
+
+// listIndex returns the index this step refers to if it can be applied to a list or a tuple.
+func (s sensitivePathStep) listIndex() (int, bool) {
+	if s.Type != "index" {
+		return 0, false
+	}
+	var key struct {
+		Value json.Number `json:"value"`
+		Type  string      `json:"type"`
+	}
+	if err := json.Unmarshal(s.Value, &key); err != nil || key.Type != "number" {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(key.Value.String())
+	if err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+// walkSensitivePath follows the path in the given value and replaces the value at the end of the path with the result
+// of fn. The walk stops early if it encounters an encrypted envelope, since everything below it is already encrypted,
+// or if the path can't be followed any further, for example because it indexes a set. In both cases fn is called with
+// the value at that point, so that no sensitive value is left out. Missing keys and indexes are skipped.
+//
+// The address passed to fn is the given address of the value followed by the steps that were actually taken, so it
+// identifies the replaced value in the same way when encrypting and when decrypting.
+func walkSensitivePath(value interface{}, addr string, path []sensitivePathStep, fn sensitiveValueVisitor) (interface{}, error) {
+	if len(path) == 0 || isSensitiveEnvelope(value) {
+		return fn(addr, value)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		key, ok := path[0].mapKey()
+		if !ok {
+			return fn(addr, value)
+		}
+		child, exists := v[key]
+		if !exists {
+			return value, nil
+		}
+		newChild, err := walkSensitivePath(child, addr+path[0].String(), path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[key] = newChild
+		return v, nil
+	case []interface{}:
+		idx, ok := path[0].listIndex()
+		if !ok {
+			return fn(addr, value)
+		}
+		if idx < 0 || idx >= len(v) {
+			return value, nil
+		}
+		newChild, err := walkSensitivePath(v[idx], addr+path[0].String(), path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = newChild
+		return v, nil
+	default:
+		return fn(addr, value)
+	}
+}
+
+// isSensitiveEnvelope returns true if the value is an encrypted value written in the sensitive_attributes mode.
+func isSensitiveEnvelope(value interface{}) bool {
+	v, ok := value.(map[string]interface{})
+	if !ok || len(v) != 2 {
+		return false
+	}
+	_, hasData := v["encrypted_data"].(string)
+	version, _ := v["encryption_version"].(string)
+	return hasData && version == encryptionVersion
+}
+
+// sensitiveValueVisitor is called for every sensitive attribute value and sensitive output in a state file with its
+// address, like output.name or module.a.test_instance.foo[0].password, and returns the value to replace it with.
+type sensitiveValueVisitor func(addr string, value interface{}) (interface{}, error)
+
+// visitSensitiveValues calls fn for each sensitive attribute value and sensitive output in the given decoded state.
+func visitSensitiveValues(state map[string]interface{}, fn sensitiveValueVisitor) error {
+	if outputs, ok := state["outputs"].(map[string]interface{}); ok {
+		for name, raw := range outputs {
+			output, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if sensitive, _ := output["sensitive"].(bool); !sensitive {
+				continue
+			}
+			value, err := walkSensitivePath(output["value"], "output."+name, nil, fn)
+			if err != nil {
+				return fmt.Errorf("output %q: %w", name, err)
+			}
+			output["value"] = value
+		}
+	}
+
+	resources, _ := state["resources"].([]interface{})
+	for _, rawResource := range resources {
+		resource, ok := rawResource.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		instances, _ := resource["instances"].([]interface{})
+		for _, rawInstance := range instances {
+			instance, ok := rawInstance.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			attributes, exists := instance["attributes"]
+			if !exists {
+				continue
+			}
+			paths, err := sensitivePaths(instance["sensitive_attributes"])
+			if err != nil {
+				return fmt.Errorf("resource %v.%v: %w", resource["type"], resource["name"], err)
+			}
+			addr := sensitiveInstanceAddr(resource, instance)
+			for _, path := range paths {
+				attributes, err = walkSensitivePath(attributes, addr, path, fn)
+				if err != nil {
+					return fmt.Errorf("resource %v.%v: %w", resource["type"], resource["name"], err)
+				}
+			}
+			instance["attributes"] = attributes
+		}
+	}
+	return nil
+}
+
+// sensitiveInstanceAddr returns the address of a resource instance in a decoded state, which binds the encrypted values
+// of the instance to it. Deposed objects have the deposed key appended, since they share the address of the current
+// object.
+func sensitiveInstanceAddr(resource, instance map[string]interface{}) string {
+	addr := fmt.Sprintf("%v.%v", resource["type"], resource["name"])
+	if resource["mode"] == "data" {
+		addr = "data." + addr
+	}
+	if module, _ := resource["module"].(string); module != "" {
+		addr = module + "." + addr
+	}
+	switch key := instance["index_key"].(type) {
+	case string:
+		addr += "[" + strconv.Quote(key) + "]"
+	case json.Number:
+		addr += "[" + key.String() + "]"
+	}
+	if deposed, _ := instance["deposed"].(string); deposed != "" {
+		addr += " (deposed object " + deposed + ")"
+	}
+	return addr
+}
+
+// sensitivePaths converts the decoded sensitive_attributes field of a resource instance into paths. Shorter paths are
+// returned first so that a value is encrypted as a whole before any of the paths inside it are visited.
+func sensitivePaths(raw interface{}) ([][]sensitivePathStep, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var paths [][]sensitivePathStep
+	if err := json.Unmarshal(buf, &paths); err != nil {
+		return nil, fmt.Errorf("invalid sensitive_attributes: %w", err)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) < len(paths[j])
+	})
+	return paths, nil
+}
+
+// decodeState decodes a JSON state file into generic values, keeping numbers in their exact representation.
+func decodeState(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// sensitiveStateDigest returns the SHA-256 hash of a decoded state file in the sensitive_attributes mode without its
+// header. The state is encoded with sorted keys and without whitespace, so the hash doesn't depend on the formatting of
+// the file.
+func sensitiveStateDigest(state map[string]interface{}) ([]byte, error) {
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(buf)
+	return digest[:], nil
+}
+
+// sensitivePlaintext is what is encrypted for each sensitive value. The value is stored together with its address, so
+// that an encrypted value can't be moved to a different place in the state file without failing to decrypt.
+type sensitivePlaintext struct {
+	Address string          `json:"address"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// encryptSensitive encrypts the sensitive values of the given state file with the primary method, leaving the rest of
+// the file readable.
+func (s *stateEncryption) encryptSensitive(plainState []byte) ([]byte, error) {
+	encryptor := s.base.encMethods[0]
+	if unencrypted.Is(encryptor) {
+		return plainState, nil
+	}
+
+	state, err := decodeState(plainState)
+	if err != nil {
+		return nil, err
+	}
+
+	err = visitSensitiveValues(state, func(addr string, value interface{}) (interface{}, error) {
+		if value == nil || isSensitiveEnvelope(value) {
+			return value, nil
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		plain, err := json.Marshal(sensitivePlaintext{Address: addr, Value: raw})
+		if err != nil {
+			return nil, err
+		}
+		encd, err := encryptor.Encrypt(plain)
+		if err != nil {
+			return nil, fmt.Errorf("encryption failed for %s: %w", s.base.name, err)
+		}
+		return map[string]interface{}{
+			"encrypted_data":     base64.StdEncoding.EncodeToString(encd),
+			"encryption_version": encryptionVersion,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := sensitiveStateDigest(state)
+	if err != nil {
+		return nil, err
+	}
+	encDigest, err := encryptor.Encrypt(digest)
+	if err != nil {
+		return nil, fmt.Errorf("encryption failed for %s: %w", s.base.name, err)
+	}
+
+	state[sensitiveStateHeaderField] = sensitiveStateHeader{
+		Mode:    config.StateModeSensitiveAttributes,
+		Meta:    s.base.outputEncMeta,
+		Digest:  encDigest,
+		Version: encryptionVersion,
+	}
+	return json.MarshalIndent(state, "", "  ")
+}
+
+// readsSensitive returns true if the target or one of its fallbacks is in the sensitive_attributes mode.
+func (s *stateEncryption) readsSensitive() bool {
+	for _, mode := range s.modes {
+		if mode == config.StateModeSensitiveAttributes {
+			return true
+		}
+	}
+	return false
+}
+
+// decryptSensitive decrypts the sensitive values of a state file written in the sensitive_attributes mode. The state
+// counts as decrypted with a fallback if any of its values needed a fallback method, or if the configuration no longer
+// uses the sensitive_attributes mode. The returned index is the highest index of the methods used.
+//
+// The file is only accepted if the target or one of its fallbacks is in the sensitive_attributes mode, every sensitive
+// value is encrypted and the rest of the file matches the encrypted digest in the header. Otherwise, anyone able to
+// write the state file could bypass the encryption by adding a header to an unencrypted state file.
+func (s *stateEncryption) decryptSensitive(encryptedState []byte, header *sensitiveStateHeader) ([]byte, int, DecryptionStatus, error) {
+	if !s.readsSensitive() {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("the state file was written in the %s mode, but neither %s nor its fallbacks are configured with this mode", config.StateModeSensitiveAttributes, s.base.name)
+	}
+	if header.Mode != config.StateModeSensitiveAttributes {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("invalid state encryption mode: %s", header.Mode)
+	}
+	if header.Version != encryptionVersion {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("invalid encrypted payload version: %s != %s", header.Version, encryptionVersion)
+	}
+	if len(header.Digest) == 0 {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("the state file has no encrypted digest")
+	}
+
+	methods, _, diags := s.base.buildTargetMethods(header.Meta, make(map[keyprovider.MetaStorageKey][]byte))
+	if diags.HasErrors() {
+		return nil, 0, DecryptedWithPrimary, diags
+	}
+
+	state, err := decodeState(encryptedState)
+	if err != nil {
+		return nil, 0, DecryptedWithPrimary, err
+	}
+	delete(state, sensitiveStateHeaderField)
+	digest, err := sensitiveStateDigest(state)
+	if err != nil {
+		return nil, 0, DecryptedWithPrimary, err
+	}
+
+	methodIndex := 0
+	err = visitSensitiveValues(state, func(addr string, value interface{}) (interface{}, error) {
+		if value == nil {
+			// Null values are not encrypted, as they don't reveal anything
+			return nil, nil
+		}
+		if !isSensitiveEnvelope(value) {
+			return nil, fmt.Errorf("the sensitive value of %s is not encrypted", addr)
+		}
+		encd, err := base64.StdEncoding.DecodeString(value.(map[string]interface{})["encrypted_data"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid encrypted value: %w", err)
+		}
+		plain, idx, err := s.decryptSensitiveValue(methods, encd)
+		if err != nil {
+			return nil, err
+		}
+		if idx > methodIndex {
+			methodIndex = idx
+		}
+		var plaintext sensitivePlaintext
+		if err := json.Unmarshal(plain, &plaintext); err != nil {
+			return nil, fmt.Errorf("invalid decrypted value of %s: %w", addr, err)
+		}
+		if plaintext.Address != addr {
+			return nil, fmt.Errorf("the encrypted value of %s was found at %s", plaintext.Address, addr)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(plaintext.Value))
+		decoder.UseNumber()
+		var decrypted interface{}
+		if err := decoder.Decode(&decrypted); err != nil {
+			return nil, fmt.Errorf("invalid decrypted value of %s: %w", addr, err)
+		}
+		return decrypted, nil
+	})
+	if err != nil {
+		return nil, 0, DecryptedWithPrimary, err
+	}
+
+	expectedDigest, idx, err := s.decryptSensitiveValue(methods, header.Digest)
+	if err != nil {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("invalid digest: %w", err)
+	}
+	if idx > methodIndex {
+		methodIndex = idx
+	}
+	if subtle.ConstantTimeCompare(digest, expectedDigest) != 1 {
+		return nil, 0, DecryptedWithPrimary, fmt.Errorf("the state file was modified after it was encrypted")
+	}
+
+	decryptedState, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return nil, 0, DecryptedWithPrimary, err
+	}
+
+	status := decryptionStatus(methodIndex)
+	if s.mode != config.StateModeSensitiveAttributes {
+		status = DecryptedWithFallback
+	}
+	return decryptedState, methodIndex, status, nil
+}
+
+// decryptSensitiveValue tries each method of a target in the sensitive_attributes mode in order and returns the
+// decrypted value along with the index of the method that succeeded.
+func (s *stateEncryption) decryptSensitiveValue(methods []method.Method, encd []byte) ([]byte, int, error) {
+	errs := make([]error, 0)
+	for i, m := range methods {
+		if unencrypted.Is(m) || i >= len(s.modes) || s.modes[i] != config.StateModeSensitiveAttributes {
+			continue
+		}
+		plain, err := m.Decrypt(encd)
+		if err == nil {
+			return plain, i, nil
+		}
+		errs = append(errs, fmt.Errorf("attempted decryption failed for %s: %w", s.base.name, err))
+	}
+
+	errMessage := "decryption failed for all provided methods: "
+	sep := ""
+	for _, err := range errs {
+		errMessage += err.Error() + sep
+		sep = "\n"
+	}
+	return nil, 0, fmt.Errorf(errMessage)
+}
@@ -0,0 +1,456 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/pbkdf2"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+const sensitiveTestState = `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 3,
+  "lineage": "magic",
+  "outputs": {
+    "public": {"value": "hello", "type": "string"},
+    "secret": {"value": {"a": 12345678901234567890}, "type": ["object", {"a": "number"}], "sensitive": true}
+  },
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "test_instance",
+      "name": "foo",
+      "provider": "provider[\"registry.opentofu.org/hashicorp/test\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "foo-id",
+            "password": "hunter2",
+            "nested": {"token": "abcdef", "other": 1},
+            "list": ["visible", "hidden"],
+            "empty": null
+          },
+          "sensitive_attributes": [
+            [{"type": "get_attr", "value": "password"}],
+            [{"type": "get_attr", "value": "nested"}, {"type": "get_attr", "value": "token"}],
+            [{"type": "get_attr", "value": "list"}, {"type": "index", "value": {"value": 1, "type": "number"}}],
+            [{"type": "get_attr", "value": "empty"}],
+            [{"type": "get_attr", "value": "missing"}]
+          ]
+        }
+      ]
+    }
+  ],
+  "check_results": null
+}`
+
+func TestStateEncryptionSensitiveAttributes(t *testing.T) {
+	const keyProviders = `
+		key_provider "pbkdf2" "basic" {
+			passphrase = "Hello world! 123"
+		}
+		method "aes_gcm" "example" {
+			keys = key_provider.pbkdf2.basic
+		}
+		method "unencrypted" "migrate" {}
+	`
+
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(unencrypted.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	stateEncryption := func(t *testing.T, state string) StateEncryption {
+		t.Helper()
+
+		cfg, diags := config.LoadConfigFromString("test", keyProviders+state)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		return enc.State()
+	}
+
+	sensitiveEnc := stateEncryption(t, `state {
+		mode   = "sensitive_attributes"
+		method = method.aes_gcm.example
+		fallback {
+			method = method.unencrypted.migrate
+		}
+	}`)
+	fullEnc := stateEncryption(t, `state {
+		method = method.aes_gcm.example
+		fallback {
+			mode   = "sensitive_attributes"
+			method = method.aes_gcm.example
+		}
+	}`)
+
+	encrypted, err := sensitiveEnc.EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The structure and the non-sensitive values must stay readable
+	if isEncrypted, _ := IsEncryptionPayload(encrypted); isEncrypted {
+		t.Fatalf("state was encrypted in full")
+	}
+	if isSensitive, _ := IsSensitiveAttributesPayload(encrypted); !isSensitive {
+		t.Fatalf("state was not marked as containing encrypted sensitive values")
+	}
+	for _, visible := range []string{`"foo-id"`, `"hello"`, `"visible"`, `"lineage": "magic"`, `"other": 1`} {
+		if !strings.Contains(string(encrypted), visible) {
+			t.Errorf("expected %s to be readable in:\n%s", visible, encrypted)
+		}
+	}
+	for _, hidden := range []string{"hunter2", "abcdef", `"hidden"`, "12345678901234567890"} {
+		if strings.Contains(string(encrypted), hidden) {
+			t.Errorf("expected %s to be encrypted in:\n%s", hidden, encrypted)
+		}
+	}
+
+	testCases := map[string]struct {
+		enc      StateEncryption
+		data     []byte
+		expected DecryptionStatus
+	}{
+		"sensitive-from-sensitive": {
+			enc:      sensitiveEnc,
+			data:     encrypted,
+			expected: DecryptedWithPrimary,
+		},
+		"sensitive-from-plain": {
+			enc:      sensitiveEnc,
+			data:     []byte(sensitiveTestState),
+			expected: DecryptedWithFallback,
+		},
+		"full-from-sensitive": {
+			enc:      fullEnc,
+			data:     encrypted,
+			expected: DecryptedWithFallback,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decrypted, status, err := tc.enc.DecryptStateWithStatus(tc.data)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if status != tc.expected {
+				t.Fatalf("expected status %d, got %d", tc.expected, status)
+			}
+			assertJSONEqual(t, sensitiveTestState, string(decrypted))
+		})
+	}
+
+	// A state encrypted in full is read by the sensitive_attributes mode, but needs to be rewritten
+	fullEncrypted, err := fullEnc.EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	decrypted, status, err := sensitiveEnc.DecryptStateWithStatus(fullEncrypted)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if status != DecryptedWithFallback {
+		t.Fatalf("expected status %d, got %d", DecryptedWithFallback, status)
+	}
+	assertJSONEqual(t, sensitiveTestState, string(decrypted))
+}
+
+func TestStateEncryptionSensitiveAttributesSwapped(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	cfg, diags := config.LoadConfigFromString("test", `
+		key_provider "pbkdf2" "basic" {
+			passphrase = "Hello world! 123"
+		}
+		method "aes_gcm" "example" {
+			keys = key_provider.pbkdf2.basic
+		}
+		state {
+			mode   = "sensitive_attributes"
+			method = method.aes_gcm.example
+		}
+	`)
+	if diags.HasErrors() {
+		t.Fatalf("%v", diags.Error())
+	}
+	enc, diags := New(reg, cfg, staticEval)
+	if diags.HasErrors() {
+		t.Fatalf("%v", diags.Error())
+	}
+	stateEnc := enc.State()
+
+	encrypted, err := stateEnc.EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testCases := map[string]func(state map[string]interface{}){
+		"between attributes": func(state map[string]interface{}) {
+			attributes := state["resources"].([]interface{})[0].(map[string]interface{})["instances"].([]interface{})[0].(map[string]interface{})["attributes"].(map[string]interface{})
+			nested := attributes["nested"].(map[string]interface{})
+			attributes["password"], nested["token"] = nested["token"], attributes["password"]
+		},
+		"between an attribute and an output": func(state map[string]interface{}) {
+			attributes := state["resources"].([]interface{})[0].(map[string]interface{})["instances"].([]interface{})[0].(map[string]interface{})["attributes"].(map[string]interface{})
+			output := state["outputs"].(map[string]interface{})["secret"].(map[string]interface{})
+			attributes["password"], output["value"] = output["value"], attributes["password"]
+		},
+		"to another resource": func(state map[string]interface{}) {
+			resource := state["resources"].([]interface{})[0].(map[string]interface{})
+			resource["name"] = "bar"
+		},
+	}
+	for name, swap := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var state map[string]interface{}
+			if err := json.Unmarshal(encrypted, &state); err != nil {
+				t.Fatalf("%v", err)
+			}
+			swap(state)
+			swapped, err := json.Marshal(state)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			_, err = stateEnc.DecryptState(swapped)
+			if err == nil {
+				t.Fatalf("expected the swapped value to fail to decrypt")
+			}
+			if !strings.Contains(err.Error(), "was found at") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestStateEncryptionSensitiveAttributesTampered(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(pbkdf2.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		panic(err)
+	}
+	staticEval := configs.NewStaticEvaluator(nil, configs.RootModuleCallForTesting())
+
+	stateEncryption := func(t *testing.T, state string) StateEncryption {
+		t.Helper()
+
+		cfg, diags := config.LoadConfigFromString("test", `
+			key_provider "pbkdf2" "basic" {
+				passphrase = "Hello world! 123"
+			}
+			method "aes_gcm" "example" {
+				keys = key_provider.pbkdf2.basic
+			}
+		`+state)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatalf("%v", diags.Error())
+		}
+		return enc.State()
+	}
+
+	sensitiveEnc := stateEncryption(t, `state {
+		mode     = "sensitive_attributes"
+		method   = method.aes_gcm.example
+		enforced = true
+	}`)
+	fullEnc := stateEncryption(t, `state {
+		method   = method.aes_gcm.example
+		enforced = true
+	}`)
+
+	encrypted, err := sensitiveEnc.EncryptState([]byte(sensitiveTestState))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// forgedHeader adds the header of the encrypted state to the plaintext state
+	forgedHeader := func(state map[string]interface{}) {
+		var plain map[string]interface{}
+		if err := json.Unmarshal([]byte(sensitiveTestState), &plain); err != nil {
+			t.Fatalf("%v", err)
+		}
+		header := state[sensitiveStateHeaderField]
+		for k := range state {
+			delete(state, k)
+		}
+		for k, v := range plain {
+			state[k] = v
+		}
+		state[sensitiveStateHeaderField] = header
+	}
+	attributes := func(state map[string]interface{}) map[string]interface{} {
+		return state["resources"].([]interface{})[0].(map[string]interface{})["instances"].([]interface{})[0].(map[string]interface{})["attributes"].(map[string]interface{})
+	}
+
+	testCases := map[string]struct {
+		enc    StateEncryption
+		tamper func(state map[string]interface{})
+		err    string
+	}{
+		"forged header in the full mode": {
+			enc:    fullEnc,
+			tamper: forgedHeader,
+			err:    "neither state nor its fallbacks are configured with this mode",
+		},
+		"forged header in the sensitive_attributes mode": {
+			enc:    sensitiveEnc,
+			tamper: forgedHeader,
+			err:    "is not encrypted",
+		},
+		"stripped attribute envelope": {
+			enc: sensitiveEnc,
+			tamper: func(state map[string]interface{}) {
+				attributes(state)["password"] = "hunter3"
+			},
+			err: "the sensitive value of test_instance.foo.password is not encrypted",
+		},
+		"stripped output envelope": {
+			enc: sensitiveEnc,
+			tamper: func(state map[string]interface{}) {
+				state["outputs"].(map[string]interface{})["secret"].(map[string]interface{})["value"] = "plain"
+			},
+			err: "the sensitive value of output.secret is not encrypted",
+		},
+		"changed readable attribute": {
+			enc: sensitiveEnc,
+			tamper: func(state map[string]interface{}) {
+				attributes(state)["id"] = "bar-id"
+			},
+			err: "the state file was modified after it was encrypted",
+		},
+		"output marked as not sensitive": {
+			enc: sensitiveEnc,
+			tamper: func(state map[string]interface{}) {
+				delete(state["outputs"].(map[string]interface{})["secret"].(map[string]interface{}), "sensitive")
+			},
+			err: "the state file was modified after it was encrypted",
+		},
+		"missing digest": {
+			enc: sensitiveEnc,
+			tamper: func(state map[string]interface{}) {
+				delete(state[sensitiveStateHeaderField].(map[string]interface{}), "digest")
+			},
+			err: "the state file has no encrypted digest",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var state map[string]interface{}
+			if err := json.Unmarshal(encrypted, &state); err != nil {
+				t.Fatalf("%v", err)
+			}
+			tc.tamper(state)
+			tampered, err := json.Marshal(state)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			_, err = tc.enc.DecryptState(tampered)
+			if err == nil {
+				t.Fatalf("expected the tampered state to fail to decrypt")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected an error containing %q, got: %v", tc.err, err)
+			}
+		})
+	}
+
+	// Formatting the state file differently does not change the digest
+	var state map[string]interface{}
+	if err := json.Unmarshal(encrypted, &state); err != nil {
+		t.Fatalf("%v", err)
+	}
+	compact, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	decrypted, err := sensitiveEnc.DecryptState(compact)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assertJSONEqual(t, sensitiveTestState, string(decrypted))
+}
+
+func TestStateEncryptionModeInvalid(t *testing.T) {
+	_, diags := config.LoadConfigFromString("test", `
+		method "unencrypted" "migrate" {}
+		state {
+			mode   = "partial"
+			method = method.unencrypted.migrate
+			fallback {
+				mode   = "other"
+				method = method.unencrypted.migrate
+			}
+		}
+		plan {
+			method = method.unencrypted.migrate
+			fallback {
+				mode   = "sensitive_attributes"
+				method = method.unencrypted.migrate
+			}
+		}
+	`)
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %v", diags)
+	}
+	for i, summary := range []string{"Invalid state encryption mode", "Invalid state encryption mode", "Unsupported plan encryption mode"} {
+		if diags[i].Summary != summary {
+			t.Errorf("unexpected diagnostic: %v", diags[i])
+		}
+	}
+}
+
+func assertJSONEqual(t *testing.T, expected string, actual string) {
+	t.Helper()
+
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
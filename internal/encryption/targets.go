@@ -36,7 +36,9 @@ type targetBuilder struct {
 	staticEval   *configs.StaticEvaluator
 }
 
-func (base *baseEncryption) buildTargetMethods(inputMeta map[keyprovider.MetaStorageKey][]byte, outputMeta map[keyprovider.MetaStorageKey][]byte) ([]method.Method, hcl.Diagnostics) {
+// buildTargetMethods returns the primary and fallback methods for the target, along with their addresses in the same
+// order.
+func (base *baseEncryption) buildTargetMethods(inputMeta map[keyprovider.MetaStorageKey][]byte, outputMeta map[keyprovider.MetaStorageKey][]byte) ([]method.Method, []method.Addr, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	builder := &targetBuilder{
@@ -55,21 +57,21 @@ func (base *baseEncryption) buildTargetMethods(inputMeta map[keyprovider.MetaSto
 	keyDiags := append(diags, builder.setupKeyProviders()...)
 	diags = append(diags, keyDiags...)
 	if diags.HasErrors() {
-		return nil, diags
+		return nil, nil, diags
 	}
 	methodDiags := append(diags, builder.setupMethods()...)
 	diags = append(diags, methodDiags...)
 	if diags.HasErrors() {
-		return nil, diags
+		return nil, nil, diags
 	}
 
-	methods, targetDiags := builder.build(base.target, base.name)
+	methods, addrs, targetDiags := builder.build(base.target, base.name)
 	diags = append(diags, targetDiags...)
 
 	if base.enforced {
 		for _, m := range methods {
 			if unencrypted.Is(m) {
-				return nil, append(diags, &hcl.Diagnostic{
+				return nil, nil, append(diags, &hcl.Diagnostic{
 					Severity: hcl.DiagError,
 					Summary:  "Unencrypted method is forbidden",
 					Detail:   "Unable to use `unencrypted` method since the `enforced` flag is used.",
@@ -78,13 +80,13 @@ func (base *baseEncryption) buildTargetMethods(inputMeta map[keyprovider.MetaSto
 		}
 	}
 
-	return methods, diags
+	return methods, addrs, diags
 }
 
-// build sets up a single target for encryption. It returns the primary and fallback methods for the target, as well
-// as a list of diagnostics if the target is invalid.
+// build sets up a single target for encryption. It returns the primary and fallback methods for the target and their
+// addresses, as well as a list of diagnostics if the target is invalid.
 // The targetName parameter is used for error messages only.
-func (e *targetBuilder) build(target *config.TargetConfig, targetName string) (methods []method.Method, diags hcl.Diagnostics) {
+func (e *targetBuilder) build(target *config.TargetConfig, targetName string) (methods []method.Method, addrs []method.Addr, diags hcl.Diagnostics) {
 
 	// gohcl has some weirdness around attributes that are not provided, but are hcl.Expressions
 	// They will set the attribute field to a static null expression
@@ -98,8 +100,10 @@ func (e *targetBuilder) build(target *config.TargetConfig, targetName string) (m
 	// Only attempt to fetch the method if the decoding was successful
 	if !decodeDiags.HasErrors() {
 		if methodIdent != nil {
-			if method, ok := e.methods[method.Addr(*methodIdent)]; ok {
-				methods = append(methods, method)
+			addr := method.Addr(*methodIdent)
+			if m, ok := e.methods[addr]; ok {
+				methods = append(methods, m)
+				addrs = append(addrs, addr)
 			} else {
 				// We can't continue if the method is not found
 				diags = append(diags, &hcl.Diagnostic{
@@ -121,10 +125,11 @@ func (e *targetBuilder) build(target *config.TargetConfig, targetName string) (m
 
 	// Attempt to fetch the fallback method if it's been configured
 	if target.Fallback != nil {
-		fallback, fallbackDiags := e.build(target.Fallback, targetName+".fallback")
+		fallback, fallbackAddrs, fallbackDiags := e.build(target.Fallback, targetName+".fallback")
 		diags = append(diags, fallbackDiags...)
 		methods = append(methods, fallback...)
+		addrs = append(addrs, fallbackAddrs...)
 	}
 
-	return methods, diags
+	return methods, addrs, diags
 }
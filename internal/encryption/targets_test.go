@@ -17,6 +17,8 @@ import (
 	"github.com/opentofu/opentofu/internal/encryption/keyprovider/static"
 	"github.com/opentofu/opentofu/internal/encryption/method"
 	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcmsiv"
+	"github.com/opentofu/opentofu/internal/encryption/method/chacha20poly1305"
 	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
 	"github.com/opentofu/opentofu/internal/encryption/registry"
 	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
@@ -76,6 +78,29 @@ func TestBaseEncryption_buildTargetMethods(t *testing.T) {
 				unencrypted.Is,
 			},
 		},
+		"fallback-chacha20-poly1305-aes-gcm-siv": {
+			rawConfig: `
+				key_provider "static" "basic" {
+					key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+				}
+				method "chacha20_poly1305" "example" {
+					keys = key_provider.static.basic
+				}
+				method "aes_gcm_siv" "example" {
+					keys = key_provider.static.basic
+				}
+				state {
+					method = method.chacha20_poly1305.example
+					fallback {
+						method = method.aes_gcm_siv.example
+					}
+				}
+			`,
+			wantMethods: []func(method.Method) bool{
+				chacha20poly1305.Is,
+				aesgcmsiv.Is,
+			},
+		},
 		"enforced": {
 			rawConfig: `
 				key_provider "static" "basic" {
@@ -187,6 +212,12 @@ func TestBaseEncryption_buildTargetMethods(t *testing.T) {
 	if err := reg.RegisterMethod(unencrypted.New()); err != nil {
 		panic(err)
 	}
+	if err := reg.RegisterMethod(chacha20poly1305.New()); err != nil {
+		panic(err)
+	}
+	if err := reg.RegisterMethod(aesgcmsiv.New()); err != nil {
+		panic(err)
+	}
 
 	mod := &configs.Module{
 		Variables: map[string]*configs.Variable{
@@ -243,7 +274,7 @@ func (testCase btmTestCase) newTestRun(reg registry.Registry, staticEval *config
 			staticEval:    staticEval,
 		}
 
-		methods, diags := base.buildTargetMethods(base.inputEncMeta, base.outputEncMeta)
+		methods, _, diags := base.buildTargetMethods(base.inputEncMeta, base.outputEncMeta)
 
 		if diags.HasErrors() {
 			if !hasDiagWithMsg(diags, testCase.wantErr) {
@@ -501,6 +501,64 @@ func TestExpander(t *testing.T) {
 	})
 }
 
+func TestExpanderEnabled(t *testing.T) {
+	enabledModuleAddr := addrs.ModuleCall{Name: "enabled"}
+	disabledModuleAddr := addrs.ModuleCall{Name: "disabled"}
+	enabledResourceAddr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test",
+		Name: "enabled",
+	}
+	disabledResourceAddr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test",
+		Name: "disabled",
+	}
+
+	ex := NewExpander()
+	ex.SetResourceEnabled(addrs.RootModuleInstance, enabledResourceAddr, true)
+	ex.SetResourceEnabled(addrs.RootModuleInstance, disabledResourceAddr, false)
+	ex.SetModuleEnabled(addrs.RootModuleInstance, enabledModuleAddr, true)
+	ex.SetModuleEnabled(addrs.RootModuleInstance, disabledModuleAddr, false)
+	ex.SetResourceEnabled(addrs.RootModuleInstance.Child("enabled", addrs.NoKey), enabledResourceAddr, true)
+
+	t.Run("resource enabled", func(t *testing.T) {
+		got := ex.ExpandModuleResource(addrs.RootModule, enabledResourceAddr)
+		want := []addrs.AbsResourceInstance{
+			mustAbsResourceInstanceAddr(`test.enabled`),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+		if got := ex.GetResourceInstanceRepetitionData(want[0]); got != (RepetitionData{}) {
+			t.Errorf("wrong repetition data\ngot:  %#v\nwant: %#v", got, RepetitionData{})
+		}
+	})
+	t.Run("resource disabled", func(t *testing.T) {
+		got := ex.ExpandModuleResource(addrs.RootModule, disabledResourceAddr)
+		want := []addrs.AbsResourceInstance(nil)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("module enabled resource enabled", func(t *testing.T) {
+		got := ex.ExpandModuleResource(mustModuleAddr(`enabled`), enabledResourceAddr)
+		want := []addrs.AbsResourceInstance{
+			mustAbsResourceInstanceAddr(`module.enabled.test.enabled`),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("module disabled", func(t *testing.T) {
+		got := ex.ExpandModule(mustModuleAddr(`disabled`))
+		want := []addrs.ModuleInstance(nil)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+}
+
 func mustAbsResourceInstanceAddr(str string) addrs.AbsResourceInstance {
 	addr, diags := addrs.ParseAbsResourceInstanceStr(str)
 	if diags.HasErrors() {
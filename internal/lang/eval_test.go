@@ -968,7 +968,19 @@ func Test_enhanceFunctionDiags(t *testing.T) {
 			"Invalid prefix",
 			"attr = magic::missing_function(54)",
 			"Unknown function namespace",
-			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core)",
+			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core,module,tofu)",
+		},
+		{
+			"Missing tofu function",
+			"attr = tofu::missing_function(54)",
+			"Call to unknown function",
+			"There is no builtin (tofu::) function named \"missing_function\".",
+		},
+		{
+			"Missing module function",
+			"attr = module::missing_function(54)",
+			"Call to unknown function",
+			"There is no function named \"missing_function\" declared in this module.",
 		},
 		{
 			"Too many namespaces",
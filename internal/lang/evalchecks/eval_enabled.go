@@ -0,0 +1,85 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package evalchecks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// EvaluateEnabledExpression is our standard mechanism for interpreting an
+// expression given for an "enabled" argument on a resource or a module. This
+// should be called during expansion in order to determine whether the single
+// instance of the object exists.
+//
+// EvaluateEnabledExpression differs from EvaluateEnabledExpressionValue by
+// returning an error if the enabled value is not known, and converting the
+// cty.Value to a bool.
+func EvaluateEnabledExpression(expr hcl.Expression, ctx EvaluateFunc) (bool, tfdiags.Diagnostics) {
+	enabledVal, diags := EvaluateEnabledExpressionValue(expr, ctx)
+	if !enabledVal.IsKnown() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid enabled argument",
+			Detail:   `The "enabled" value depends on resource attributes that cannot be determined until apply, so OpenTofu cannot predict whether the object will be created. To work around this, use the -target argument to first apply only the resources that the enabled argument depends on.`,
+			Subject:  expr.Range().Ptr(),
+			Extra:    DiagnosticCausedByUnknown(true),
+		})
+	}
+
+	if enabledVal.IsNull() || !enabledVal.IsKnown() {
+		return false, diags
+	}
+
+	return enabledVal.True(), diags
+}
+
+// EvaluateEnabledExpressionValue is like EvaluateEnabledExpression
+// except that it returns a cty.Value which must be a cty.Bool and can be
+// unknown.
+func EvaluateEnabledExpressionValue(expr hcl.Expression, ctx EvaluateFunc) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	nullEnabled := cty.NullVal(cty.Bool)
+	if expr == nil {
+		return nullEnabled, nil
+	}
+
+	enabledVal, enabledDiags := ctx(expr)
+	diags = diags.Append(enabledDiags)
+	if diags.HasErrors() {
+		return nullEnabled, diags
+	}
+
+	// Like count, a sensitive enabled value is allowed because using it here
+	// does not disclose anything beyond whether the object exists.
+	enabledVal, _ = enabledVal.Unmark()
+
+	if enabledVal.IsNull() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid enabled argument",
+			Detail:   `The given "enabled" argument value is null. A boolean is required.`,
+			Subject:  expr.Range().Ptr(),
+		})
+		return nullEnabled, diags
+	}
+
+	enabledVal, err := convert.Convert(enabledVal, cty.Bool)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid enabled argument",
+			Detail:   fmt.Sprintf(`The given "enabled" argument value is unsuitable: %s.`, tfdiags.FormatError(err)),
+			Subject:  expr.Range().Ptr(),
+		})
+		return nullEnabled, diags
+	}
+
+	return enabledVal, diags
+}
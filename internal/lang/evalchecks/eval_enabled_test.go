@@ -0,0 +1,101 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package evalchecks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hcltest"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEvaluateEnabledExpression_valid(t *testing.T) {
+	tests := map[string]struct {
+		val      cty.Value
+		expected bool
+	}{
+		"true": {
+			cty.True,
+			true,
+		},
+		"false": {
+			cty.False,
+			false,
+		},
+		"string": {
+			cty.StringVal("true"),
+			true,
+		},
+		"sensitive": {
+			cty.True.Mark(marks.Sensitive),
+			true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := EvaluateEnabledExpression(hcltest.MockExprLiteral(test.val), mockEvaluateFunc(test.val))
+
+			if len(diags) != 0 {
+				t.Errorf("unexpected diagnostics %s", diags.Err())
+			}
+			if actual != test.expected {
+				t.Errorf("wrong result\ngot:  %t\nwant: %t", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluateEnabledExpression_errors(t *testing.T) {
+	tests := map[string]struct {
+		val                      cty.Value
+		Summary, DetailSubstring string
+		CausedByUnknown          bool
+	}{
+		"null": {
+			cty.NullVal(cty.Bool),
+			"Invalid enabled argument",
+			`The given "enabled" argument value is null. A boolean is required.`,
+			false,
+		},
+		"number": {
+			cty.NumberIntVal(1),
+			"Invalid enabled argument",
+			`The given "enabled" argument value is unsuitable: bool required.`,
+			false,
+		},
+		"unknown": {
+			cty.UnknownVal(cty.Bool),
+			"Invalid enabled argument",
+			`The "enabled" value depends on resource attributes that cannot be determined until apply`,
+			true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := EvaluateEnabledExpression(hcltest.MockExprLiteral(test.val), mockEvaluateFunc(test.val))
+
+			if len(diags) != 1 {
+				t.Fatalf("got %d diagnostics; want 1", len(diags))
+			}
+			if got, want := diags[0].Severity(), tfdiags.Error; got != want {
+				t.Errorf("wrong diagnostic severity %#v; want %#v", got, want)
+			}
+			if got, want := diags[0].Description().Summary, test.Summary; got != want {
+				t.Errorf("wrong diagnostic summary\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := diags[0].Description().Detail, test.DetailSubstring; !strings.Contains(got, want) {
+				t.Errorf("wrong diagnostic detail\ngot: %s\nwant substring: %s", got, want)
+			}
+			if got, want := tfdiags.DiagnosticCausedByUnknown(diags[0]), test.CausedByUnknown; got != want {
+				t.Errorf("wrong result from tfdiags.DiagnosticCausedByUnknown\ngot:  %#v\nwant: %#v", got, want)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@
 package funcs
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rsa"
 	"crypto/sha1"
@@ -24,7 +27,9 @@ import (
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/gocty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/hkdf"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -166,18 +171,9 @@ var RsaDecryptFunc = function.New(&function.Spec{
 			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "failed to decode input %q: cipher text must be base64-encoded", s)
 		}
 
-		rawKey, err := ssh.ParseRawPrivateKey([]byte(key))
+		rawKey, err := parsePrivateKey(key, 1)
 		if err != nil {
-			var errStr string
-			switch e := err.(type) {
-			case asn1.SyntaxError:
-				errStr = strings.ReplaceAll(e.Error(), "asn1: syntax error", "invalid ASN1 data in the given private key")
-			case asn1.StructuralError:
-				errStr = strings.ReplaceAll(e.Error(), "asn1: structure error", "invalid ASN1 data in the given private key")
-			default:
-				errStr = fmt.Sprintf("invalid private key: %s", e)
-			}
-			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, errStr)
+			return cty.UnknownVal(cty.String), err
 		}
 		privateKey, ok := rawKey.(*rsa.PrivateKey)
 		if !ok {
@@ -193,6 +189,123 @@ var RsaDecryptFunc = function.New(&function.Spec{
 	},
 })
 
+// HmacSha256Func constructs a function that computes the HMAC-SHA256 of a
+// given string with a given key and encodes it with hexadecimal digits.
+var HmacSha256Func = makeStringHmacFunction(sha256.New, hex.EncodeToString)
+
+// HmacSha512Func constructs a function that computes the HMAC-SHA512 of a
+// given string with a given key and encodes it with hexadecimal digits.
+var HmacSha512Func = makeStringHmacFunction(sha512.New, hex.EncodeToString)
+
+// HkdfFunc constructs a function that derives a key from a secret using
+// HKDF-SHA256, as defined in RFC 5869, and encodes it with Base64.
+var HkdfFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "secret",
+			Type: cty.String,
+		},
+		{
+			Name: "salt",
+			Type: cty.String,
+		},
+		{
+			Name: "info",
+			Type: cty.String,
+		},
+		{
+			Name: "length",
+			Type: cty.Number,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		secret := args[0].AsString()
+		salt := args[1].AsString()
+		info := args[2].AsString()
+
+		var length int
+		if err := gocty.FromCtyValue(args[3], &length); err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(3, err)
+		}
+		if max := 255 * sha256.Size; length < 1 || length > max {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(3, "length must be between 1 and %d bytes", max)
+		}
+
+		out := make([]byte, length)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), []byte(salt), []byte(info)), out); err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to derive key: %w", err)
+		}
+
+		return cty.StringVal(base64.StdEncoding.EncodeToString(out)), nil
+	},
+})
+
+// JWTSignFunc constructs a function that encodes the given claims as a JSON
+// Web Token signed with the given private key. The signature algorithm is
+// RS256 for RSA keys and ES256 for ECDSA P-256 keys.
+var JWTSignFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "claims",
+			Type: cty.DynamicPseudoType,
+		},
+		{
+			Name: "privatekey",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		claims := args[0]
+		if !claims.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		if ty := claims.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "claims must be an object")
+		}
+		payload, err := ctyjson.Marshal(claims, claims.Type())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "failed to encode claims: %s", err)
+		}
+
+		rawKey, err := parsePrivateKey(args[1].AsString(), 1)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+
+		var alg string
+		switch rawKey.(type) {
+		case *rsa.PrivateKey:
+			alg = "RS256"
+		case *ecdsa.PrivateKey:
+			alg = "ES256"
+		default:
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "unsupported private key type %T: must be an RSA or ECDSA P-256 key", rawKey)
+		}
+
+		header := fmt.Sprintf(`{"alg":%q,"typ":"JWT"}`, alg)
+		signingInput := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+		digest := sha256.Sum256([]byte(signingInput))
+
+		var sig []byte
+		switch key := rawKey.(type) {
+		case *rsa.PrivateKey:
+			// PKCS #1 v1.5 signatures are deterministic.
+			sig, err = rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+		case *ecdsa.PrivateKey:
+			sig, err = signECDSADeterministic(key, digest[:])
+		}
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to sign token: %w", err)
+		}
+
+		return cty.StringVal(signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)), nil
+	},
+})
+
 // Sha1Func constructs a function that computes the SHA1 hash of a given string
 // and encodes it with hexadecimal digits.
 var Sha1Func = makeStringHashFunction(sha1.New, hex.EncodeToString)
@@ -243,6 +356,51 @@ func makeStringHashFunction(hf func() hash.Hash, enc func([]byte) string) functi
 	})
 }
 
+func makeStringHmacFunction(hf func() hash.Hash, enc func([]byte) string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "key",
+				Type: cty.String,
+			},
+			{
+				Name: "str",
+				Type: cty.String,
+			},
+		},
+		Type:         function.StaticReturnType(cty.String),
+		RefineResult: refineNotNull,
+		Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+			key := args[0].AsString()
+			s := args[1].AsString()
+			h := hmac.New(hf, []byte(key))
+			h.Write([]byte(s))
+			rv := enc(h.Sum(nil))
+			return cty.StringVal(rv), nil
+		},
+	})
+}
+
+// parsePrivateKey parses a PEM-encoded private key, returning an error for
+// the argument at the given index if it is invalid. The error never
+// includes the key itself.
+func parsePrivateKey(key string, argIdx int) (interface{}, error) {
+	rawKey, err := ssh.ParseRawPrivateKey([]byte(key))
+	if err != nil {
+		var errStr string
+		switch e := err.(type) {
+		case asn1.SyntaxError:
+			errStr = strings.ReplaceAll(e.Error(), "asn1: syntax error", "invalid ASN1 data in the given private key")
+		case asn1.StructuralError:
+			errStr = strings.ReplaceAll(e.Error(), "asn1: structure error", "invalid ASN1 data in the given private key")
+		default:
+			errStr = fmt.Sprintf("invalid private key: %s", e)
+		}
+		return nil, function.NewArgErrorf(argIdx, errStr)
+	}
+	return rawKey, nil
+}
+
 func makeFileHashFunction(baseDir string, hf func() hash.Hash, enc func([]byte) string) function.Function {
 	return function.New(&function.Spec{
 		Params: []function.Parameter{
@@ -343,3 +501,27 @@ func Sha256(str cty.Value) (cty.Value, error) {
 func Sha512(str cty.Value) (cty.Value, error) {
 	return Sha512Func.Call([]cty.Value{str})
 }
+
+// HmacSha256 computes the HMAC-SHA256 of a given string with a given key and
+// encodes it with hexadecimal digits.
+func HmacSha256(key, str cty.Value) (cty.Value, error) {
+	return HmacSha256Func.Call([]cty.Value{key, str})
+}
+
+// HmacSha512 computes the HMAC-SHA512 of a given string with a given key and
+// encodes it with hexadecimal digits.
+func HmacSha512(key, str cty.Value) (cty.Value, error) {
+	return HmacSha512Func.Call([]cty.Value{key, str})
+}
+
+// Hkdf derives a key of the given length in bytes from a secret, salt and
+// info string using HKDF-SHA256, and encodes it with Base64.
+func Hkdf(secret, salt, info, length cty.Value) (cty.Value, error) {
+	return HkdfFunc.Call([]cty.Value{secret, salt, info, length})
+}
+
+// JWTSign encodes the given claims as a JSON Web Token signed with the given
+// PEM-encoded RSA or ECDSA P-256 private key.
+func JWTSign(claims, privatekey cty.Value) (cty.Value, error) {
+	return JWTSignFunc.Call([]cty.Value{claims, privatekey})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"math/big"
+)
+
+// signECDSADeterministic signs the given SHA-256 digest with a P-256 key,
+// returning r and s as 32-byte big-endian integers, as used by JSON Web
+// Signatures.
+//
+// The standard library generates a random nonce for each signature, which
+// would make the result of a function different every time it is called.
+// The nonce is instead derived from the key and the digest as described in
+// RFC 6979, so that the same inputs always produce the same signature.
+func signECDSADeterministic(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("unsupported curve %s: only P-256 keys are supported", key.Curve.Params().Name)
+	}
+	q := key.Curve.Params().N
+	qlen := q.BitLen()
+	size := (qlen + 7) / 8
+
+	e := bits2int(digest, qlen)
+	nextNonce := rfc6979Nonces(key.D, digest, q, sha256.New)
+	for {
+		k := nextNonce()
+
+		// r is the x coordinate of k*G, which crypto/ecdh can compute for us
+		// as the public key of the private key k.
+		kPriv, err := ecdh.P256().NewPrivateKey(k.FillBytes(make([]byte, size)))
+		if err != nil {
+			return nil, err
+		}
+		point := kPriv.PublicKey().Bytes() // 0x04 || x || y
+		r := new(big.Int).SetBytes(point[1 : 1+size])
+		r.Mod(r, q)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k^-1 * (e + r*d) mod q
+		s := new(big.Int).Mul(r, key.D)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, q))
+		s.Mod(s, q)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig, nil
+	}
+}
+
+// rfc6979Nonces returns a function that generates the sequence of candidate
+// nonces for signing the given digest with the private key x, as described in
+// RFC 6979 section 3.2.
+func rfc6979Nonces(x *big.Int, digest []byte, q *big.Int, h func() hash.Hash) func() *big.Int {
+	qlen := q.BitLen()
+	rolen := (qlen + 7) / 8
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(h, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+
+	bx := x.FillBytes(make([]byte, rolen))
+	bh := bits2int(digest, qlen)
+	if bh.Cmp(q) >= 0 {
+		bh.Sub(bh, q)
+	}
+	bho := bh.FillBytes(make([]byte, rolen))
+
+	hlen := h().Size()
+	v := bytes.Repeat([]byte{0x01}, hlen)
+	k := make([]byte, hlen)
+	k = mac(k, v, []byte{0x00}, bx, bho)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, bx, bho)
+	v = mac(k, v)
+
+	started := false
+	return func() *big.Int {
+		for {
+			if started {
+				k = mac(k, v, []byte{0x00})
+				v = mac(k, v)
+			}
+			started = true
+
+			var t []byte
+			for len(t) < rolen {
+				v = mac(k, v)
+				t = append(t, v...)
+			}
+			nonce := bits2int(t, qlen)
+			if nonce.Sign() > 0 && nonce.Cmp(q) < 0 {
+				return nonce
+			}
+		}
+	}
+}
+
+// bits2int converts the leftmost qlen bits of b to an integer, as described
+// in RFC 6979 section 2.3.2.
+func bits2int(b []byte, qlen int) *big.Int {
+	ret := new(big.Int).SetBytes(b)
+	if blen := len(b) * 8; blen > qlen {
+		ret.Rsh(ret, uint(blen-qlen))
+	}
+	return ret
+}
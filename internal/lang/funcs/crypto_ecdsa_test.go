@@ -0,0 +1,58 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// TestSignECDSADeterministic checks the signature against the test vector
+// for P-256 with SHA-256 and the message "sample" in RFC 6979 appendix A.2.5.
+func TestSignECDSADeterministic(t *testing.T) {
+	hexInt := func(s string) *big.Int {
+		i, ok := new(big.Int).SetString(s, 16)
+		if !ok {
+			t.Fatalf("invalid hex integer %q", s)
+		}
+		return i
+	}
+
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     hexInt("60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6"),
+			Y:     hexInt("7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299"),
+		},
+		D: hexInt("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"),
+	}
+	digest := sha256.Sum256([]byte("sample"))
+
+	q := key.Curve.Params().N
+	gotK := rfc6979Nonces(key.D, digest[:], q, sha256.New)()
+	if wantK := hexInt("A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60"); gotK.Cmp(wantK) != 0 {
+		t.Errorf("wrong nonce\ngot:  %X\nwant: %X", gotK, wantK)
+	}
+
+	sig, err := signECDSADeterministic(key, digest[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := hex.EncodeToString(sig)
+	want := "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716" + // r
+		"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8" // s
+	if got != want {
+		t.Errorf("wrong signature\ngot:  %s\nwant: %s", got, want)
+	}
+
+	if !ecdsa.Verify(&key.PublicKey, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+		t.Errorf("invalid signature")
+	}
+}
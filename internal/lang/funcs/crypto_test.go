@@ -6,11 +6,26 @@
 package funcs
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/zclconf/go-cty/cty"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
 )
 
 func TestUUID(t *testing.T) {
@@ -801,3 +816,302 @@ T8UYnFu6RzkixElTf2rseEav7rkdKkI3LAeIZy7B0HulKKsmqVQ7
 -----END RSA PRIVATE KEY-----
 `
 )
+
+func TestHmacSha256(t *testing.T) {
+	tests := []struct {
+		Key  cty.Value
+		Str  cty.Value
+		Want cty.Value
+	}{
+		{
+			cty.StringVal("key"),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"),
+		},
+		{
+			cty.StringVal(""),
+			cty.StringVal(""),
+			cty.StringVal("b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad"),
+		},
+		{
+			cty.StringVal("key").Mark(marks.Sensitive),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8").Mark(marks.Sensitive),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hmacsha256(%#v, %#v)", test.Key, test.Str), func(t *testing.T) {
+			got, err := HmacSha256(test.Key, test.Str)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHmacSha512(t *testing.T) {
+	tests := []struct {
+		Key  cty.Value
+		Str  cty.Value
+		Want cty.Value
+	}{
+		{
+			cty.StringVal("key"),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hmacsha512(%#v, %#v)", test.Key, test.Str), func(t *testing.T) {
+			got, err := HmacSha512(test.Key, test.Str)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHkdf(t *testing.T) {
+	tests := []struct {
+		Secret cty.Value
+		Salt   cty.Value
+		Info   cty.Value
+		Length cty.Value
+		Want   cty.Value
+		Err    string
+	}{
+		// RFC 5869 test case 3, with the output encoded with Base64
+		{
+			cty.StringVal(strings.Repeat("\x0b", 22)),
+			cty.StringVal(""),
+			cty.StringVal(""),
+			cty.NumberIntVal(42),
+			cty.StringVal(base64.StdEncoding.EncodeToString(mustDecodeHex(t, "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8"))),
+			"",
+		},
+		{
+			cty.StringVal("secret").Mark(marks.Sensitive),
+			cty.StringVal("salt"),
+			cty.StringVal("production"),
+			cty.NumberIntVal(16),
+			cty.StringVal("yqhzBBEldT+Cely+OCBzOg==").Mark(marks.Sensitive),
+			"",
+		},
+		{
+			cty.StringVal("secret"),
+			cty.StringVal("salt"),
+			cty.StringVal("info"),
+			cty.NumberIntVal(0),
+			cty.UnknownVal(cty.String),
+			"length must be between 1 and 8160 bytes",
+		},
+		{
+			cty.StringVal("secret"),
+			cty.StringVal("salt"),
+			cty.StringVal("info"),
+			cty.NumberFloatVal(1.5),
+			cty.UnknownVal(cty.String),
+			"value must be a whole number, between -9223372036854775808 and 9223372036854775807",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hkdf(%#v, %#v, %#v, %#v)", test.Secret, test.Salt, test.Info, test.Length), func(t *testing.T) {
+			got, err := Hkdf(test.Secret, test.Salt, test.Info, test.Length)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestJWTSign(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}))
+
+	claims := cty.ObjectVal(map[string]cty.Value{
+		"sub": cty.StringVal("bootstrap"),
+		"iat": cty.NumberIntVal(1700000000),
+	})
+
+	tests := map[string]struct {
+		Key     string
+		WantAlg string
+		Verify  func(t *testing.T, digest, sig []byte)
+	}{
+		"RS256": {
+			PrivateKey,
+			"RS256",
+			func(t *testing.T, digest, sig []byte) {
+				rawKey, err := ssh.ParseRawPrivateKey([]byte(PrivateKey))
+				if err != nil {
+					t.Fatal(err)
+				}
+				key := rawKey.(*rsa.PrivateKey)
+				if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest, sig); err != nil {
+					t.Errorf("invalid signature: %s", err)
+				}
+			},
+		},
+		"ES256": {
+			ecPEM,
+			"ES256",
+			func(t *testing.T, digest, sig []byte) {
+				if len(sig) != 64 {
+					t.Fatalf("wrong signature length %d; want 64", len(sig))
+				}
+				r := new(big.Int).SetBytes(sig[:32])
+				s := new(big.Int).SetBytes(sig[32:])
+				if !ecdsa.Verify(&ecKey.PublicKey, digest, r, s) {
+					t.Errorf("invalid signature")
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := JWTSign(claims, cty.StringVal(test.Key))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// The signature must be the same every time, so that it
+			// doesn't cause perpetual diffs.
+			again, err := JWTSign(claims, cty.StringVal(test.Key))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(again) {
+				t.Fatalf("result is not deterministic\nfirst:  %s\nsecond: %s", got.AsString(), again.AsString())
+			}
+
+			parts := strings.Split(got.AsString(), ".")
+			if len(parts) != 3 {
+				t.Fatalf("wrong number of token parts %d; want 3", len(parts))
+			}
+			header, err := base64.RawURLEncoding.DecodeString(parts[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf(`{"alg":%q,"typ":"JWT"}`, test.WantAlg); string(header) != want {
+				t.Errorf("wrong header\ngot:  %s\nwant: %s", header, want)
+			}
+			payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := `{"iat":1700000000,"sub":"bootstrap"}`; string(payload) != want {
+				t.Errorf("wrong payload\ngot:  %s\nwant: %s", payload, want)
+			}
+			sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+			if err != nil {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			test.Verify(t, digest[:], sig)
+		})
+	}
+
+	t.Run("sensitive", func(t *testing.T) {
+		got, err := JWTSign(claims, cty.StringVal(PrivateKey).Mark(marks.Sensitive))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !got.HasMark(marks.Sensitive) {
+			t.Errorf("result is not sensitive")
+		}
+	})
+
+	t.Run("unknown claims", func(t *testing.T) {
+		got, err := JWTSign(cty.ObjectVal(map[string]cty.Value{
+			"sub": cty.UnknownVal(cty.String),
+		}), cty.StringVal(PrivateKey))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := cty.UnknownVal(cty.String).RefineNotNull(); !got.RawEquals(want) {
+			t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+		}
+	})
+
+	errTests := map[string]struct {
+		Claims cty.Value
+		Key    string
+		Err    string
+	}{
+		"invalid claims": {
+			cty.StringVal("sub"),
+			PrivateKey,
+			"claims must be an object",
+		},
+		"bad key": {
+			claims,
+			BadPrivateKey,
+			"invalid ASN1 data in the given private key: data truncated",
+		},
+		"unsupported curve": {
+			claims,
+			func() string {
+				key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+				if err != nil {
+					t.Fatal(err)
+				}
+				der, err := x509.MarshalECPrivateKey(key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+			}(),
+			"failed to sign token: unsupported curve P-384: only P-256 keys are supported",
+		},
+	}
+	for name, test := range errTests {
+		t.Run(name, func(t *testing.T) {
+			_, err := JWTSign(test.Claims, cty.StringVal(test.Key))
+			if err == nil {
+				t.Fatal("succeeded; want error")
+			} else if err.Error() != test.Err {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+			}
+		})
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -6,11 +6,17 @@
 package funcs
 
 import (
+	"archive/zip"
+	"bytes"
+	_ "embed"
 	"fmt"
+	"io/fs"
+	"sync"
 	"time"
 
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 // TimestampFunc constructs a function that returns a string representation of the current date and time.
@@ -99,6 +105,177 @@ var TimeCmpFunc = function.New(&function.Spec{
 	},
 })
 
+// ParseDateFunc constructs a function that parses a string in the format
+// described by a formatdate format string, returning an RFC 3339 timestamp.
+var ParseDateFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "format",
+			Type: cty.String,
+		},
+		{
+			Name: "time",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		t, err := parseDate(args[0].AsString(), args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(t.Format(time.RFC3339)), nil
+	},
+})
+
+// TimezoneFunc constructs a function that converts a timestamp to the local
+// time of a time zone, returning a new timestamp for the same instant.
+var TimezoneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+		{
+			Name: "zone",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ts, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		loc, err := loadLocation(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		return cty.StringVal(ts.In(loc).Format(time.RFC3339)), nil
+	},
+})
+
+// TimeDiffFunc constructs a function that returns the duration between two
+// timestamps, in the same syntax that timeadd accepts.
+var TimeDiffFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp_a",
+			Type: cty.String,
+		},
+		{
+			Name: "timestamp_b",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		tsA, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		tsB, err := parseTimestamp(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		return cty.StringVal(tsA.Sub(tsB).String()), nil
+	},
+})
+
+// WeekdayFunc constructs a function that returns the English name of the day
+// of the week of a timestamp.
+var WeekdayFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ts, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		return cty.StringVal(ts.Weekday().String()), nil
+	},
+})
+
+// DateAddFunc constructs a function that adds a number of years, months and
+// days to a timestamp, returning a new timestamp.
+var DateAddFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+		{
+			Name: "years",
+			Type: cty.Number,
+		},
+		{
+			Name: "months",
+			Type: cty.Number,
+		},
+		{
+			Name: "days",
+			Type: cty.Number,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ts, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		var n [3]int
+		for i := range n {
+			if err := gocty.FromCtyValue(args[i+1], &n[i]); err != nil {
+				return cty.UnknownVal(cty.String), function.NewArgErrorf(i+1, "must be a whole number")
+			}
+		}
+		return cty.StringVal(ts.AddDate(n[0], n[1], n[2]).Format(time.RFC3339)), nil
+	},
+})
+
+// DaysInMonthFunc constructs a function that returns the number of days in a
+// month of a particular year.
+var DaysInMonthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "year",
+			Type: cty.Number,
+		},
+		{
+			Name: "month",
+			Type: cty.Number,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Number),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var year, month int
+		if err := gocty.FromCtyValue(args[0], &year); err != nil {
+			return cty.UnknownVal(cty.Number), function.NewArgErrorf(0, "must be a whole number")
+		}
+		if err := gocty.FromCtyValue(args[1], &month); err != nil {
+			return cty.UnknownVal(cty.Number), function.NewArgErrorf(1, "must be a whole number")
+		}
+		if month < 1 || month > 12 {
+			return cty.UnknownVal(cty.Number), function.NewArgErrorf(1, "must be between 1 and 12")
+		}
+		// Day zero of the following month normalizes to the last day of
+		// the requested month.
+		last := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC)
+		return cty.NumberIntVal(int64(last.Day())), nil
+	},
+})
+
 // Timestamp returns a string representation of the current date and time.
 //
 // In the OpenTofu language, timestamps are conventionally represented as
@@ -142,6 +319,89 @@ func TimeCmp(timestampA, timestampB cty.Value) (cty.Value, error) {
 	return TimeCmpFunc.Call([]cty.Value{timestampA, timestampB})
 }
 
+// ParseDate parses a string in the format described by a format string,
+// returning the same instant as an RFC 3339 timestamp.
+//
+// The format string uses the same syntax as formatdate, so a string produced
+// by formatdate can be parsed back with the same format string. If the format
+// does not include a time zone offset then the time is taken to be in UTC.
+func ParseDate(format, str cty.Value) (cty.Value, error) {
+	return ParseDateFunc.Call([]cty.Value{format, str})
+}
+
+// Timezone converts a timestamp to the local time of a time zone from the
+// IANA time zone database, like "Europe/Berlin".
+//
+// The result represents the same instant as the given timestamp, but with
+// the UTC offset that the zone observed at that instant.
+func Timezone(timestamp, zone cty.Value) (cty.Value, error) {
+	return TimezoneFunc.Call([]cty.Value{timestamp, zone})
+}
+
+// TimeDiff returns the duration from timestampB to timestampA, as a string
+// that TimeAdd accepts, like "1h30m0s". The duration is negative if
+// timestampA is earlier than timestampB.
+func TimeDiff(timestampA, timestampB cty.Value) (cty.Value, error) {
+	return TimeDiffFunc.Call([]cty.Value{timestampA, timestampB})
+}
+
+// Weekday returns the English name of the day of the week of a timestamp,
+// like "Monday", taking into account the UTC offset of the timestamp.
+func Weekday(timestamp cty.Value) (cty.Value, error) {
+	return WeekdayFunc.Call([]cty.Value{timestamp})
+}
+
+// DateAdd adds a number of calendar years, months and days to a timestamp,
+// keeping the time of day and UTC offset of the timestamp.
+//
+// Dates that do not exist are normalized in the same way as by Go's
+// time.AddDate, so adding one month to October 31 produces December 1.
+func DateAdd(timestamp, years, months, days cty.Value) (cty.Value, error) {
+	return DateAddFunc.Call([]cty.Value{timestamp, years, months, days})
+}
+
+// DaysInMonth returns the number of days in a month of a particular year,
+// taking leap years into account.
+func DaysInMonth(year, month cty.Value) (cty.Value, error) {
+	return DaysInMonthFunc.Call([]cty.Value{year, month})
+}
+
+// zoneinfoZip is the IANA time zone database, copied from lib/time/zoneinfo.zip
+// of the Go distribution. Time zones are only loaded from it and never from
+// the system or the ZONEINFO environment variable, because the functions using
+// them would otherwise return different results on different systems.
+//
+//go:embed tzdata/zoneinfo.zip
+var zoneinfoZip []byte
+
+var zoneinfo = sync.OnceValues(func() (*zip.Reader, error) {
+	return zip.NewReader(bytes.NewReader(zoneinfoZip), int64(len(zoneinfoZip)))
+})
+
+// loadLocation loads a time zone by its name in the embedded IANA time zone
+// database.
+//
+// The system's local time zone is not accepted, because a function using it
+// would return different results on different systems.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("must be the name of a time zone, like \"Europe/Berlin\" or \"UTC\"")
+	}
+	zr, err := zoneinfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the time zone database: %w", err)
+	}
+	data, err := fs.ReadFile(zr, name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
 func parseTimestamp(ts string) (time.Time, error) {
 	t, err := time.Parse(time.RFC3339, ts)
 	if err != nil {
@@ -0,0 +1,310 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// parseDate parses str according to a format string in the syntax of the
+// formatdate function, which is the inverse of the formatting it performs.
+//
+// Errors about the format string are reported against the first argument of
+// the parsedate function, and errors about the string against the second.
+func parseDate(format, str string) (time.Time, error) {
+	tokens, err := splitDateFormatTokens(format)
+	if err != nil {
+		return time.Time{}, function.NewArgError(0, err)
+	}
+
+	year, month, day := 1970, 1, 1
+	var hour, minute, second int
+	var hour12, pm, hasMeridiem bool
+	weekday := -1
+	loc := time.UTC
+
+	rest := str
+	for _, tok := range tokens {
+		if tok.literal {
+			if !strings.HasPrefix(rest, tok.text) {
+				return time.Time{}, parseDateMismatch(rest, fmt.Sprintf("%q", tok.text))
+			}
+			rest = rest[len(tok.text):]
+			continue
+		}
+
+		// Verbs with a single letter accept one or two digits, while the
+		// others require exactly as many digits as there are letters.
+		digits := func(what string, lo, hi int) (int, error) {
+			i := 0
+			for i < len(rest) && i < 2 && isDigit(rest[i]) {
+				i++
+			}
+			if i == 0 || (len(tok.text) == 2 && i != 2) {
+				return 0, parseDateMismatch(rest, what)
+			}
+			n := atoiDigits(rest[:i])
+			if n < lo || n > hi {
+				return 0, function.NewArgErrorf(1, "cannot use %q as %s", rest[:i], what)
+			}
+			rest = rest[i:]
+			return n, nil
+		}
+
+		var err error
+		switch tok.text {
+		case "YYYY":
+			if len(rest) < 4 || !allDigits(rest[:4]) {
+				return time.Time{}, parseDateMismatch(rest, "year")
+			}
+			year = atoiDigits(rest[:4])
+			rest = rest[4:]
+		case "YY":
+			// As with Go's own parser, two-digit years from 69 onwards are
+			// in the twentieth century.
+			if len(rest) < 2 || !allDigits(rest[:2]) {
+				return time.Time{}, parseDateMismatch(rest, "year")
+			}
+			year = atoiDigits(rest[:2])
+			if year >= 69 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+			rest = rest[2:]
+		case "M", "MM":
+			month, err = digits("month", 1, 12)
+		case "MMM", "MMMM":
+			month, err = parseDateName(&rest, monthNames, len(tok.text) == 3, "month")
+			month++
+		case "D", "DD":
+			day, err = digits("day of month", 1, 31)
+		case "EEE", "EEEE":
+			weekday, err = parseDateName(&rest, weekdayNames, len(tok.text) == 3, "day of week")
+		case "h", "hh":
+			hour, err = digits("hour", 0, 23)
+		case "H", "HH":
+			hour, err = digits("hour", 1, 12)
+			hour12 = true
+		case "AA", "aa":
+			marker := strings.ToUpper(rest[:min(2, len(rest))])
+			if marker != "AM" && marker != "PM" {
+				return time.Time{}, parseDateMismatch(rest, "AM/PM marker")
+			}
+			pm = marker == "PM"
+			hasMeridiem = true
+			rest = rest[2:]
+		case "m", "mm":
+			minute, err = digits("minute", 0, 59)
+		case "s", "ss":
+			second, err = digits("second", 0, 59)
+		case "Z", "ZZZ", "ZZZZ", "ZZZZZ":
+			loc, err = parseDateOffset(&rest, tok.text)
+		default:
+			return time.Time{}, function.NewArgErrorf(0, "invalid date format verb %q", tok.text)
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	if rest != "" {
+		return time.Time{}, function.NewArgErrorf(1, "extra text %q after the end of the date", rest)
+	}
+
+	if hour12 {
+		if !hasMeridiem {
+			return time.Time{}, function.NewArgErrorf(0, "a 12-hour verb requires an AM/PM marker verb, AA or aa")
+		}
+		hour %= 12
+		if pm {
+			hour += 12
+		}
+	}
+
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, loc)
+	if t.Day() != day {
+		return time.Time{}, function.NewArgErrorf(1, "%s %d does not have %d days", time.Month(month), year, day)
+	}
+	if weekday >= 0 && int(t.Weekday()) != weekday {
+		return time.Time{}, function.NewArgErrorf(1, "%s is a %s, not a %s", t.Format("2006-01-02"), t.Weekday(), time.Weekday(weekday))
+	}
+	return t, nil
+}
+
+var monthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var weekdayNames = []string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+// parseDateName consumes one of the given English names from the start of
+// *rest, either in full or abbreviated to three letters, and returns its
+// index. The names are matched without regard to case.
+func parseDateName(rest *string, names []string, abbrev bool, what string) (int, error) {
+	for i, name := range names {
+		if abbrev {
+			name = name[:3]
+		}
+		if len(*rest) >= len(name) && strings.EqualFold((*rest)[:len(name)], name) {
+			*rest = (*rest)[len(name):]
+			return i, nil
+		}
+	}
+	return 0, parseDateMismatch(*rest, what)
+}
+
+// parseDateOffset consumes a UTC offset from the start of *rest in the form
+// that the given timezone verb produces.
+func parseDateOffset(rest *string, verb string) (*time.Location, error) {
+	switch {
+	case verb == "Z" && strings.HasPrefix(*rest, "Z"):
+		*rest = (*rest)[1:]
+		return time.UTC, nil
+	case verb == "ZZZ" && strings.HasPrefix(*rest, "UTC"):
+		*rest = (*rest)[3:]
+		return time.UTC, nil
+	}
+
+	// Only ZZZZ and ZZZ use the form without a colon, like "-0800".
+	colon := verb == "Z" || verb == "ZZZZZ"
+	width := 5
+	if colon {
+		width = 6
+	}
+	if len(*rest) < width {
+		return nil, parseDateMismatch(*rest, "UTC offset")
+	}
+	s := (*rest)[:width]
+	var sign, hh, mm string
+	if colon {
+		sign, hh, mm = s[:1], s[1:3], s[4:6]
+		if s[3] != ':' {
+			return nil, parseDateMismatch(*rest, "UTC offset")
+		}
+	} else {
+		sign, hh, mm = s[:1], s[1:3], s[3:5]
+	}
+	if (sign != "+" && sign != "-") || !allDigits(hh+mm) {
+		return nil, parseDateMismatch(*rest, "UTC offset")
+	}
+	h, m := atoiDigits(hh), atoiDigits(mm)
+	if h > 23 || m > 59 {
+		return nil, function.NewArgErrorf(1, "cannot use %q as UTC offset", s)
+	}
+	offset := h*3600 + m*60
+	if sign == "-" {
+		offset = -offset
+	}
+	*rest = (*rest)[width:]
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone("", offset), nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// atoiDigits converts a string of decimal digits, which the caller has
+// already checked with allDigits, to an integer.
+func atoiDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
+
+func parseDateMismatch(rest, what string) error {
+	if rest == "" {
+		return function.NewArgErrorf(1, "end of string where %s is expected", what)
+	}
+	return function.NewArgErrorf(1, "cannot parse %q as %s", rest, what)
+}
+
+type dateFormatToken struct {
+	text    string
+	literal bool
+}
+
+// splitDateFormatTokens splits a date format into its verbs and literal
+// text, in the same way as the formatdate function.
+func splitDateFormatTokens(format string) ([]dateFormatToken, error) {
+	const esc = '\''
+	isVerb := func(b byte) bool {
+		return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+	}
+
+	var tokens []dateFormatToken
+	literal := func(s string) {
+		if n := len(tokens); n > 0 && tokens[n-1].literal {
+			tokens[n-1].text += s
+			return
+		}
+		tokens = append(tokens, dateFormatToken{text: s, literal: true})
+	}
+
+	for i := 0; i < len(format); {
+		switch {
+		case format[i] == esc:
+			if i+1 < len(format) && format[i+1] == esc {
+				literal("'")
+				i += 2
+				continue
+			}
+			var buf strings.Builder
+			j := i + 1
+			for ; j < len(format); j++ {
+				if format[j] == esc {
+					if j+1 < len(format) && format[j+1] == esc {
+						buf.WriteByte(esc)
+						j++
+						continue
+					}
+					break
+				}
+				buf.WriteByte(format[j])
+			}
+			if j >= len(format) {
+				return nil, fmt.Errorf("unterminated literal '")
+			}
+			literal(buf.String())
+			i = j + 1
+		case isVerb(format[i]):
+			j := i + 1
+			for j < len(format) && format[j] == format[i] {
+				j++
+			}
+			tokens = append(tokens, dateFormatToken{text: format[i:j]})
+			i = j
+		default:
+			j := i + 1
+			for j < len(format) && format[j] != esc && !isVerb(format[j]) {
+				j++
+			}
+			literal(format[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
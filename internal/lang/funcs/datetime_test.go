@@ -6,11 +6,16 @@
 package funcs
 
 import (
+	"archive/zip"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
 )
 
 func TestTimestamp(t *testing.T) {
@@ -185,3 +190,537 @@ func TestTimeCmp(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		Format, Time cty.Value
+		Want         cty.Value
+		Err          string
+	}{
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2018-01-04"),
+			cty.StringVal("2018-01-04T00:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("DD MMM YYYY hh:mm ZZZ"),
+			cty.StringVal("04 Jan 2018 23:12 UTC"),
+			cty.StringVal("2018-01-04T23:12:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("EEEE, MMMM D, YYYY H:mmaa ZZZZZ"),
+			cty.StringVal("Thursday, January 4, 2018 11:12pm -08:00"),
+			cty.StringVal("2018-01-04T23:12:00-08:00"),
+			``,
+		},
+		{
+			cty.StringVal("HH:mm AA"),
+			cty.StringVal("12:30 AM"),
+			cty.StringVal("1970-01-01T00:30:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("D/M/YY h:m:s ZZZZ"),
+			cty.StringVal("1/2/03 4:5:6 +0530"),
+			cty.StringVal("2003-02-01T04:05:06+05:30"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD'T'hh:mm:ssZ"),
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("'o''clock' h"),
+			cty.StringVal("o'clock 7"),
+			cty.StringVal("1970-01-01T07:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2018-1-04"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`cannot parse "1-04" as month`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2018-13-04"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`cannot use "13" as month`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2023-02-29"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`February 2023 does not have 29 days`,
+		},
+		{
+			cty.StringVal("EEE YYYY-MM-DD"),
+			cty.StringVal("Mon 2018-01-04"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`2018-01-04 is a Thursday, not a Monday`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2018-01-04 00:00"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`extra text " 00:00" after the end of the date`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2018-01"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`end of string where "-" is expected`,
+		},
+		{
+			cty.StringVal("YYY"),
+			cty.StringVal("2018"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`invalid date format verb "YYY"`,
+		},
+		{
+			cty.StringVal("HH:mm"),
+			cty.StringVal("11:00"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`a 12-hour verb requires an AM/PM marker verb, AA or aa`,
+		},
+		{
+			cty.StringVal("'YYYY"),
+			cty.StringVal("YYYY"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`unterminated literal '`,
+		},
+		{
+			cty.StringVal("YYYY"),
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ParseDate(%#v, %#v)", test.Format, test.Time), func(t *testing.T) {
+			got, err := ParseDate(test.Format, test.Time)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Errorf("wrong error message\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestParseDate_formatDateRoundTrip(t *testing.T) {
+	// Every timestamp formatted by formatdate with a format that includes
+	// all of the parts of the timestamp must parse back to the same instant.
+	formats := []string{
+		"YYYY-MM-DD'T'hh:mm:ssZ",
+		"EEE, DD MMM YYYY HH:mm:ss aa ZZZZ",
+		"EEEE MMMM D YY h:m:s ZZZ",
+		"M/D/YYYY H:mm:ss AA ZZZZZ",
+	}
+	timestamps := []string{
+		"2018-01-04T23:12:01Z",
+		"2024-02-29T00:00:00+05:30",
+		"1999-12-31T12:59:59-08:00",
+		"2030-07-01T12:00:00Z",
+	}
+
+	for _, format := range formats {
+		for _, ts := range timestamps {
+			t.Run(fmt.Sprintf("%s %s", format, ts), func(t *testing.T) {
+				formatted, err := stdlib.FormatDate(cty.StringVal(format), cty.StringVal(ts))
+				if err != nil {
+					t.Fatalf("unexpected error formatting: %s", err)
+				}
+				got, err := ParseDate(cty.StringVal(format), formatted)
+				if err != nil {
+					t.Fatalf("unexpected error parsing %q: %s", formatted.AsString(), err)
+				}
+				cmp, err := TimeCmp(got, cty.StringVal(ts))
+				if err != nil {
+					t.Fatalf("unexpected error comparing: %s", err)
+				}
+				if !cmp.RawEquals(cty.Zero) {
+					t.Errorf("wrong result for %q\ngot:  %s\nwant: %s", formatted.AsString(), got.AsString(), ts)
+				}
+			})
+		}
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		Time, Zone cty.Value
+		Want       cty.Value
+		Err        string
+	}{
+		{
+			cty.StringVal("2024-07-01T12:00:00Z"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-07-01T14:00:00+02:00"),
+			``,
+		},
+		{ // standard time rather than daylight saving time
+			cty.StringVal("2024-01-01T12:00:00Z"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-01-01T13:00:00+01:00"),
+			``,
+		},
+		{
+			cty.StringVal("2024-03-10T06:59:59Z"),
+			cty.StringVal("America/New_York"),
+			cty.StringVal("2024-03-10T01:59:59-05:00"),
+			``,
+		},
+		{
+			cty.StringVal("2024-03-10T07:00:00Z"),
+			cty.StringVal("America/New_York"),
+			cty.StringVal("2024-03-10T03:00:00-04:00"),
+			``,
+		},
+		{
+			cty.StringVal("2024-07-01T14:00:00+02:00"),
+			cty.StringVal("UTC"),
+			cty.StringVal("2024-07-01T12:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("2024-07-01T12:00:00Z"),
+			cty.StringVal("Mars/Olympus_Mons"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`unknown time zone "Mars/Olympus_Mons"`,
+		},
+		{
+			cty.StringVal("2024-07-01T12:00:00Z"),
+			cty.StringVal("Local"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`must be the name of a time zone, like "Europe/Berlin" or "UTC"`,
+		},
+		{
+			cty.StringVal("2024-07-01"),
+			cty.StringVal("UTC"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`not a valid RFC3339 timestamp: missing required time introducer 'T'`,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.StringVal("UTC"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Timezone(%#v, %#v)", test.Time, test.Zone), func(t *testing.T) {
+			got, err := Timezone(test.Time, test.Zone)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Errorf("wrong error message\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestTimezone_zoneinfo(t *testing.T) {
+	// A bogus time zone database in which Europe/Berlin is Tokyo time must not
+	// change the result.
+	zr, err := zoneinfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := fs.ReadFile(zr, "Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "zoneinfo.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(tokyo); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ZONEINFO", path)
+
+	got, err := Timezone(cty.StringVal("2024-01-01T12:00:00Z"), cty.StringVal("Europe/Berlin"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.StringVal("2024-01-01T13:00:00+01:00")
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestTimeDiff(t *testing.T) {
+	tests := []struct {
+		TimeA, TimeB cty.Value
+		Want         cty.Value
+		Err          bool
+	}{
+		{
+			cty.StringVal("2017-11-22T01:30:00Z"),
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			cty.StringVal("1h30m0s"),
+			false,
+		},
+		{
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			cty.StringVal("2017-11-22T01:30:00Z"),
+			cty.StringVal("-1h30m0s"),
+			false,
+		},
+		{
+			cty.StringVal("2017-11-22T01:00:00+01:00"),
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			cty.StringVal("0s"),
+			false,
+		},
+		{
+			cty.StringVal("2017-11-22T00:00:00Z"),
+			cty.StringVal("bloop"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("TimeDiff(%#v, %#v)", test.TimeA, test.TimeB), func(t *testing.T) {
+			got, err := TimeDiff(test.TimeA, test.TimeB)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+
+			// The result must be usable with timeadd to get back from
+			// timestamp B to timestamp A.
+			sum, err := TimeAdd(test.TimeB, got)
+			if err != nil {
+				t.Fatalf("unexpected error from TimeAdd: %s", err)
+			}
+			if cmp, _ := TimeCmp(sum, test.TimeA); !cmp.RawEquals(cty.Zero) {
+				t.Errorf("TimeAdd(%#v, %#v) = %#v, want %#v", test.TimeB, got, sum, test.TimeA)
+			}
+		})
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	tests := []struct {
+		Time cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			cty.StringVal("2024-07-01T12:00:00Z"),
+			cty.StringVal("Monday"),
+			false,
+		},
+		{ // the day of the week depends on the UTC offset of the timestamp
+			cty.StringVal("2024-07-01T00:30:00+01:00"),
+			cty.StringVal("Monday"),
+			false,
+		},
+		{
+			cty.StringVal("2024-06-30T23:30:00Z"),
+			cty.StringVal("Sunday"),
+			false,
+		},
+		{
+			cty.StringVal("2024-07-01"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Weekday(%#v)", test.Time), func(t *testing.T) {
+			got, err := Weekday(test.Time)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestDateAdd(t *testing.T) {
+	tests := []struct {
+		Time                cty.Value
+		Years, Months, Days cty.Value
+		Want                cty.Value
+		Err                 string
+	}{
+		{
+			cty.StringVal("2024-02-29T09:00:00Z"),
+			cty.NumberIntVal(1),
+			cty.Zero,
+			cty.Zero,
+			cty.StringVal("2025-03-01T09:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("2024-10-31T09:00:00-05:00"),
+			cty.Zero,
+			cty.NumberIntVal(1),
+			cty.Zero,
+			cty.StringVal("2024-12-01T09:00:00-05:00"),
+			``,
+		},
+		{
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.Zero,
+			cty.NumberIntVal(-1),
+			cty.NumberIntVal(-15),
+			cty.StringVal("2023-11-30T09:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.Zero,
+			cty.Zero,
+			cty.NumberFloatVal(1.5),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			`must be a whole number`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("DateAdd(%#v, %#v, %#v, %#v)", test.Time, test.Years, test.Months, test.Days), func(t *testing.T) {
+			got, err := DateAdd(test.Time, test.Years, test.Months, test.Days)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Errorf("wrong error message\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		Year, Month cty.Value
+		Want        cty.Value
+		Err         string
+	}{
+		{
+			cty.NumberIntVal(2024),
+			cty.NumberIntVal(2),
+			cty.NumberIntVal(29),
+			``,
+		},
+		{
+			cty.NumberIntVal(1900),
+			cty.NumberIntVal(2),
+			cty.NumberIntVal(28),
+			``,
+		},
+		{
+			cty.NumberIntVal(2023),
+			cty.NumberIntVal(12),
+			cty.NumberIntVal(31),
+			``,
+		},
+		{
+			cty.NumberIntVal(2023),
+			cty.NumberIntVal(4),
+			cty.NumberIntVal(30),
+			``,
+		},
+		{
+			cty.NumberIntVal(2023),
+			cty.NumberIntVal(13),
+			cty.UnknownVal(cty.Number).RefineNotNull(),
+			`must be between 1 and 12`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("DaysInMonth(%#v, %#v)", test.Year, test.Month), func(t *testing.T) {
+			got, err := DaysInMonth(test.Year, test.Month)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Errorf("wrong error message\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -157,6 +157,22 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`csvdecode` decodes a string containing CSV-formatted data and produces a list of maps representing that data.",
 		ParamDescription: []string{""},
 	},
+	"dateadd": {
+		Description: "`dateadd` adds a number of calendar years, months and days to a timestamp, returning a new timestamp.",
+		ParamDescription: []string{
+			"",
+			"A whole number of years to add, which may be negative.",
+			"A whole number of months to add, which may be negative.",
+			"A whole number of days to add, which may be negative.",
+		},
+	},
+	"daysinmonth": {
+		Description: "`daysinmonth` returns the number of days in a month of a particular year, taking leap years into account.",
+		ParamDescription: []string{
+			"",
+			"The month number, from 1 for January to 12 for December.",
+		},
+	},
 	"dirname": {
 		Description:      "`dirname` takes a string containing a filesystem path and removes the last portion from it.",
 		ParamDescription: []string{""},
@@ -233,6 +249,23 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`formatlist` produces a list of strings by formatting a number of other values according to a specification string.",
 		ParamDescription: []string{"", ""},
 	},
+	"hkdf": {
+		Description: "`hkdf` derives a key from a secret using HKDF-SHA256, as defined in [RFC 5869](https://tools.ietf.org/html/rfc5869), and encodes it with Base64.",
+		ParamDescription: []string{
+			"The input keying material.",
+			"A non-secret random value, which may be empty.",
+			"Context information that makes the derived key specific to its use, which may be empty.",
+			"The length of the derived key, in bytes.",
+		},
+	},
+	"hmacsha256": {
+		Description:      "`hmacsha256` computes the HMAC-SHA256 of a given string with a given key and encodes it with hexadecimal digits.",
+		ParamDescription: []string{"", ""},
+	},
+	"hmacsha512": {
+		Description:      "`hmacsha512` computes the HMAC-SHA512 of a given string with a given key and encodes it with hexadecimal digits.",
+		ParamDescription: []string{"", ""},
+	},
 	"indent": {
 		Description: "`indent` adds a given number of spaces to the beginnings of all but the first line in a given multi-line string.",
 		ParamDescription: []string{
@@ -263,6 +296,13 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`jsonencode` encodes a given value to a string using JSON syntax.",
 		ParamDescription: []string{""},
 	},
+	"jwtsign": {
+		Description: "`jwtsign` encodes the given claims as a JSON Web Token, signed with RS256 for an RSA private key or ES256 for an ECDSA P-256 private key.",
+		ParamDescription: []string{
+			"An object containing the claims of the token.",
+			"The PEM-encoded private key to sign the token with.",
+		},
+	},
 	"keys": {
 		Description: "`keys` takes a map and returns a list containing the keys from that map.",
 		ParamDescription: []string{
@@ -321,6 +361,13 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`one` takes a list, set, or tuple value with either zero or one elements. If the collection is empty, `one` returns `null`. Otherwise, `one` returns the first element. If there are two or more elements then `one` will return an error.",
 		ParamDescription: []string{""},
 	},
+	"parsedate": {
+		Description: "`parsedate` parses a string in a given format into a timestamp in [RFC 3339](https://tools.ietf.org/html/rfc3339) format.",
+		ParamDescription: []string{
+			"A format string in the same syntax as for `formatdate`.",
+			"",
+		},
+	},
 	"parseint": {
 		Description:      "`parseint` parses the given string as a representation of an integer in the specified base and returns the resulting number. The base must be between 2 and 62 inclusive.",
 		ParamDescription: []string{"", ""},
@@ -451,10 +498,21 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`timecmp` compares two timestamps and returns a number that represents the ordering of the instants those timestamps represent.",
 		ParamDescription: []string{"", ""},
 	},
+	"timediff": {
+		Description:      "`timediff` returns the duration between two timestamps, in the syntax accepted by `timeadd`.",
+		ParamDescription: []string{"", ""},
+	},
 	"timestamp": {
 		Description:      "`timestamp` returns a UTC timestamp string in [RFC 3339](https://tools.ietf.org/html/rfc3339) format.",
 		ParamDescription: []string{},
 	},
+	"timezone": {
+		Description: "`timezone` converts a timestamp to the local time of a given time zone, returning a new timestamp that represents the same instant.",
+		ParamDescription: []string{
+			"",
+			"The name of a time zone in the IANA time zone database, like `Europe/Berlin`.",
+		},
+	},
 	"plantimestamp": {
 		Description:      "`plantimestamp` returns a UTC timestamp string in [RFC 3339](https://tools.ietf.org/html/rfc3339) format, fixed to a constant time representing the time of the plan.",
 		ParamDescription: []string{},
@@ -542,6 +600,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`values` takes a map and returns a list containing the values of the elements in that map.",
 		ParamDescription: []string{""},
 	},
+	"weekday": {
+		Description:      "`weekday` returns the English name of the day of the week of a timestamp.",
+		ParamDescription: []string{""},
+	},
 	"yamldecode": {
 		Description:      "`yamldecode` parses a string as a subset of YAML, and produces a representation of its value.",
 		ParamDescription: []string{""},
@@ -554,6 +616,18 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`zipmap` constructs a map from a list of keys and a corresponding list of values.",
 		ParamDescription: []string{"", ""},
 	},
+	"tofu::decode_tfvars": {
+		Description:      "`tofu::decode_tfvars` parses a string containing the contents of a `.tfvars` file and produces an object with one attribute per variable.",
+		ParamDescription: []string{""},
+	},
+	"tofu::encode_expr": {
+		Description:      "`tofu::encode_expr` encodes a given value as an OpenTofu language expression that would produce the same value.",
+		ParamDescription: []string{""},
+	},
+	"tofu::encode_tfvars": {
+		Description:      "`tofu::encode_tfvars` encodes a given object as the contents of a `.tfvars` file, with one variable per attribute.",
+		ParamDescription: []string{""},
+	},
 }
 
 // WithDescription looks up the description for a given function and uses
@@ -15,6 +15,9 @@ import (
 	"net/url"
 	"unicode/utf8"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"golang.org/x/text/encoding/ianaindex"
@@ -247,6 +250,134 @@ var URLDecodeFunc = function.New(&function.Spec{
 	},
 })
 
+// EncodeTfvarsFunc constructs a function that encodes an object as the
+// contents of a .tfvars file.
+var EncodeTfvarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowDynamicType: true,
+			AllowUnknown:     true,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		obj := args[0]
+		if obj.IsNull() {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "cannot encode a null value in tfvars syntax")
+		}
+		if !obj.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+
+		ty := obj.Type()
+		if !ty.IsObjectType() && !ty.IsMapType() {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "invalid value to encode: must be an object whose attribute names will become the encoded variable names")
+		}
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		for it := obj.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			name := k.AsString()
+			if !hclsyntax.ValidIdentifier(name) {
+				return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "invalid variable name %q: must be a valid identifier, per OpenTofu's rules for input variable declarations", name)
+			}
+			body.SetAttributeValue(name, v)
+		}
+		return cty.StringVal(string(f.Bytes())), nil
+	},
+})
+
+// DecodeTfvarsFunc constructs a function that decodes the contents of a
+// .tfvars file into an object.
+var DecodeTfvarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+		val, err := decodeTfvars(args[0].AsString())
+		if err != nil {
+			return cty.DynamicPseudoType, err
+		}
+		return val.Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return decodeTfvars(args[0].AsString())
+	},
+})
+
+func decodeTfvars(src string) (cty.Value, error) {
+	f, hclDiags := hclsyntax.ParseConfig([]byte(src), "<decode_tfvars argument>", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		return cty.DynamicVal, function.NewArgErrorf(0, "invalid tfvars syntax: %s", hclDiags.Error())
+	}
+	attrs, hclDiags := f.Body.JustAttributes()
+	if hclDiags.HasErrors() {
+		return cty.DynamicVal, function.NewArgErrorf(0, "invalid tfvars content: %s", hclDiags.Error())
+	}
+
+	// Like in .tfvars files, the values must be constant: there are no
+	// variables or functions available.
+	vals := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		val, hclDiags := attr.Expr.Value(nil)
+		if hclDiags.HasErrors() {
+			return cty.DynamicVal, function.NewArgErrorf(0, "invalid expression for variable %q: %s", name, hclDiags.Error())
+		}
+		vals[name] = val
+	}
+	return cty.ObjectVal(vals), nil
+}
+
+// EncodeExprFunc constructs a function that encodes a value as an HCL
+// expression.
+var EncodeExprFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowDynamicType: true,
+			AllowUnknown:     true,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v := args[0]
+		if !v.IsWhollyKnown() {
+			ret := cty.UnknownVal(retType).RefineNotNull()
+			// The HCL grammar tells us how the result will start for some
+			// types, as long as we know the value isn't null.
+			if !v.Range().CouldBeNull() {
+				ty := v.Type()
+				switch {
+				case ty.IsObjectType() || ty.IsMapType():
+					ret = ret.Refine().StringPrefixFull("{").NewValue()
+				case ty.IsTupleType() || ty.IsListType() || ty.IsSetType():
+					ret = ret.Refine().StringPrefixFull("[").NewValue()
+				case ty == cty.String:
+					ret = ret.Refine().StringPrefixFull(`"`).NewValue()
+				}
+			}
+			return ret, nil
+		}
+
+		src := bytes.TrimSpace(hclwrite.TokensForValue(v).Bytes())
+		return cty.StringVal(string(src)), nil
+	},
+})
+
 // Base64Decode decodes a string containing a base64 sequence.
 //
 // OpenTofu uses the "standard" Base64 alphabet as defined in RFC 4648 section 4.
@@ -333,3 +464,21 @@ func TextEncodeBase64(str, enc cty.Value) (cty.Value, error) {
 func TextDecodeBase64(str, enc cty.Value) (cty.Value, error) {
 	return TextDecodeBase64Func.Call([]cty.Value{str, enc})
 }
+
+// EncodeTfvars encodes an object as the contents of a .tfvars file, with
+// one attribute per variable.
+func EncodeTfvars(value cty.Value) (cty.Value, error) {
+	return EncodeTfvarsFunc.Call([]cty.Value{value})
+}
+
+// DecodeTfvars decodes the contents of a .tfvars file into an object with
+// one attribute per variable.
+func DecodeTfvars(src cty.Value) (cty.Value, error) {
+	return DecodeTfvarsFunc.Call([]cty.Value{src})
+}
+
+// EncodeExpr encodes a value as an HCL expression that would produce an
+// equal value.
+func EncodeExpr(value cty.Value) (cty.Value, error) {
+	return EncodeExprFunc.Call([]cty.Value{value})
+}
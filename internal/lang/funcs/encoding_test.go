@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"testing"
 
+	ctyyaml "github.com/zclconf/go-cty-yaml"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/lang/marks"
@@ -531,3 +532,288 @@ func TestBase64TextDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeTfvars(t *testing.T) {
+	tests := []struct {
+		Value cty.Value
+		Want  cty.Value
+		Err   string
+	}{
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"string": cty.StringVal("hello"),
+				"number": cty.NumberIntVal(5),
+				"list":   cty.ListVal([]cty.Value{cty.True, cty.False}),
+				"object": cty.ObjectVal(map[string]cty.Value{
+					"nested": cty.NullVal(cty.String),
+				}),
+			}),
+			cty.StringVal(`list   = [true, false]
+number = 5
+object = {
+  nested = null
+}
+string = "hello"
+`),
+			``,
+		},
+		{
+			cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("b"),
+			}),
+			cty.StringVal("a = \"b\"\n"),
+			``,
+		},
+		{
+			cty.EmptyObjectVal,
+			cty.StringVal(""),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b").Mark(marks.Sensitive),
+			}),
+			cty.StringVal("a = \"b\"\n").Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.NullVal(cty.EmptyObject),
+			cty.NilVal,
+			`cannot encode a null value in tfvars syntax`,
+		},
+		{
+			cty.StringVal("a"),
+			cty.NilVal,
+			`invalid value to encode: must be an object whose attribute names will become the encoded variable names`,
+		},
+		{
+			cty.MapVal(map[string]cty.Value{
+				"not valid": cty.StringVal("b"),
+			}),
+			cty.NilVal,
+			`invalid variable name "not valid": must be a valid identifier, per OpenTofu's rules for input variable declarations`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("encode_tfvars(%#v)", test.Value), func(t *testing.T) {
+			got, err := EncodeTfvars(test.Value)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestDecodeTfvars(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("a = \"b\"\nc = {\n  d = [1, true]\n}\n"),
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b"),
+				"c": cty.ObjectVal(map[string]cty.Value{
+					"d": cty.TupleVal([]cty.Value{cty.NumberIntVal(1), cty.True}),
+				}),
+			}),
+			``,
+		},
+		{
+			cty.StringVal(""),
+			cty.EmptyObjectVal,
+			``,
+		},
+		{
+			cty.StringVal("a = \"b\"").Mark(marks.Sensitive),
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b"),
+			}).Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.DynamicVal,
+			``,
+		},
+		{
+			cty.StringVal("a = "),
+			cty.NilVal,
+			`invalid tfvars syntax: <decode_tfvars argument>:1,5-5: Missing expression; Expected the start of an expression, but found the end of the file.`,
+		},
+		{
+			cty.StringVal("a {\n}\n"),
+			cty.NilVal,
+			`invalid tfvars content: <decode_tfvars argument>:1,1-2: Unexpected "a" block; Blocks are not allowed here.`,
+		},
+		{
+			cty.StringVal("a = var.b"),
+			cty.NilVal,
+			`invalid expression for variable "a": <decode_tfvars argument>:1,5-8: Variables not allowed; Variables may not be used here.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("decode_tfvars(%#v)", test.Src), func(t *testing.T) {
+			got, err := DecodeTfvars(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestEncodeExpr(t *testing.T) {
+	tests := []struct {
+		Value cty.Value
+		Want  cty.Value
+	}{
+		{
+			cty.StringVal("hello\n${world}"),
+			cty.StringVal(`"hello\n$${world}"`),
+		},
+		{
+			cty.NumberFloatVal(1.5),
+			cty.StringVal(`1.5`),
+		},
+		{
+			cty.NullVal(cty.String),
+			cty.StringVal(`null`),
+		},
+		{
+			cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			cty.StringVal(`["a", "b"]`),
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.True,
+			}),
+			cty.StringVal("{\n  a = true\n}"),
+		},
+		{
+			cty.StringVal("secret").Mark(marks.Sensitive),
+			cty.StringVal(`"secret"`).Mark(marks.Sensitive),
+		},
+		{
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			cty.UnknownVal(cty.String).Refine().NotNull().StringPrefixFull(`"`).NewValue(),
+		},
+		{
+			cty.UnknownVal(cty.List(cty.String)).RefineNotNull(),
+			cty.UnknownVal(cty.String).Refine().NotNull().StringPrefixFull(`[`).NewValue(),
+		},
+		{
+			cty.UnknownVal(cty.Number),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("encode_expr(%#v)", test.Value), func(t *testing.T) {
+			got, err := EncodeExpr(test.Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+// TestEncodeTfvars_yamlencodeParity checks that encode_tfvars and
+// decode_tfvars round-trip values like yamlencode and yamldecode do, and
+// that they treat sensitive and unknown values the same way.
+func TestEncodeTfvars_yamlencodeParity(t *testing.T) {
+	tests := map[string]cty.Value{
+		"primitives": cty.ObjectVal(map[string]cty.Value{
+			"string": cty.StringVal("hello \"world\"\n${x}"),
+			"number": cty.NumberFloatVal(-1.25),
+			"bool":   cty.True,
+		}),
+		"collections": cty.ObjectVal(map[string]cty.Value{
+			"list": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.NumberIntVal(1)}),
+			"map": cty.ObjectVal(map[string]cty.Value{
+				"key with spaces": cty.StringVal("b"),
+			}),
+		}),
+		"sensitive": cty.ObjectVal(map[string]cty.Value{
+			"a": cty.StringVal("secret").Mark(marks.Sensitive),
+		}),
+		"unknown": cty.ObjectVal(map[string]cty.Value{
+			"a": cty.UnknownVal(cty.String),
+		}),
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			yamlSrc, err := ctyyaml.YAMLEncodeFunc.Call([]cty.Value{value})
+			if err != nil {
+				t.Fatalf("unexpected yamlencode error: %s", err)
+			}
+			tfvarsSrc, err := EncodeTfvars(value)
+			if err != nil {
+				t.Fatalf("unexpected encode_tfvars error: %s", err)
+			}
+
+			if got, want := tfvarsSrc.IsKnown(), yamlSrc.IsKnown(); got != want {
+				t.Fatalf("encode_tfvars result known is %t, but yamlencode result known is %t", got, want)
+			}
+			if got, want := tfvarsSrc.HasMark(marks.Sensitive), yamlSrc.HasMark(marks.Sensitive); got != want {
+				t.Fatalf("encode_tfvars result sensitive is %t, but yamlencode result sensitive is %t", got, want)
+			}
+			if !tfvarsSrc.IsKnown() {
+				return
+			}
+
+			fromYAML, err := ctyyaml.YAMLDecodeFunc.Call([]cty.Value{yamlSrc})
+			if err != nil {
+				t.Fatalf("unexpected yamldecode error: %s", err)
+			}
+			fromTfvars, err := DecodeTfvars(tfvarsSrc)
+			if err != nil {
+				t.Fatalf("unexpected decode_tfvars error: %s", err)
+			}
+
+			if !fromTfvars.RawEquals(fromYAML) {
+				t.Errorf("encode_tfvars round trip differs from yamlencode round trip\ntfvars: %#v\nyaml:   %#v", fromTfvars, fromYAML)
+			}
+		})
+	}
+}
@@ -28,6 +28,13 @@ var impureFunctions = []string{
 // This should probably be replaced with addrs.Function everywhere
 const CoreNamespace = addrs.FunctionNamespaceCore + "::"
 
+// ModuleNamespace is the namespace of the user-defined functions of a module.
+const ModuleNamespace = addrs.FunctionNamespaceModule + "::"
+
+// TofuNamespace is the namespace of the built-in functions that are only
+// available with a namespace.
+const TofuNamespace = addrs.FunctionNamespaceTofu + "::"
+
 // Functions returns the set of functions that should be used to when evaluating
 // expressions in the receiving scope.
 func (s *Scope) Functions() map[string]function.Function {
@@ -67,6 +74,8 @@ func (s *Scope) Functions() map[string]function.Function {
 			"concat":           stdlib.ConcatFunc,
 			"contains":         stdlib.ContainsFunc,
 			"csvdecode":        stdlib.CSVDecodeFunc,
+			"dateadd":          funcs.DateAddFunc,
+			"daysinmonth":      funcs.DaysInMonthFunc,
 			"dirname":          funcs.DirnameFunc,
 			"distinct":         stdlib.DistinctFunc,
 			"element":          stdlib.ElementFunc,
@@ -87,11 +96,15 @@ func (s *Scope) Functions() map[string]function.Function {
 			"format":           stdlib.FormatFunc,
 			"formatdate":       stdlib.FormatDateFunc,
 			"formatlist":       stdlib.FormatListFunc,
+			"hkdf":             funcs.HkdfFunc,
+			"hmacsha256":       funcs.HmacSha256Func,
+			"hmacsha512":       funcs.HmacSha512Func,
 			"indent":           stdlib.IndentFunc,
 			"index":            funcs.IndexFunc, // stdlib.IndexFunc is not compatible
 			"join":             stdlib.JoinFunc,
 			"jsondecode":       stdlib.JSONDecodeFunc,
 			"jsonencode":       stdlib.JSONEncodeFunc,
+			"jwtsign":          funcs.JWTSignFunc,
 			"keys":             stdlib.KeysFunc,
 			"length":           funcs.LengthFunc,
 			"list":             funcs.ListFunc,
@@ -105,6 +118,7 @@ func (s *Scope) Functions() map[string]function.Function {
 			"merge":            stdlib.MergeFunc,
 			"min":              stdlib.MinFunc,
 			"one":              funcs.OneFunc,
+			"parsedate":        funcs.ParseDateFunc,
 			"parseint":         stdlib.ParseIntFunc,
 			"pathexpand":       funcs.PathExpandFunc,
 			"pow":              stdlib.PowFunc,
@@ -138,6 +152,8 @@ func (s *Scope) Functions() map[string]function.Function {
 			"timestamp":        funcs.TimestampFunc,
 			"timeadd":          stdlib.TimeAddFunc,
 			"timecmp":          funcs.TimeCmpFunc,
+			"timediff":         funcs.TimeDiffFunc,
+			"timezone":         funcs.TimezoneFunc,
 			"title":            stdlib.TitleFunc,
 			"tostring":         funcs.MakeToFunc(cty.String),
 			"tonumber":         funcs.MakeToFunc(cty.Number),
@@ -157,6 +173,7 @@ func (s *Scope) Functions() map[string]function.Function {
 			"uuid":             funcs.UUIDFunc,
 			"uuidv5":           funcs.UUIDV5Func,
 			"values":           stdlib.ValuesFunc,
+			"weekday":          funcs.WeekdayFunc,
 			"yamldecode":       ctyyaml.YAMLDecodeFunc,
 			"yamlencode":       ctyyaml.YAMLEncodeFunc,
 			"zipmap":           stdlib.ZipmapFunc,
@@ -206,6 +223,25 @@ func (s *Scope) Functions() map[string]function.Function {
 		for _, name := range coreNames {
 			s.funcs[CoreNamespace+name] = s.funcs[name]
 		}
+
+		// These functions are only available in the tofu:: namespace, so
+		// that they can't conflict with the names of existing functions.
+		tofuFuncs := map[string]function.Function{
+			"decode_tfvars": funcs.DecodeTfvarsFunc,
+			"encode_expr":   funcs.EncodeExprFunc,
+			"encode_tfvars": funcs.EncodeTfvarsFunc,
+		}
+		for name, f := range tofuFuncs {
+			s.funcs[TofuNamespace+name] = funcs.WithDescription(TofuNamespace+name, f)
+		}
+
+		// User-defined functions can call any other function of the scope,
+		// including each other.
+		for name, fn := range s.ModuleFunctions {
+			s.funcs[ModuleNamespace+name] = fn(func() map[string]function.Function {
+				return s.funcs
+			})
+		}
 	}
 	s.funcsLock.Unlock()
 
@@ -21,8 +21,15 @@ func TestFunctionDescriptions(t *testing.T) {
 	allFunctions := scope.Functions()
 
 	// plantimestamp isn't available with ConsoleMode: true
-	// THis also includes the core:: prefixed functions
-	expectedFunctionCount := (len(funcs.DescriptionList) - 1) * 2
+	// THis also includes the core:: prefixed functions, except for the
+	// functions that are only available in the tofu:: namespace
+	tofuOnly := 0
+	for name := range funcs.DescriptionList {
+		if strings.HasPrefix(name, TofuNamespace) {
+			tofuOnly++
+		}
+	}
+	expectedFunctionCount := (len(funcs.DescriptionList)-tofuOnly-1)*2 + tofuOnly
 
 	if len(allFunctions) != expectedFunctionCount {
 		t.Errorf("DescriptionList length expected: %d, got %d", len(allFunctions), expectedFunctionCount)
@@ -86,6 +86,9 @@ func (a *Analyzer) ReferencesFromResourceRepetition(addr addrs.AbsResource) []Re
 	case rc.Count != nil:
 		refs, _ := lang.ReferencesInExpr(addrs.ParseRef, rc.Count)
 		return absoluteRefs(addr.Module, refs)
+	case rc.Enabled != nil:
+		refs, _ := lang.ReferencesInExpr(addrs.ParseRef, rc.Enabled)
+		return absoluteRefs(addr.Module, refs)
 	default:
 		return nil
 	}
@@ -73,10 +73,19 @@ type Scope struct {
 	PlanTimestamp time.Time
 
 	ProviderFunctions ProviderFunction
+
+	// ModuleFunctions are the user-defined functions of the module, by name
+	// without the "module::" namespace.
+	ModuleFunctions map[string]ModuleFunction
 }
 
 type ProviderFunction func(addrs.ProviderFunction, tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics)
 
+// ModuleFunction returns the implementation of a user-defined function. The
+// function may call the functions returned by funcs, which are all the
+// functions of the scope.
+type ModuleFunction func(funcs func() map[string]function.Function) function.Function
+
 // SetActiveExperiments allows a caller to declare that a set of experiments
 // is active for the module that the receiving Scope belongs to, which might
 // then cause the scope to activate some additional experimental behaviors.
@@ -362,6 +362,18 @@ func (p *MockProvider) ReadDataSource(r providers.ReadDataSourceRequest) provide
 	return p.ReadDataSourceResponse
 }
 
+func (p *MockProvider) OpenEphemeralResource(providers.OpenEphemeralResourceRequest) providers.OpenEphemeralResourceResponse {
+	panic("Not Implemented")
+}
+
+func (p *MockProvider) RenewEphemeralResource(providers.RenewEphemeralResourceRequest) providers.RenewEphemeralResourceResponse {
+	panic("Not Implemented")
+}
+
+func (p *MockProvider) CloseEphemeralResource(providers.CloseEphemeralResourceRequest) providers.CloseEphemeralResourceResponse {
+	panic("Not Implemented")
+}
+
 func (p *MockProvider) GetFunctions() providers.GetFunctionsResponse {
 	panic("Not Implemented")
 }
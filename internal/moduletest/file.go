@@ -6,6 +6,10 @@
 package moduletest
 
 import (
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -18,5 +22,19 @@ type File struct {
 
 	Runs []*Run
 
+	// Duration records how long the file took to execute, including the
+	// clean up of any infrastructure created by its run blocks.
+	Duration time.Duration
+
 	Diagnostics tfdiags.Diagnostics
 }
+
+// GetRunReferences returns the names of the run blocks that the file level
+// variables refer to. These references apply to every run block in the file.
+func (file *File) GetRunReferences() []string {
+	var traversals []hcl.Traversal
+	for _, expr := range file.Config.Variables {
+		traversals = append(traversals, expr.Variables()...)
+	}
+	return runNamesFromTraversals(traversals)
+}
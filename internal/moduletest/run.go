@@ -7,6 +7,7 @@ package moduletest
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 
@@ -28,6 +29,10 @@ type Run struct {
 	Index  int
 	Status Status
 
+	// Duration records how long the run block took to execute. It is zero for
+	// run blocks that were skipped.
+	Duration time.Duration
+
 	Diagnostics tfdiags.Diagnostics
 }
 
@@ -110,6 +115,42 @@ func (run *Run) GetReferences() ([]*addrs.Reference, tfdiags.Diagnostics) {
 	return references, diagnostics
 }
 
+// GetRunReferences returns the names of the other run blocks that this run
+// block refers to, through either its variables or its assertions.
+//
+// Run blocks that refer to each other can't execute concurrently, as the
+// referenced run block must complete before its outputs are available.
+func (run *Run) GetRunReferences() []string {
+	var traversals []hcl.Traversal
+	for _, expr := range run.Config.Variables {
+		traversals = append(traversals, expr.Variables()...)
+	}
+	for _, rule := range run.Config.CheckRules {
+		traversals = append(traversals, rule.Condition.Variables()...)
+		traversals = append(traversals, rule.ErrorMessage.Variables()...)
+	}
+	return runNamesFromTraversals(traversals)
+}
+
+// runNamesFromTraversals returns the names of the run blocks referred to by
+// the given traversals, in the order they first appear.
+func runNamesFromTraversals(traversals []hcl.Traversal) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, traversal := range traversals {
+		if traversal.RootName() != "run" || len(traversal) < 2 {
+			continue
+		}
+		attr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok || seen[attr.Name] {
+			continue
+		}
+		seen[attr.Name] = true
+		names = append(names, attr.Name)
+	}
+	return names
+}
+
 // ValidateExpectedFailures steps through the provided diagnostics (which should
 // be the result of a plan or an apply operation), and does 3 things:
 //  1. Removes diagnostics that match the expected failures from the config.
@@ -802,3 +802,33 @@ func createDiagnostics(populate func(diags tfdiags.Diagnostics) tfdiags.Diagnost
 	diags = populate(diags)
 	return diags
 }
+
+func TestRun_GetRunReferences(t *testing.T) {
+	parse := func(src string) hcl.Expression {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tftest.hcl", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("failed to parse %q: %s", src, diags.Error())
+		}
+		return expr
+	}
+
+	run := Run{
+		Config: &configs.TestRun{
+			Variables: map[string]hcl.Expression{
+				"input": parse("run.setup.id"),
+			},
+			CheckRules: []*configs.CheckRule{
+				{
+					Condition:    parse("var.input == run.setup.id && output.id == run.other.id"),
+					ErrorMessage: parse("\"${run.last.id} is wrong\""),
+				},
+			},
+		},
+	}
+
+	got := run.GetRunReferences()
+	want := []string{"setup", "other", "last"}
+	if diff := cmp.Diff(want, got); len(diff) > 0 {
+		t.Errorf("wrong references\n%s", diff)
+	}
+}
@@ -42,7 +42,14 @@ type Plan struct {
 	// checked carefully against existing destroy behaviors.
 	UIMode Mode
 
-	VariableValues    map[string]DynamicValue
+	VariableValues map[string]DynamicValue
+
+	// ApplyTimeVariables is the names of any ephemeral root module input
+	// variables that were set when creating the plan. Values for these
+	// variables are never included in VariableValues and must instead be
+	// provided again when applying the plan.
+	ApplyTimeVariables []string
+
 	Changes           *Changes
 	DriftedResources  []*ResourceInstanceChangeSrc
 	TargetAddrs       []addrs.Targetable
@@ -247,6 +247,13 @@ func readTfplan(r io.Reader) (*plans.Plan, error) {
 		plan.VariableValues[name] = val
 	}
 
+	for _, name := range rawPlan.ApplyTimeVariables {
+		if _, exists := plan.VariableValues[name]; exists {
+			return nil, fmt.Errorf("plan contains a saved value for apply-time input variable %q", name)
+		}
+		plan.ApplyTimeVariables = append(plan.ApplyTimeVariables, name)
+	}
+
 	if rawBackend := rawPlan.Backend; rawBackend == nil {
 		return nil, fmt.Errorf("plan file has no backend settings; backend settings are required")
 	} else {
@@ -630,6 +637,15 @@ func writeTfplan(plan *plans.Plan, w io.Writer) error {
 		rawPlan.Variables[name] = valueToTfplan(val)
 	}
 
+	// Values for ephemeral variables must never be saved in the plan, so
+	// we record only their names to remind the applier to ask for them again.
+	for _, name := range plan.ApplyTimeVariables {
+		if _, exists := plan.VariableValues[name]; exists {
+			return fmt.Errorf("plan has a value for apply-time input variable %q, which must not be saved", name)
+		}
+		rawPlan.ApplyTimeVariables = append(rawPlan.ApplyTimeVariables, name)
+	}
+
 	if plan.Backend.Type == "" || plan.Backend.Config == nil {
 		// This suggests a bug in the code that created the plan, since it
 		// ought to always have a backend populated, even if it's the default
@@ -29,6 +29,7 @@ func TestTFPlanRoundTrip(t *testing.T) {
 		VariableValues: map[string]plans.DynamicValue{
 			"foo": mustNewDynamicValueStr("foo value"),
 		},
+		ApplyTimeVariables: []string{"token"},
 		Changes: &plans.Changes{
 			Outputs: []*plans.OutputChangeSrc{
 				{
@@ -318,6 +319,32 @@ func TestTFPlanRoundTrip(t *testing.T) {
 	}
 }
 
+// TestTFPlanApplyTimeVariableValue ensures that a value for an ephemeral
+// input variable can never be written to a plan file.
+func TestTFPlanApplyTimeVariableValue(t *testing.T) {
+	plan := &plans.Plan{
+		VariableValues: map[string]plans.DynamicValue{
+			"token": mustNewDynamicValueStr("secret"),
+		},
+		ApplyTimeVariables: []string{"token"},
+		Changes:            &plans.Changes{},
+		Backend: plans.Backend{
+			Type:      "local",
+			Config:    mustNewDynamicValue(cty.EmptyObjectVal, cty.EmptyObject),
+			Workspace: "default",
+		},
+	}
+
+	var buf bytes.Buffer
+	err := writeTfplan(plan, &buf)
+	if err == nil {
+		t.Fatal("succeeded; want error")
+	}
+	if got, want := err.Error(), `plan has a value for apply-time input variable "token", which must not be saved`; got != want {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
 func mustDynamicOutputValue(val string) plans.DynamicValue {
 	ret, err := plans.NewDynamicValue(cty.StringVal(val), cty.DynamicPseudoType)
 	if err != nil {
@@ -6,6 +6,8 @@
 package providers
 
 import (
+	"time"
+
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/configs/configschema"
@@ -77,6 +79,21 @@ type Interface interface {
 	// ReadDataSource returns the data source's current state.
 	ReadDataSource(ReadDataSourceRequest) ReadDataSourceResponse
 
+	// OpenEphemeralResource opens an ephemeral resource and returns its
+	// result object. Ephemeral resources are never persisted in the state
+	// or plan, and so OpenTofu calls this method afresh during each walk
+	// that needs the result.
+	OpenEphemeralResource(OpenEphemeralResourceRequest) OpenEphemeralResourceResponse
+
+	// RenewEphemeralResource extends the validity of a previously-opened
+	// ephemeral resource. OpenTofu calls this only if the provider requested
+	// it by returning a non-zero RenewAt time.
+	RenewEphemeralResource(RenewEphemeralResourceRequest) RenewEphemeralResourceResponse
+
+	// CloseEphemeralResource releases a previously-opened ephemeral
+	// resource once OpenTofu no longer needs its result.
+	CloseEphemeralResource(CloseEphemeralResourceRequest) CloseEphemeralResourceResponse
+
 	// GetFunctions returns a full list of functions defined in this provider.  It should be a super
 	// set of the functions returned in GetProviderSchema()
 	GetFunctions() GetFunctionsResponse
@@ -106,6 +123,10 @@ type GetProviderSchemaResponse struct {
 	// DataSources maps the data source name to that data source's schema.
 	DataSources map[string]Schema
 
+	// EphemeralResources maps the ephemeral resource type name to that
+	// type's schema.
+	EphemeralResources map[string]Schema
+
 	// Diagnostics contains any warnings or errors from the method call.
 	Diagnostics tfdiags.Diagnostics
 
@@ -478,6 +499,68 @@ type ReadDataSourceResponse struct {
 	Diagnostics tfdiags.Diagnostics
 }
 
+type OpenEphemeralResourceRequest struct {
+	// TypeName is the name of the ephemeral resource type to open.
+	TypeName string
+
+	// Config is the complete configuration for the requested ephemeral
+	// resource.
+	Config cty.Value
+}
+
+type OpenEphemeralResourceResponse struct {
+	// Result is the object produced by opening the ephemeral resource. It
+	// must conform to the ephemeral resource type's schema.
+	Result cty.Value
+
+	// Private is an opaque blob that will be passed back to the provider
+	// in any subsequent RenewEphemeralResource or CloseEphemeralResource
+	// call for the same object.
+	Private []byte
+
+	// RenewAt, if not zero, is the time at which OpenTofu should call
+	// RenewEphemeralResource if the object is still in use.
+	RenewAt time.Time
+
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
+type RenewEphemeralResourceRequest struct {
+	// TypeName is the name of the ephemeral resource type to renew.
+	TypeName string
+
+	// Private is the opaque blob most recently returned by the provider for
+	// this object.
+	Private []byte
+}
+
+type RenewEphemeralResourceResponse struct {
+	// RenewAt, if not zero, is the time at which OpenTofu should call
+	// RenewEphemeralResource again if the object is still in use.
+	RenewAt time.Time
+
+	// Private is an updated opaque blob to use for subsequent calls.
+	Private []byte
+
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
+type CloseEphemeralResourceRequest struct {
+	// TypeName is the name of the ephemeral resource type to close.
+	TypeName string
+
+	// Private is the opaque blob most recently returned by the provider for
+	// this object.
+	Private []byte
+}
+
+type CloseEphemeralResourceResponse struct {
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
 type GetFunctionsResponse struct {
 	Functions map[string]FunctionSpec
 
@@ -15,22 +15,34 @@ import (
 )
 
 type RemoveStatement struct {
-	From      addrs.ConfigRemovable
+	From addrs.ConfigRemovable
+
+	// Destroy is true if the objects matched by From should be destroyed,
+	// rather than only forgotten from the state.
+	Destroy bool
+
+	// Provisioners are the destroy-time provisioners to run before destroying
+	// each matched resource instance.
+	Provisioners []*configs.Provisioner
+
 	DeclRange tfdiags.SourceRange
 }
 
 // GetEndpointsToRemove recurses through the modules of the given configuration
-// and returns an array of all "removed" addresses within, in a
+// and returns an array of all "removed" statements within, in a
 // deterministic but undefined order.
 // We also validate that the removed modules/resources configuration blocks were removed.
-func GetEndpointsToRemove(rootCfg *configs.Config) ([]addrs.ConfigRemovable, tfdiags.Diagnostics) {
-	rm := findRemoveStatements(rootCfg, nil)
+func GetEndpointsToRemove(rootCfg *configs.Config) ([]*RemoveStatement, tfdiags.Diagnostics) {
+	rm := FindRemoveStatements(rootCfg)
 	diags := validateRemoveStatements(rootCfg, rm)
-	removedAddresses := make([]addrs.ConfigRemovable, len(rm))
-	for i, rs := range rm {
-		removedAddresses[i] = rs.From
-	}
-	return removedAddresses, diags
+	return rm, diags
+}
+
+// FindRemoveStatements recurses through the modules of the given configuration
+// and returns an array of all "removed" statements within, in a
+// deterministic but undefined order, without validating them.
+func FindRemoveStatements(rootCfg *configs.Config) []*RemoveStatement {
+	return findRemoveStatements(rootCfg, nil)
 }
 
 func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*RemoveStatement {
@@ -51,7 +63,7 @@ func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*Remov
 				Module:   absModule,
 			}
 
-			removedEndpoint = &RemoveStatement{From: absConfigResource, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange)}
+			removedEndpoint = &RemoveStatement{From: absConfigResource, Destroy: rc.Destroy, Provisioners: rc.Provisioners, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange)}
 
 		case addrs.Module:
 			// Get the absolute address of the module by appending the module config address
@@ -59,7 +71,7 @@ func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*Remov
 			var absModule = make(addrs.Module, 0, len(modAddr)+len(FromAddress))
 			absModule = append(absModule, modAddr...)
 			absModule = append(absModule, FromAddress...)
-			removedEndpoint = &RemoveStatement{From: absModule, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange)}
+			removedEndpoint = &RemoveStatement{From: absModule, Destroy: rc.Destroy, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange)}
 
 		default:
 			panic(fmt.Sprintf("unhandled address type %T", FromAddress))
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 )
@@ -17,41 +18,41 @@ func TestGetEndpointsToRemove(t *testing.T) {
 	tests := []struct {
 		name        string
 		fixtureName string
-		want        []addrs.ConfigRemovable
+		want        []*RemoveStatement
 		wantError   string
 	}{
 		{
 			name:        "Valid cases",
 			fixtureName: "testdata/remove-statement/valid-remove-statements",
-			want: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("foo.basic_resource")).(addrs.ConfigRemovable),
-				interface{}(addrs.Module{"basic_module"}).(addrs.ConfigRemovable),
-				interface{}(mustConfigResourceAddr("module.child.foo.removed_resource_from_root_module")).(addrs.ConfigRemovable),
-				interface{}(mustConfigResourceAddr("module.child.foo.removed_resource_from_child_module")).(addrs.ConfigRemovable),
-				interface{}(addrs.Module{"child", "removed_module_from_child_module"}).(addrs.ConfigRemovable),
-				interface{}(mustConfigResourceAddr("module.child.module.grandchild.foo.removed_resource_from_grandchild_module")).(addrs.ConfigRemovable),
-				interface{}(addrs.Module{"child", "grandchild", "removed_module_from_grandchild_module"}).(addrs.ConfigRemovable),
+			want: []*RemoveStatement{
+				{From: mustConfigResourceAddr("foo.basic_resource")},
+				{From: addrs.Module{"basic_module"}},
+				{From: mustConfigResourceAddr("module.child.foo.removed_resource_from_root_module")},
+				{From: mustConfigResourceAddr("module.child.foo.removed_resource_from_child_module")},
+				{From: addrs.Module{"child", "removed_module_from_child_module"}, Destroy: true},
+				{From: mustConfigResourceAddr("module.child.module.grandchild.foo.removed_resource_from_grandchild_module")},
+				{From: addrs.Module{"child", "grandchild", "removed_module_from_grandchild_module"}},
 			},
 			wantError: ``,
 		},
 		{
 			name:        "Error - resource block still exist",
 			fixtureName: "testdata/remove-statement/not-valid-resource-block-still-exist",
-			want: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("foo.basic_resource")).(addrs.ConfigRemovable),
+			want: []*RemoveStatement{
+				{From: mustConfigResourceAddr("foo.basic_resource")},
 			},
 			wantError: `Removed resource block still exists: This statement declares a removal of the resource foo.basic_resource, but this resource block still exists in the configuration. Please remove the resource block.`,
 		},
 		{
 			name:        "Error - module block still exist",
 			fixtureName: "testdata/remove-statement/not-valid-module-block-still-exist",
-			want:        []addrs.ConfigRemovable{},
+			want:        []*RemoveStatement{},
 			wantError:   `Removed module block still exists: This statement declares a removal of the module module.child, but this module block still exists in the configuration. Please remove the module block.`,
 		},
 		{
 			name:        "Error - nested resource block still exist",
 			fixtureName: "testdata/remove-statement/not-valid-nested-resource-block-still-exist",
-			want:        []addrs.ConfigRemovable{},
+			want:        []*RemoveStatement{},
 			wantError:   `Removed resource block still exists: This statement declares a removal of the resource module.child.foo.basic_resource, but this resource block still exists in the configuration. Please remove the resource block.`,
 		}}
 	for _, tt := range tests {
@@ -68,7 +69,7 @@ func TestGetEndpointsToRemove(t *testing.T) {
 					t.Fatalf("wrong error\ngot:  %s\nwant: %s", errStr, tt.wantError)
 				}
 			} else {
-				if diff := cmp.Diff(tt.want, got); diff != "" {
+				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(RemoveStatement{}, "DeclRange")); diff != "" {
 					t.Errorf("wrong result\n%s", diff)
 				}
 			}
@@ -8,4 +8,8 @@ module "grandchild" {
 
 removed {
   from = module.removed_module_from_child_module
-}
\ No newline at end of file
+
+  lifecycle {
+    destroy = true
+  }
+}
@@ -41,6 +41,20 @@ type OptionalClientLocker interface {
 	IsLockingEnabled() bool
 }
 
+// ClientHistorian is an optional interface that allows a remote state
+// backend to list and read earlier versions of the state, for storage
+// that retains them.
+type ClientHistorian interface {
+	Client
+
+	// ListVersions returns the stored versions of the state, newest first.
+	ListVersions() ([]statemgr.StateVersion, error)
+
+	// GetVersion returns the payload of the version with the given ID, or
+	// nil if there is no such version.
+	GetVersion(id string) (*Payload, error)
+}
+
 // Payload is the return value from the remote state storage.
 type Payload struct {
 	MD5  []byte
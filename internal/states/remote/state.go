@@ -54,6 +54,7 @@ type State struct {
 
 var _ statemgr.Full = (*State)(nil)
 var _ statemgr.Migrator = (*State)(nil)
+var _ statemgr.Historian = (*State)(nil)
 var _ local.IntermediateStateConditionalPersister = (*State)(nil)
 
 func NewState(client Client, enc encryption.StateEncryption) *State {
@@ -315,3 +316,34 @@ func (s *State) StateSnapshotMeta() statemgr.SnapshotMeta {
 		Serial:  s.serial,
 	}
 }
+
+// ListStateVersions returns the versions of the state retained by the
+// client, or statemgr.ErrHistoryUnsupported if the client doesn't keep them.
+//
+// This is an implementation of statemgr.Historian.
+func (s *State) ListStateVersions() ([]statemgr.StateVersion, error) {
+	c, ok := s.Client.(ClientHistorian)
+	if !ok {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+	return c.ListVersions()
+}
+
+// GetStateVersion reads and decrypts the version of the state with the
+// given ID.
+//
+// This is an implementation of statemgr.Historian.
+func (s *State) GetStateVersion(id string) (*statefile.File, error) {
+	c, ok := s.Client.(ClientHistorian)
+	if !ok {
+		return nil, statemgr.ErrHistoryUnsupported
+	}
+	payload, err := c.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("state version %q does not exist", id)
+	}
+	return statefile.Read(bytes.NewReader(payload.Data), s.encryption)
+}
@@ -81,6 +81,15 @@ func Read(r io.Reader, enc encryption.StateEncryption) (*File, error) {
 		return nil, err
 	}
 
+	if encrypted, _ := encryption.IsSensitiveAttributesPayload(decrypted); encrypted {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			unsupportedFormat,
+			"The sensitive values in this state file are encrypted and can not be read without an encryption configuration",
+		))
+		return nil, diags.Err()
+	}
+
 	state, err := readState(decrypted)
 	if err != nil {
 		return nil, err
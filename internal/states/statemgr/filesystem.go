@@ -62,6 +62,10 @@ type Filesystem struct {
 	backupFile     *statefile.File
 	writtenBackup  bool
 
+	// historyLimit is the number of persistent snapshots to keep in the
+	// history directory, or zero if history is disabled.
+	historyLimit int
+
 	encryption encryption.StateEncryption
 }
 
@@ -69,6 +73,7 @@ var (
 	_ Full           = (*Filesystem)(nil)
 	_ PersistentMeta = (*Filesystem)(nil)
 	_ Migrator       = (*Filesystem)(nil)
+	_ Historian      = (*Filesystem)(nil)
 )
 
 // NewFilesystem creates a filesystem-based state manager that reads and writes
@@ -226,18 +231,32 @@ func (s *Filesystem) persistState(schemas *tofu.Schemas) error {
 		return nil
 	}
 
-	if s.readFile == nil || !statefile.StatesMarshalEqual(s.file.State, s.readFile.State) {
+	changed := s.readFile == nil || !statefile.StatesMarshalEqual(s.file.State, s.readFile.State)
+	if changed {
 		s.file.Serial++
 		log.Printf("[TRACE] statemgr.Filesystem: state has changed since last snapshot, so incrementing serial to %d", s.file.Serial)
 	} else {
 		log.Print("[TRACE] statemgr.Filesystem: no state changes since last snapshot")
 	}
 
+	// When history is enabled, we also keep a copy of what we write.
+	var w io.Writer = s.stateFileOut
+	var snapshot bytes.Buffer
+	if s.historyLimit > 0 {
+		w = io.MultiWriter(s.stateFileOut, &snapshot)
+	}
+
 	log.Printf("[TRACE] statemgr.Filesystem: writing snapshot at %s", s.path)
-	if err := statefile.Write(s.file, s.stateFileOut, s.encryption); err != nil {
+	if err := statefile.Write(s.file, w, s.encryption); err != nil {
 		return err
 	}
 
+	if s.historyLimit > 0 {
+		if err := s.writeHistory(snapshot.Bytes(), changed); err != nil {
+			return fmt.Errorf("failed to write state history: %w", err)
+		}
+	}
+
 	// Any future reads must come from the file we've now updated
 	s.readPath = s.path
 	return nil
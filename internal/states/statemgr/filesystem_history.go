@@ -0,0 +1,189 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statemgr
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+const (
+	// historyDirSuffix is appended to the state file path to get the
+	// directory where earlier snapshots are kept.
+	historyDirSuffix = ".history"
+
+	// historyFileExt is the extension of the snapshot files in the history
+	// directory. The rest of the file name is the version ID.
+	historyFileExt = ".tfstate"
+
+	// historyIDFormat is the time format of the version IDs. It sorts in
+	// the same order as the times it represents.
+	historyIDFormat = "20060102T150405.000000000Z"
+)
+
+// SetHistoryLimit configures the receiver to keep a copy of each of the last
+// n persistent snapshots in a directory next to the state file, named after
+// the state file with a ".history" suffix. Setting n to zero disables the
+// history, but leaves any existing copies in place.
+//
+// For correct operation, this must be called before any other state methods
+// are called.
+func (s *Filesystem) SetHistoryLimit(n int) {
+	s.historyLimit = n
+}
+
+// HistoryDir returns the directory where the manager keeps earlier
+// snapshots if history is enabled, or an empty string otherwise.
+func (s *Filesystem) HistoryDir() string {
+	if s.historyLimit == 0 {
+		return ""
+	}
+	return s.path + historyDirSuffix
+}
+
+// ListStateVersions is an implementation of Historian.
+func (s *Filesystem) ListStateVersions() ([]StateVersion, error) {
+	defer s.mutex()()
+
+	if s.historyLimit == 0 {
+		return nil, ErrHistoryUnsupported
+	}
+
+	entries, err := s.historyEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]StateVersion, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		version := StateVersion{ID: strings.TrimSuffix(entry.Name(), historyFileExt)}
+		version.Created, _ = time.Parse(historyIDFormat, version.ID)
+		if info, err := entry.Info(); err == nil {
+			version.Size = info.Size()
+		}
+		versions = append(versions, version)
+	}
+
+	// The newest copy is only the current snapshot if nothing else has
+	// written the state file since, such as a run with history disabled.
+	if len(versions) > 0 {
+		if s.file == nil && s.readFile == nil {
+			if err := s.refreshState(); err != nil {
+				return nil, err
+			}
+		}
+		newest, err := s.readHistory(versions[0].ID)
+		if err != nil {
+			return nil, err
+		}
+		current := s.file
+		versions[0].Current = current != nil && newest.Lineage == current.Lineage && newest.Serial == current.Serial
+	}
+
+	return versions, nil
+}
+
+// GetStateVersion is an implementation of Historian.
+func (s *Filesystem) GetStateVersion(id string) (*statefile.File, error) {
+	defer s.mutex()()
+
+	if s.historyLimit == 0 {
+		return nil, ErrHistoryUnsupported
+	}
+	return s.readHistory(id)
+}
+
+// historyEntries returns the snapshot files in the history directory, oldest
+// first.
+func (s *Filesystem) historyEntries() ([]os.DirEntry, error) {
+	all, err := os.ReadDir(s.HistoryDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []os.DirEntry
+	for _, entry := range all {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), historyFileExt) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+func (s *Filesystem) readHistory(id string) (*statefile.File, error) {
+	if id == "" || filepath.Base(id) != id || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid state version ID %q", id)
+	}
+
+	f, err := os.Open(filepath.Join(s.HistoryDir(), id+historyFileExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("state version %q does not exist", id)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	return statefile.Read(f, s.encryption)
+}
+
+// writeHistory stores a copy of a snapshot that was just persisted, and
+// removes the copies that exceed the history limit. Unchanged snapshots are
+// only stored if there is no copy yet.
+func (s *Filesystem) writeHistory(data []byte, changed bool) error {
+	entries, err := s.historyEntries()
+	if err != nil {
+		return err
+	}
+	if !changed && len(entries) > 0 {
+		log.Print("[TRACE] statemgr.Filesystem: not adding to history, because the snapshot is unchanged")
+		return nil
+	}
+
+	dir := s.HistoryDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// The IDs are timestamps, so we make sure to not reuse one when
+	// snapshots are persisted in quick succession.
+	created := time.Now().UTC()
+	if len(entries) > 0 {
+		last := strings.TrimSuffix(entries[len(entries)-1].Name(), historyFileExt)
+		if t, err := time.Parse(historyIDFormat, last); err == nil && !created.After(t) {
+			created = t.Add(time.Nanosecond)
+		}
+	}
+	name := created.Format(historyIDFormat) + historyFileExt
+	log.Printf("[TRACE] statemgr.Filesystem: adding snapshot to history at %s", filepath.Join(dir, name))
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0666); err != nil {
+		return err
+	}
+
+	// The new copy is not in entries, so we keep one less of them
+	for i := 0; i < len(entries)-s.historyLimit+1; i++ {
+		path := filepath.Join(dir, entries[i].Name())
+		log.Printf("[TRACE] statemgr.Filesystem: removing %s from history", path)
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -347,6 +347,58 @@ func TestFilesystem_lockUnlockWithoutWrite(t *testing.T) {
 	}
 }
 
+func TestFilesystem_history(t *testing.T) {
+	defer testOverrideVersion(t, "1.2.3")()
+	ls := NewFilesystem(filepath.Join(t.TempDir(), "terraform.tfstate"), encryption.StateEncryptionDisabled())
+	ls.SetHistoryLimit(2)
+
+	for _, value := range []string{"one", "two", "two", "three"} {
+		state := states.NewState()
+		state.RootModule().SetOutputValue("value", cty.StringVal(value), false)
+		if err := WriteAndPersist(ls, state, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The unchanged snapshot is not added, and the oldest one is removed
+	versions, err := ls.ListStateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Fatalf("expected only the newest version to be current: %#v", versions)
+	}
+	if !versions[0].Created.After(versions[1].Created) {
+		t.Fatalf("expected the versions newest first: %#v", versions)
+	}
+
+	before := ls.file.Serial
+	if _, err := RestoreStateVersion(ls, versions[1].ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.PersistState(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := ls.State().RootModule().OutputValues["value"].Value; !got.RawEquals(cty.StringVal("two")) {
+		t.Fatalf("unexpected value after restore: %#v", got)
+	}
+	if ls.file.Serial <= before {
+		t.Fatalf("expected the restored snapshot to get a serial higher than %d, got %d", before, ls.file.Serial)
+	}
+
+	if _, err := ls.GetStateVersion("../terraform"); err == nil {
+		t.Fatal("expected an error for an invalid version ID")
+	}
+
+	ls.SetHistoryLimit(0)
+	if _, err := ls.ListStateVersions(); err != ErrHistoryUnsupported {
+		t.Fatalf("expected ErrHistoryUnsupported with history disabled, got %v", err)
+	}
+}
+
 func TestFilesystem_impl(t *testing.T) {
 	defer testOverrideVersion(t, "1.2.3")()
 	var _ Reader = new(Filesystem)
@@ -355,6 +407,7 @@ func TestFilesystem_impl(t *testing.T) {
 	var _ Refresher = new(Filesystem)
 	var _ OutputReader = new(Filesystem)
 	var _ Locker = new(Filesystem)
+	var _ Historian = new(Filesystem)
 }
 
 func testFilesystem(t *testing.T) *Filesystem {
@@ -0,0 +1,84 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statemgr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+// ErrHistoryUnsupported is returned by Historian implementations whose
+// underlying storage turns out not to retain earlier snapshots.
+var ErrHistoryUnsupported = errors.New("the state storage does not keep earlier versions of the state")
+
+// Historian is an optional extension to Persistent for managers whose
+// storage retains earlier persistent snapshots, such as object stores with
+// versioning enabled.
+//
+// Historical snapshots are read-only. To restore one, callers use
+// RestoreStateVersion and then persist it again as a new snapshot, so that
+// the history itself is never rewritten.
+type Historian interface {
+	// ListStateVersions returns the persistent snapshots that are available
+	// in storage, newest first.
+	ListStateVersions() ([]StateVersion, error)
+
+	// GetStateVersion reads the snapshot with the given ID, as returned by
+	// ListStateVersions.
+	GetStateVersion(id string) (*statefile.File, error)
+}
+
+// StateVersion describes a persistent snapshot retained by a Historian.
+type StateVersion struct {
+	// ID identifies the snapshot in the storage. Its format depends on the
+	// storage and should be treated as opaque.
+	ID string
+
+	// Created is the time the snapshot was persisted, if known.
+	Created time.Time
+
+	// Size is the size of the stored snapshot in bytes, if known.
+	Size int64
+
+	// Current is true for the latest snapshot, which is also the one
+	// returned by RefreshState.
+	Current bool
+}
+
+// RestoreStateVersion reads the snapshot with the given ID from the given
+// manager, which must also implement Historian, and writes it to the
+// manager's transient storage as the latest snapshot. The restored snapshot
+// is returned.
+//
+// The snapshot gets a serial higher than the current one, so that it isn't
+// rejected as an outdated snapshot and the current snapshot stays in the
+// history. As with Import, the lineage must match the current one unless
+// force is set.
+//
+// Callers should hold a lock and refresh the manager before calling this
+// function, and must call PersistState afterwards to save the result.
+func RestoreStateVersion(mgr Transient, id string, force bool) (*statefile.File, error) {
+	historian, ok := mgr.(Historian)
+	if !ok {
+		return nil, ErrHistoryUnsupported
+	}
+
+	restored, err := historian.GetStateVersion(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state version %q: %w", id, err)
+	}
+
+	if current := Export(mgr); current != nil {
+		restored.Serial = current.Serial + 1
+	}
+	if err := Import(restored, mgr, force); err != nil {
+		return nil, fmt.Errorf("failed to restore state version %q: %w", id, err)
+	}
+	return restored, nil
+}
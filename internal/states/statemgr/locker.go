@@ -153,6 +153,16 @@ type LockInfo struct {
 
 	// Path to the state file when applicable. Set by the Lock implementation.
 	Path string
+
+	// LeaseTTL is the number of seconds the lock stays valid without being
+	// renewed, for backends that grant leases on their locks. It is zero for
+	// locks that are held until they are released. Set by the Lock
+	// implementation.
+	LeaseTTL int `json:",omitempty"`
+
+	// LeaseExpires is the time the lease on the lock runs out unless it is
+	// renewed. It is only set together with LeaseTTL.
+	LeaseExpires *time.Time `json:",omitempty"`
 }
 
 // NewLockInfo creates a LockInfo object and populates many of its fields
@@ -210,6 +220,9 @@ func (l *LockInfo) String() string {
   Version:   {{.Version}}
   Created:   {{.Created}}
   Info:      {{.Info}}
+{{- if .LeaseTTL}}
+  Lease:     {{.LeaseTTL}}s{{if .LeaseExpires}}, expires {{.LeaseExpires}}{{end}}
+{{- end}}
 `
 
 	t := template.Must(template.New("LockInfo").Parse(tmpl))
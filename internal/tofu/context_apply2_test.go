@@ -27,6 +27,7 @@ import (
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/provisioners"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -4450,3 +4451,182 @@ resource "test_instance" "a" {
 		destroy(t, partial, state)
 	})
 }
+
+func TestContext2Apply_ephemeralResource(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    other = {
+      source = "hashicorp/other"
+    }
+  }
+}
+
+ephemeral "test_object" "a" {
+  test_string = "secret"
+}
+
+provider "other" {
+  test_string = ephemeral.test_object.a.test_string
+}
+
+resource "other_object" "b" {
+  test_string = "b"
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	other := &MockProvider{
+		GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+			Provider: providers.Schema{Block: simpleTestSchema()},
+			ResourceTypes: map[string]providers.Schema{
+				"other_object": {Block: simpleTestSchema()},
+			},
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"):  testProviderFuncFixed(p),
+			addrs.NewDefaultProvider("other"): testProviderFuncFixed(other),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	// Ephemeral resources are never saved in the plan, so they must be
+	// opened again during apply.
+	p.OpenEphemeralResourceCalled = false
+	p.CloseEphemeralResourceCalled = false
+	other.ConfigureProviderCalled = false
+
+	state, diags := ctx.Apply(context.Background(), plan, m)
+	assertNoErrors(t, diags)
+
+	if !p.OpenEphemeralResourceCalled {
+		t.Errorf("OpenEphemeralResource was not called during apply")
+	}
+	if !p.CloseEphemeralResourceCalled {
+		t.Errorf("CloseEphemeralResource was not called during apply")
+	}
+	if !other.ConfigureProviderCalled {
+		t.Fatalf("other provider was not configured during apply")
+	}
+	if got, want := other.ConfigureProviderRequest.Config.GetAttr("test_string"), cty.StringVal("secret"); !got.RawEquals(want) {
+		t.Errorf("wrong provider configuration\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Addr.Resource.Mode == addrs.EphemeralResourceMode {
+			t.Errorf("state includes %s", rs.Addr)
+		}
+	}
+}
+
+func TestContext2Apply_removedResourceDestroyProvisioner(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			removed {
+				from = aws_instance.foo
+
+				lifecycle {
+					destroy = true
+				}
+
+				provisioner "shell" {
+					when    = destroy
+					command = "destroy ${self.id}"
+				}
+			}
+		`,
+	})
+
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	pr := testProvisioner()
+	pr.ProvisionResourceFn = func(req provisioners.ProvisionResourceRequest) (resp provisioners.ProvisionResourceResponse) {
+		if got, want := req.Config.GetAttr("command").AsString(), "destroy bar"; got != want {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("wrong command\ngot:  %s\nwant: %s", got, want))
+		}
+		return
+	}
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("aws_instance.foo"), &states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"id":"bar"}`),
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/aws"]`), addrs.NoKey)
+	})
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]provisioners.Factory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	state, diags = ctx.Apply(context.Background(), plan, m)
+	assertNoErrors(t, diags)
+
+	if !pr.ProvisionResourceCalled {
+		t.Fatalf("provisioner was not called")
+	}
+	checkStateString(t, state, `<no state>`)
+}
+
+func TestContext2Apply_resourceEnabled(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			data "test_object" "a" {
+				test_string = "data"
+
+				lifecycle {
+					enabled = false
+				}
+			}
+
+			resource "test_object" "a" {
+				test_string = data.test_object.a == null ? "no data" : data.test_object.a.test_string
+
+				lifecycle {
+					enabled = true
+				}
+			}
+
+			output "a" {
+				value = test_object.a.test_string
+			}
+		`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	state, diags := ctx.Apply(context.Background(), plan, m)
+	assertNoErrors(t, diags)
+
+	if p.ReadDataSourceCalled {
+		t.Errorf("disabled data source was read")
+	}
+	if rs := state.ResourceInstance(mustResourceInstanceAddr("test_object.a")); rs == nil {
+		t.Errorf("test_object.a is not in the state")
+	}
+	if got, want := state.RootModule().OutputValues["a"].Value, cty.StringVal("no data"); !want.RawEquals(got) {
+		t.Errorf("wrong output value\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -98,9 +98,9 @@ type PlanOpts struct {
 	// will be added to the plan graph.
 	ImportTargets []*ImportTarget
 
-	// EndpointsToRemove are the list of resources and modules to forget from
-	// the state.
-	EndpointsToRemove []addrs.ConfigRemovable
+	// EndpointsToRemove are the removed statements declaring resources and
+	// modules to forget from the state or destroy.
+	EndpointsToRemove []*refactoring.RemoveStatement
 
 	// GenerateConfig tells OpenTofu where to write any generated configuration
 	// for any ImportTargets that do not have configuration already.
@@ -229,11 +229,19 @@ The -target and -exclude options are not for routine use, and are provided only
 
 	// convert the variables into the format expected for the plan
 	varVals := make(map[string]plans.DynamicValue, len(opts.SetVariables))
+	var applyTimeVars []string
 	for k, iv := range opts.SetVariables {
 		if iv.Value == cty.NilVal {
 			continue // We only record values that the caller actually set
 		}
 
+		// Ephemeral variables must never be saved in the plan, so we only
+		// record that they will need to be set again during apply.
+		if vc, ok := config.Module.Variables[k]; ok && vc.Ephemeral {
+			applyTimeVars = append(applyTimeVars, k)
+			continue
+		}
+
 		// We use cty.DynamicPseudoType here so that we'll save both the
 		// value _and_ its dynamic type in the plan, so we can recover
 		// exactly the same value later.
@@ -253,6 +261,8 @@ The -target and -exclude options are not for routine use, and are provided only
 	// targets and provider SHAs.
 	if plan != nil {
 		plan.VariableValues = varVals
+		sort.Strings(applyTimeVars)
+		plan.ApplyTimeVariables = applyTimeVars
 		plan.TargetAddrs = opts.Targets
 		plan.ExcludeAddrs = opts.Excludes
 	} else if !diags.HasErrors() {
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -7853,6 +7854,264 @@ func TestContext2Plan_removedModuleButModuleBlockStillExists(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_removedResourceDestroy(t *testing.T) {
+	forgetAddr := mustResourceInstanceAddr("test_object.a")
+	destroyAddr := mustResourceInstanceAddr("test_object.b")
+	moduleAddr := mustResourceInstanceAddr("module.mod.test_object.a")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			removed {
+				from = test_object.a
+
+				lifecycle {
+					destroy = false
+				}
+			}
+
+			removed {
+				from = test_object.b
+
+				lifecycle {
+					destroy = true
+				}
+			}
+
+			removed {
+				from = module.mod
+
+				lifecycle {
+					destroy = true
+				}
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{forgetAddr, destroyAddr, moduleAddr} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+		}
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	for addr, want := range map[string]plans.Action{
+		forgetAddr.String():  plans.Forget,
+		destroyAddr.String(): plans.Delete,
+		moduleAddr.String():  plans.Delete,
+	} {
+		t.Run(addr, func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(mustResourceInstanceAddr(addr))
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", addr)
+			}
+			if got := instPlan.Action; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_resourceEnabled(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			variable "create" {
+				type = bool
+			}
+
+			resource "test_object" "a" {
+				test_string = "a"
+
+				lifecycle {
+					enabled = var.create
+				}
+			}
+
+			resource "test_object" "b" {
+				test_string = "b"
+
+				lifecycle {
+					enabled = !var.create
+				}
+			}
+
+			module "mod" {
+				source = "./mod"
+
+				lifecycle {
+					enabled = !var.create
+				}
+			}
+
+			output "a" {
+				value = test_object.a == null ? "absent" : test_object.a.test_string
+			}
+
+			output "b" {
+				value = test_object.b == null ? "absent" : test_object.b.test_string
+			}
+
+			output "mod" {
+				value = module.mod == null ? "absent" : module.mod.id
+			}
+		`,
+		"mod/main.tf": `
+			resource "test_object" "a" {
+				test_string = "mod"
+			}
+
+			output "id" {
+				value = test_object.a.test_string
+			}
+		`,
+	})
+
+	bAddr := mustResourceInstanceAddr("test_object.b")
+	modAddr := mustResourceInstanceAddr("module.mod.test_object.a")
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{bAddr, modAddr} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"test_string":"old"}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+		}
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+		SetVariables: InputValues{
+			"create": &InputValue{
+				Value:      cty.True,
+				SourceType: ValueFromCaller,
+			},
+		},
+	})
+	assertNoErrors(t, diags)
+
+	for addr, want := range map[string]plans.Action{
+		"test_object.a":            plans.Create,
+		"test_object.b":            plans.Delete,
+		"module.mod.test_object.a": plans.Delete,
+	} {
+		t.Run(addr, func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(mustResourceInstanceAddr(addr))
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", addr)
+			}
+			if got := instPlan.Action; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+
+	for name, want := range map[string]cty.Value{
+		"a":   cty.StringVal("a"),
+		"b":   cty.StringVal("absent"),
+		"mod": cty.StringVal("absent"),
+	} {
+		t.Run("output."+name, func(t *testing.T) {
+			outChangeSrc := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue(name))
+			if outChangeSrc == nil {
+				t.Fatalf("no change planned for output value %q", name)
+			}
+			outChange, err := outChangeSrc.Decode()
+			if err != nil {
+				t.Fatalf("failed to decode output value %q: %s", name, err)
+			}
+			if got := outChange.After; !want.RawEquals(got) {
+				t.Errorf("wrong value for output value %q\ngot:  %#v\nwant: %#v", name, got, want)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_resourceEnabledImpliedMove(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				lifecycle {
+					enabled = true
+				}
+			}
+		`,
+	})
+
+	oldAddr := mustResourceInstanceAddr("test_object.a[0]")
+	newAddr := mustResourceInstanceAddr("test_object.a")
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(oldAddr, &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	instPlan := plan.Changes.ResourceInstance(newAddr)
+	if instPlan == nil {
+		t.Fatalf("no plan for %s at all", newAddr)
+	}
+	if got, want := instPlan.PrevRunAddr, oldAddr; !got.Equal(want) {
+		t.Errorf("wrong previous run address\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := instPlan.Action, plans.NoOp; got != want {
+		t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_resourceEnabledUnknown(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				lifecycle {
+					# timestamp() is always unknown during planning
+					enabled = timestamp() != ""
+				}
+			}
+		`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want error")
+	}
+	if got, want := diags.Err().Error(), "Invalid enabled argument"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+	}
+}
+
 func TestContext2Plan_importResourceWithSensitiveDataSource(t *testing.T) {
 	addr := mustResourceInstanceAddr("test_object.b")
 	m := testModuleInline(t, map[string]string{
@@ -8046,3 +8305,423 @@ func featuresBlockTestSchema() *configschema.Block {
 		},
 	}
 }
+
+func TestContext2Plan_ephemeralVariables(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"child/main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+output "header" {
+  value     = "Bearer ${var.token}"
+  ephemeral = true
+}
+`,
+		"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+variable "name" {
+  type = string
+}
+
+module "child" {
+  source = "./child"
+  token  = var.token
+}
+
+locals {
+  header = module.child.header
+}
+
+resource "test_object" "a" {
+  test_string = var.name
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+		Mode: plans.NormalMode,
+		SetVariables: InputValues{
+			"token": &InputValue{
+				Value:      cty.StringVal("secret"),
+				SourceType: ValueFromCLIArg,
+			},
+			"name": &InputValue{
+				Value:      cty.StringVal("foo"),
+				SourceType: ValueFromCLIArg,
+			},
+		},
+	})
+	assertNoErrors(t, diags)
+
+	if _, ok := plan.VariableValues["token"]; ok {
+		t.Errorf("plan includes a value for the ephemeral variable")
+	}
+	if _, ok := plan.VariableValues["name"]; !ok {
+		t.Errorf("plan does not include a value for the non-ephemeral variable")
+	}
+	if got, want := plan.ApplyTimeVariables, []string{"token"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong apply-time variables\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestContext2Plan_ephemeralValueInvalidUse(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		wantErr string
+	}{
+		"resource argument": {
+			files: map[string]string{
+				"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+resource "test_object" "a" {
+  test_string = var.token
+}
+`,
+			},
+			wantErr: "Invalid use of ephemeral value",
+		},
+		"data source argument": {
+			files: map[string]string{
+				"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+data "test_object" "a" {
+  test_string = var.token
+}
+`,
+			},
+			wantErr: "Invalid use of ephemeral value",
+		},
+		"ephemeral resource attribute": {
+			files: map[string]string{
+				"main.tf": `
+ephemeral "test_object" "a" {
+  test_string = "secret"
+}
+
+resource "test_object" "b" {
+  test_string = ephemeral.test_object.a.test_string
+}
+`,
+			},
+			wantErr: "Invalid use of ephemeral value",
+		},
+		"non-ephemeral module variable": {
+			files: map[string]string{
+				"child/main.tf": `
+variable "token" {
+  type = string
+}
+`,
+				"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+module "child" {
+  source = "./child"
+  token  = var.token
+}
+`,
+			},
+			wantErr: "Invalid use of ephemeral value",
+		},
+		"non-ephemeral module output": {
+			files: map[string]string{
+				"child/main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+output "token" {
+  value = var.token
+}
+`,
+				"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+module "child" {
+  source = "./child"
+  token  = var.token
+}
+`,
+			},
+			wantErr: "Output refers to ephemeral values",
+		},
+		"root module output": {
+			files: map[string]string{
+				"main.tf": `
+variable "token" {
+  type      = string
+  ephemeral = true
+}
+
+output "token" {
+  value     = var.token
+  ephemeral = true
+}
+`,
+			},
+			wantErr: "Ephemeral output not allowed",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, test.files)
+			p := simpleMockProvider()
+			ctx := testContext2(t, &ContextOpts{
+				Providers: map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				},
+			})
+
+			_, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+				Mode: plans.NormalMode,
+				SetVariables: InputValues{
+					"token": &InputValue{
+						Value:      cty.StringVal("secret"),
+						SourceType: ValueFromCLIArg,
+					},
+				},
+			})
+			if !diags.HasErrors() {
+				t.Fatal("succeeded; want errors")
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+				t.Fatalf("wrong error:\ngot:  %s\nwant: message containing %q", got, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_ephemeralResource(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    other = {
+      source = "hashicorp/other"
+    }
+  }
+}
+
+ephemeral "test_object" "a" {
+  count = 2
+
+  test_string = "secret-${count.index}"
+}
+
+provider "other" {
+  test_string = ephemeral.test_object.a[1].test_string
+}
+
+resource "other_object" "b" {
+}
+`,
+	})
+
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	p := simpleMockProvider()
+	p.OpenEphemeralResourceFn = func(req providers.OpenEphemeralResourceRequest) providers.OpenEphemeralResourceResponse {
+		record("open " + req.Config.GetAttr("test_string").AsString())
+		return providers.OpenEphemeralResourceResponse{
+			Result:  req.Config,
+			Private: []byte(req.Config.GetAttr("test_string").AsString()),
+		}
+	}
+	p.CloseEphemeralResourceFn = func(req providers.CloseEphemeralResourceRequest) providers.CloseEphemeralResourceResponse {
+		record("close " + string(req.Private))
+		return providers.CloseEphemeralResourceResponse{}
+	}
+
+	other := &MockProvider{
+		GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+			Provider: providers.Schema{Block: simpleTestSchema()},
+			ResourceTypes: map[string]providers.Schema{
+				"other_object": {Block: simpleTestSchema()},
+			},
+		},
+	}
+	other.ConfigureProviderFn = func(req providers.ConfigureProviderRequest) providers.ConfigureProviderResponse {
+		record("configure " + req.Config.GetAttr("test_string").AsString())
+		return providers.ConfigureProviderResponse{}
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"):  testProviderFuncFixed(p),
+			addrs.NewDefaultProvider("other"): testProviderFuncFixed(other),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	// The other provider can only be configured once the ephemeral resource
+	// it refers to is open, and the ephemeral resources must stay open until
+	// the provider has finished with them.
+	sort.Strings(events[:2])
+	sort.Strings(events[3:])
+	want := []string{
+		"open secret-0",
+		"open secret-1",
+		"configure secret-1",
+		"close secret-0",
+		"close secret-1",
+	}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("wrong events\n%s", diff)
+	}
+
+	for _, rc := range plan.Changes.Resources {
+		if rc.Addr.Resource.Resource.Mode == addrs.EphemeralResourceMode {
+			t.Errorf("plan includes a change for %s", rc.Addr)
+		}
+	}
+	for _, rs := range plan.PriorState.RootModule().Resources {
+		if rs.Addr.Resource.Mode == addrs.EphemeralResourceMode {
+			t.Errorf("prior state includes %s", rs.Addr)
+		}
+	}
+}
+
+func TestContext2Plan_ephemeralResourceUnknownConfig(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+}
+
+ephemeral "test_object" "b" {
+  test_string = test_object.a.id
+}
+`,
+	})
+
+	p := &MockProvider{
+		GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+			ResourceTypes: map[string]providers.Schema{
+				"test_object": {
+					Block: &configschema.Block{
+						Attributes: map[string]*configschema.Attribute{
+							"id": {
+								Type:     cty.String,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+			EphemeralResources: map[string]providers.Schema{
+				"test_object": {Block: simpleTestSchema()},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	if p.OpenEphemeralResourceCalled {
+		t.Errorf("OpenEphemeralResource was called with unknown configuration")
+	}
+	if p.CloseEphemeralResourceCalled {
+		t.Errorf("CloseEphemeralResource was called for a resource that was never opened")
+	}
+}
+
+func TestContext2Plan_moduleFunctions(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+function "name" {
+  parameter "value" {
+    type = string
+  }
+
+  result = "root-${value}"
+}
+
+module "child" {
+  source = "./child"
+  value  = module::name("Hello World")
+}
+
+output "out" {
+  value = module.child.out
+}
+`,
+		"child/main.tf": `
+variable "value" {
+  type = string
+}
+
+function "name" {
+  parameter "value" {
+    type = string
+  }
+
+  result = replace(lower(value), " ", "-")
+}
+
+output "out" {
+  value = module::name(var.value)
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	// Each module calls its own function of the same name.
+	outChangeSrc := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue("out"))
+	if outChangeSrc == nil {
+		t.Fatalf("no change planned for output value 'out'")
+	}
+	outChange, err := outChangeSrc.Decode()
+	if err != nil {
+		t.Fatalf("failed to decode output value 'out': %s", err)
+	}
+	got := outChange.After
+	want := cty.StringVal("root-hello-world")
+	if !want.RawEquals(got) {
+		t.Errorf("wrong value for output value 'out'\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -2488,3 +2490,91 @@ locals {
 		t.Fatalf("expected deprecated warning, got: %q\n", warn)
 	}
 }
+
+func TestContext2Validate_ephemeralResourceInvalidType(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+ephemeral "test_objects" "a" {
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	diags := ctx.Validate(context.Background(), m)
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want errors")
+	}
+	if got, want := diags.Err().Error(), `Did you mean "test_object"?`; !strings.Contains(got, want) {
+		t.Fatalf("wrong error:\ngot:  %s\nwant: message containing %q", got, want)
+	}
+	if p.OpenEphemeralResourceCalled {
+		t.Errorf("OpenEphemeralResource was called during validation")
+	}
+}
+
+func TestContext2Validate_deprecatedModuleVariableAndOutput(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"child/main.tf": `
+variable "old_name" {
+  type       = string
+  default    = null
+  deprecated = "Use new_name instead."
+}
+
+variable "new_name" {
+  type    = string
+  default = null
+}
+
+output "old_id" {
+  value      = "old"
+  deprecated = "Use new_id instead."
+}
+
+output "new_id" {
+  value = "new"
+}
+`,
+		"main.tf": `
+module "child" {
+  source   = "./child"
+  old_name = "foo"
+}
+
+module "quiet" {
+  source   = "./child"
+  new_name = "foo"
+}
+
+locals {
+  old = module.child.old_id
+  new = module.quiet.new_id
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+
+	diags := ctx.Validate(context.Background(), m)
+	assertNoErrors(t, diags)
+
+	var got []string
+	for _, diag := range diags {
+		desc := diag.Description()
+		got = append(got, fmt.Sprintf("%s: %s", desc.Summary, desc.Detail))
+	}
+	sort.Strings(got)
+	want := []string{
+		`Deprecated output: The output "old_id" of module "child" is deprecated: Use new_id instead.`,
+		`Deprecated variable: The variable "old_name" of module "child" is deprecated: Use new_name instead.`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong warnings\n%s", diff)
+	}
+}
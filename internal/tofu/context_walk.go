@@ -60,6 +60,12 @@ func (c *Context) walk(ctx context.Context, graph *Graph, operation walkOperatio
 	// Walk the real graph, this will block until it completes
 	diags := graph.Walk(ctx, walker)
 
+	// Close any ephemeral resource instances that are still open, such as
+	// when the walk was halted by an error before their close nodes ran.
+	if walker.ephemeralResources != nil {
+		diags = diags.Append(walker.ephemeralResources.closeAll())
+	}
+
 	// Close the channel so the watcher stops, and wait for it to return.
 	close(watchStop)
 	<-watchWait
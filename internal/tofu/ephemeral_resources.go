@@ -0,0 +1,211 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ephemeralResources tracks the ephemeral resource instances that have been
+// opened during a single graph walk.
+//
+// Ephemeral resource results are never written to the state or the plan, so
+// this object is the only place their values live. Each open instance is
+// renewed in the background whenever its provider asks for it, and must be
+// closed once nothing else in the graph needs its value anymore.
+type ephemeralResources struct {
+	mu        sync.Mutex
+	expanded  addrs.Set[addrs.AbsResource]
+	instances addrs.Map[addrs.AbsResourceInstance, *ephemeralResourceInstance]
+}
+
+func newEphemeralResources() *ephemeralResources {
+	return &ephemeralResources{
+		expanded:  addrs.MakeSet[addrs.AbsResource](),
+		instances: addrs.MakeMap[addrs.AbsResourceInstance, *ephemeralResourceInstance](),
+	}
+}
+
+// ephemeralResourceInstance is a single open ephemeral resource instance.
+type ephemeralResourceInstance struct {
+	addr     addrs.AbsResourceInstance
+	provider providers.Interface
+	value    cty.Value
+
+	// open is false for instances whose value was not known enough to open
+	// them, and so which must not be renewed or closed.
+	open bool
+
+	mu      sync.Mutex
+	private []byte
+	diags   tfdiags.Diagnostics
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// setExpanded records that all of the instances of the given resource have
+// been registered, and so get can return a complete result for it.
+func (e *ephemeralResources) setExpanded(addr addrs.AbsResource) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expanded.Add(addr)
+}
+
+// registerUnknown records an instance whose configuration was not wholly
+// known, and so which could not be opened. Its value is unknown and it is
+// never renewed or closed.
+func (e *ephemeralResources) registerUnknown(addr addrs.AbsResourceInstance, val cty.Value) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.instances.Put(addr, &ephemeralResourceInstance{
+		addr:  addr,
+		value: val,
+	})
+}
+
+// open records an instance that the provider has opened, and starts renewing
+// it in the background if the provider requested a renewal.
+func (e *ephemeralResources) open(addr addrs.AbsResourceInstance, provider providers.Interface, val cty.Value, private []byte, renewAt time.Time) {
+	inst := &ephemeralResourceInstance{
+		addr:     addr,
+		provider: provider,
+		value:    val,
+		open:     true,
+		private:  private,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	e.mu.Lock()
+	e.instances.Put(addr, inst)
+	e.mu.Unlock()
+
+	go inst.renew(renewAt)
+}
+
+// get returns the values of all of the registered instances of the given
+// resource, keyed by their instance keys. The second return value is false
+// if the resource has not been expanded yet.
+func (e *ephemeralResources) get(addr addrs.AbsResource) (map[addrs.InstanceKey]cty.Value, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.expanded.Has(addr) {
+		return nil, false
+	}
+
+	ret := make(map[addrs.InstanceKey]cty.Value)
+	for _, elem := range e.instances.Elems {
+		if elem.Key.ContainingResource().Equal(addr) {
+			ret[elem.Key.Resource.Key] = elem.Value.value
+		}
+	}
+	return ret, true
+}
+
+// closeResource closes all of the open instances of the given resource, in
+// all of the module instances it was expanded into.
+func (e *ephemeralResources) closeResource(addr addrs.ConfigResource) tfdiags.Diagnostics {
+	e.mu.Lock()
+	var toClose []*ephemeralResourceInstance
+	for _, elem := range e.instances.Elems {
+		if elem.Key.ConfigResource().Equal(addr) {
+			toClose = append(toClose, elem.Value)
+			e.instances.Remove(elem.Key)
+		}
+	}
+	e.mu.Unlock()
+
+	var diags tfdiags.Diagnostics
+	for _, inst := range toClose {
+		diags = diags.Append(inst.close())
+	}
+	return diags
+}
+
+// closeAll closes any instances that are still open. This is a safety net
+// for graph walks that ended early, such as after an error, before all of
+// the close nodes had a chance to run.
+func (e *ephemeralResources) closeAll() tfdiags.Diagnostics {
+	e.mu.Lock()
+	var toClose []*ephemeralResourceInstance
+	for _, elem := range e.instances.Elems {
+		toClose = append(toClose, elem.Value)
+	}
+	e.instances = addrs.MakeMap[addrs.AbsResourceInstance, *ephemeralResourceInstance]()
+	e.mu.Unlock()
+
+	var diags tfdiags.Diagnostics
+	for _, inst := range toClose {
+		diags = diags.Append(inst.close())
+	}
+	return diags
+}
+
+// renew calls the provider's RenewEphemeralResource function each time the
+// most recent renewal deadline is reached, until the instance is closed or
+// the provider no longer requests a renewal.
+func (i *ephemeralResourceInstance) renew(renewAt time.Time) {
+	defer close(i.done)
+
+	for !renewAt.IsZero() {
+		timer := time.NewTimer(time.Until(renewAt))
+		select {
+		case <-i.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		i.mu.Lock()
+		log.Printf("[TRACE] ephemeralResources: renewing %s", i.addr)
+		resp := i.provider.RenewEphemeralResource(providers.RenewEphemeralResourceRequest{
+			TypeName: i.addr.Resource.Resource.Type,
+			Private:  i.private,
+		})
+		i.diags = i.diags.Append(resp.Diagnostics)
+		if resp.Private != nil {
+			i.private = resp.Private
+		}
+		failed := resp.Diagnostics.HasErrors()
+		i.mu.Unlock()
+
+		if failed {
+			return
+		}
+		renewAt = resp.RenewAt
+	}
+}
+
+// close stops any background renewals and then asks the provider to close
+// the instance, returning any diagnostics from the renewals and the close.
+func (i *ephemeralResourceInstance) close() tfdiags.Diagnostics {
+	if !i.open {
+		return nil
+	}
+
+	close(i.stop)
+	<-i.done
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	log.Printf("[TRACE] ephemeralResources: closing %s", i.addr)
+	resp := i.provider.CloseEphemeralResource(providers.CloseEphemeralResourceRequest{
+		TypeName: i.addr.Resource.Resource.Type,
+		Private:  i.private,
+	})
+	return i.diags.Append(resp.Diagnostics)
+}
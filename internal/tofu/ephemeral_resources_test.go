@@ -0,0 +1,81 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+)
+
+func TestEphemeralResources(t *testing.T) {
+	resAddr := addrs.Resource{
+		Mode: addrs.EphemeralResourceMode,
+		Type: "test_object",
+		Name: "a",
+	}.Absolute(addrs.RootModuleInstance)
+	instAddr := resAddr.Instance(addrs.NoKey)
+
+	renewed := make(chan []byte, 1)
+	p := simpleMockProvider()
+	p.RenewEphemeralResourceFn = func(req providers.RenewEphemeralResourceRequest) providers.RenewEphemeralResourceResponse {
+		renewed <- req.Private
+		// No further renewals are needed
+		return providers.RenewEphemeralResourceResponse{
+			Private: []byte("renewed"),
+		}
+	}
+
+	e := newEphemeralResources()
+	if _, ok := e.get(resAddr); ok {
+		t.Fatalf("get succeeded before the resource was expanded")
+	}
+
+	e.setExpanded(resAddr)
+	e.open(instAddr, p, cty.StringVal("value"), []byte("opened"), time.Now())
+
+	got, ok := e.get(resAddr)
+	if !ok {
+		t.Fatalf("get failed after the resource was expanded")
+	}
+	if want := cty.StringVal("value"); !got[addrs.NoKey].RawEquals(want) {
+		t.Errorf("wrong value\ngot:  %#v\nwant: %#v", got[addrs.NoKey], want)
+	}
+
+	select {
+	case private := <-renewed:
+		if got, want := string(private), "opened"; got != want {
+			t.Errorf("wrong private data for renew\ngot:  %s\nwant: %s", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("resource was not renewed")
+	}
+
+	diags := e.closeResource(resAddr.Config())
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if !p.CloseEphemeralResourceCalled {
+		t.Fatalf("CloseEphemeralResource was not called")
+	}
+	if got, want := string(p.CloseEphemeralResourceRequest.Private), "renewed"; got != want {
+		t.Errorf("wrong private data for close\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// The resource is no longer open, so closing everything that remains
+	// must not close it again.
+	p.CloseEphemeralResourceCalled = false
+	if diags := e.closeAll(); diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if p.CloseEphemeralResourceCalled {
+		t.Errorf("CloseEphemeralResource was called for a closed resource")
+	}
+}
@@ -175,6 +175,10 @@ type EvalContext interface {
 	// declared in the configuration.
 	Checks() *checks.State
 
+	// EphemeralResources returns the object that tracks the ephemeral
+	// resource instances opened during the current graph walk.
+	EphemeralResources() *ephemeralResources
+
 	// RefreshState returns a wrapper object that provides safe concurrent
 	// access to the state used to store the most recently refreshed resource
 	// values.
@@ -76,6 +76,7 @@ type BuiltinEvalContext struct {
 	ChangesValue            *plans.ChangesSync
 	StateValue              *states.SyncState
 	ChecksValue             *checks.State
+	EphemeralResourcesValue *ephemeralResources
 	RefreshStateValue       *states.SyncState
 	PrevRunStateValue       *states.SyncState
 	InstanceExpanderValue   *instances.Expander
@@ -491,6 +492,7 @@ func (ctx *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source
 
 		return evalContextProviderFunction(provider, ctx.Evaluator.Operation, pf, rng)
 	})
+	scope.ModuleFunctions = mc.Module.ScopeFunctions()
 	scope.SetActiveExperiments(mc.Module.ActiveExperiments)
 
 	return scope
@@ -561,6 +563,10 @@ func (ctx *BuiltinEvalContext) Checks() *checks.State {
 	return ctx.ChecksValue
 }
 
+func (ctx *BuiltinEvalContext) EphemeralResources() *ephemeralResources {
+	return ctx.EphemeralResourcesValue
+}
+
 func (ctx *BuiltinEvalContext) RefreshState() *states.SyncState {
 	return ctx.RefreshStateValue
 }
@@ -143,6 +143,9 @@ type MockEvalContext struct {
 	ChecksCalled bool
 	ChecksState  *checks.State
 
+	EphemeralResourcesCalled    bool
+	EphemeralResourcesResources *ephemeralResources
+
 	RefreshStateCalled bool
 	RefreshStateState  *states.SyncState
 
@@ -388,6 +391,11 @@ func (c *MockEvalContext) Checks() *checks.State {
 	return c.ChecksState
 }
 
+func (c *MockEvalContext) EphemeralResources() *ephemeralResources {
+	c.EphemeralResourcesCalled = true
+	return c.EphemeralResourcesResources
+}
+
 func (c *MockEvalContext) RefreshState() *states.SyncState {
 	c.RefreshStateCalled = true
 	return c.RefreshStateState
@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// validateResourceForbiddenEphemeralValues returns an error diagnostic for
+// each attribute of the given resource configuration value that is derived
+// from an ephemeral value.
+//
+// The arguments of managed resources and data sources are persisted in the
+// state and plan, and so they must never refer to ephemeral values.
+//
+// The given address is used only to annotate the returned diagnostics.
+func validateResourceForbiddenEphemeralValues(mode addrs.ResourceMode, addr string, val cty.Value, body hcl.Body) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if !val.ContainsMarked() {
+		return diags
+	}
+
+	noun := "resource"
+	if mode == addrs.DataResourceMode {
+		noun = "data source"
+	}
+
+	_, pvms := val.UnmarkDeepWithPaths()
+	for _, pvm := range pvms {
+		if _, ephemeral := pvm.Marks[marks.Ephemeral]; !ephemeral {
+			continue
+		}
+		diags = diags.Append(tfdiags.AttributeValue(
+			tfdiags.Error,
+			"Invalid use of ephemeral value",
+			fmt.Sprintf("Ephemeral values are not valid in %s arguments, because OpenTofu persists them in the state and plan.", noun),
+			pvm.Path,
+		))
+	}
+	return diags.InConfigBody(body, addr)
+}
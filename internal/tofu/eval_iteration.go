@@ -26,9 +26,9 @@ func evalContextScope(ctx EvalContext) evalchecks.ContextFunc {
 	}
 }
 
-func evalContextEvaluate(ctx EvalContext) evalchecks.EvaluateFunc {
+func evalContextEvaluate(ctx EvalContext, wantType cty.Type) evalchecks.EvaluateFunc {
 	return func(expr hcl.Expression) (cty.Value, tfdiags.Diagnostics) {
-		return ctx.EvaluateExpr(expr, cty.Number, nil)
+		return ctx.EvaluateExpr(expr, wantType, nil)
 	}
 }
 
@@ -41,9 +41,17 @@ func evaluateForEachExpressionValue(expr hcl.Expression, ctx EvalContext, allowU
 }
 
 func evaluateCountExpression(expr hcl.Expression, ctx EvalContext) (int, tfdiags.Diagnostics) {
-	return evalchecks.EvaluateCountExpression(expr, evalContextEvaluate(ctx))
+	return evalchecks.EvaluateCountExpression(expr, evalContextEvaluate(ctx, cty.Number))
 }
 
 func evaluateCountExpressionValue(expr hcl.Expression, ctx EvalContext) (cty.Value, tfdiags.Diagnostics) {
-	return evalchecks.EvaluateCountExpressionValue(expr, evalContextEvaluate(ctx))
+	return evalchecks.EvaluateCountExpressionValue(expr, evalContextEvaluate(ctx, cty.Number))
+}
+
+func evaluateEnabledExpression(expr hcl.Expression, ctx EvalContext) (bool, tfdiags.Diagnostics) {
+	return evalchecks.EvaluateEnabledExpression(expr, evalContextEvaluate(ctx, cty.DynamicPseudoType))
+}
+
+func evaluateEnabledExpressionValue(expr hcl.Expression, ctx EvalContext) (cty.Value, tfdiags.Diagnostics) {
+	return evalchecks.EvaluateEnabledExpressionValue(expr, evalContextEvaluate(ctx, cty.DynamicPseudoType))
 }
@@ -148,6 +148,23 @@ func prepareFinalInputVariableValue(addr addrs.AbsInputVariableInstance, raw *In
 		return cty.UnknownVal(cfg.Type), diags
 	}
 
+	// An ephemeral value can only be assigned to a variable that is also
+	// declared as ephemeral, because otherwise the module would be free to
+	// use the value in a way that would cause it to be persisted.
+	if !cfg.Ephemeral && marks.Contains(val, marks.Ephemeral) {
+		log.Printf("[ERROR] prepareFinalInputVariableValue: %s is not ephemeral but was given an ephemeral value", addr)
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid use of ephemeral value",
+			Detail: fmt.Sprintf(
+				"The given value for %s is derived from an ephemeral value, but the variable declared at %s is not ephemeral. Add ephemeral = true to the variable declaration to allow ephemeral values.",
+				addr, cfg.DeclRange.String(),
+			),
+			Subject: sourceRange.ToHCL().Ptr(),
+		})
+		return cty.UnknownVal(cfg.Type), diags
+	}
+
 	// By the time we get here, we know:
 	// - val matches the variable's type constraint
 	// - val is definitely not cty.NilVal, but might be a null value if the given was already null.
@@ -67,6 +67,10 @@ type Evaluator struct {
 	// ensures they can be safely accessed and modified concurrently.
 	Changes *plans.ChangesSync
 
+	// EphemeralResources tracks the ephemeral resource instances opened
+	// during the graph walk, whose values are never saved in State.
+	EphemeralResources *ephemeralResources
+
 	PlanTimestamp time.Time
 }
 
@@ -265,11 +269,16 @@ func (d *evaluationStateData) GetInputVariable(addr addrs.InputVariable, rng tfd
 	// being liberal in what it accepts because the subsequent plan walk has
 	// more information available and so can be more conservative.
 	if d.Operation == walkValidate {
-		// Ensure variable sensitivity is captured in the validate walk
+		// Ensure variable sensitivity and ephemerality are captured in the
+		// validate walk
+		val := cty.UnknownVal(config.Type)
 		if config.Sensitive {
-			return cty.UnknownVal(config.Type).Mark(marks.Sensitive), diags
+			val = val.Mark(marks.Sensitive)
 		}
-		return cty.UnknownVal(config.Type), diags
+		if config.Ephemeral {
+			val = val.Mark(marks.Ephemeral)
+		}
+		return val, diags
 	}
 
 	moduleAddrStr := d.ModulePath.String()
@@ -307,6 +316,11 @@ func (d *evaluationStateData) GetInputVariable(addr addrs.InputVariable, rng tfd
 	if config.Sensitive {
 		val = val.Mark(marks.Sensitive)
 	}
+	// Mark if ephemeral, so that we can prevent the value from being
+	// persisted anywhere.
+	if config.Ephemeral {
+		val = val.Mark(marks.Ephemeral)
+	}
 
 	return val, diags
 }
@@ -420,6 +434,10 @@ func (d *evaluationStateData) GetModule(addr addrs.ModuleCall, rng tfdiags.Sourc
 	// module instance.
 	moduleInstances := map[addrs.InstanceKey]map[string]cty.Value{}
 
+	// track the module instances whose outputs are all planned for deletion,
+	// which happens when the instance is no longer part of the expansion
+	deletedInstances := map[addrs.InstanceKey]bool{}
+
 	// create a dummy object type for validation below
 	unknownMap := map[string]cty.Type{}
 
@@ -442,6 +460,9 @@ func (d *evaluationStateData) GetModule(addr addrs.ModuleCall, rng tfdiags.Sourc
 				moduleInstances[key] = instance
 			}
 
+			if cfg.Ephemeral {
+				outputState = outputState.Mark(marks.Ephemeral)
+			}
 			instance[cfg.Name] = outputState
 		}
 
@@ -456,6 +477,10 @@ func (d *evaluationStateData) GetModule(addr addrs.ModuleCall, rng tfdiags.Sourc
 			if !ok {
 				instance = map[string]cty.Value{}
 				moduleInstances[key] = instance
+				deletedInstances[key] = true
+			}
+			if changeSrc.Action != plans.Delete {
+				deletedInstances[key] = false
 			}
 
 			change, err := changeSrc.Decode()
@@ -470,7 +495,10 @@ func (d *evaluationStateData) GetModule(addr addrs.ModuleCall, rng tfdiags.Sourc
 			instance[cfg.Name] = change.After
 
 			if change.Sensitive {
-				instance[cfg.Name] = change.After.Mark(marks.Sensitive)
+				instance[cfg.Name] = instance[cfg.Name].Mark(marks.Sensitive)
+			}
+			if cfg.Ephemeral {
+				instance[cfg.Name] = instance[cfg.Name].Mark(marks.Ephemeral)
 			}
 		}
 	}
@@ -534,6 +562,16 @@ func (d *evaluationStateData) GetModule(addr addrs.ModuleCall, rng tfdiags.Sourc
 			ret = cty.EmptyObjectVal
 		}
 
+	case callConfig.Enabled != nil:
+		// A disabled module call has no instances, and so it evaluates to
+		// null rather than to an object.
+		val, ok := moduleInstances[addrs.NoKey]
+		if ok && !deletedInstances[addrs.NoKey] {
+			ret = cty.ObjectVal(val)
+		} else {
+			ret = cty.NullVal(cty.DynamicPseudoType)
+		}
+
 	default:
 		val, ok := moduleInstances[addrs.NoKey]
 		if !ok {
@@ -683,6 +721,10 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 	}
 	ty := schema.ImpliedType()
 
+	if addr.Mode == addrs.EphemeralResourceMode {
+		return d.getEphemeralResource(addr, config, ty), diags
+	}
+
 	rs := d.Evaluator.State.Resource(addr.Absolute(d.ModulePath))
 
 	if rs == nil {
@@ -696,6 +738,8 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 				return cty.EmptyTupleVal, diags
 			case config.ForEach != nil:
 				return cty.EmptyObjectVal, diags
+			case config.Enabled != nil:
+				return cty.NullVal(ty), diags
 			default:
 				// While we can reference an expanded resource with 0
 				// instances, we cannot reference instances that do not exist.
@@ -831,6 +875,13 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 		instances[key] = val
 	}
 
+	return resourceValueFromInstances(config, instances, ty), diags
+}
+
+// resourceValueFromInstances aggregates the values of the given resource
+// instances into the value that represents the whole resource, based on
+// whether the resource uses count, for_each, enabled, or none of them.
+func resourceValueFromInstances(config *configs.Resource, instances map[addrs.InstanceKey]cty.Value, ty cty.Type) cty.Value {
 	// ret should be populated with a valid value in all cases below
 	var ret cty.Value
 
@@ -892,6 +943,15 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 			ret = cty.EmptyObjectVal
 		}
 
+	case config.Enabled != nil:
+		val, ok := instances[addrs.NoKey]
+		if !ok {
+			// a disabled resource has no instance, and so is null
+			val = cty.NullVal(ty)
+		}
+
+		ret = val
+
 	default:
 		val, ok := instances[addrs.NoKey]
 		if !ok {
@@ -902,7 +962,28 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 		ret = val
 	}
 
-	return ret, diags
+	return ret
+}
+
+// getEphemeralResource returns the value of an ephemeral resource, which is
+// never saved in the state and so must instead be read from the ephemeral
+// resources opened so far during the graph walk.
+func (d *evaluationStateData) getEphemeralResource(addr addrs.Resource, config *configs.Resource, ty cty.Type) cty.Value {
+	var instances map[addrs.InstanceKey]cty.Value
+	expanded := false
+	if d.Evaluator.EphemeralResources != nil {
+		instances, expanded = d.Evaluator.EphemeralResources.get(addr.Absolute(d.ModulePath))
+	}
+	if !expanded {
+		// The resource has not been opened yet, which is always the case
+		// during the validate walk.
+		if config.Count != nil || config.ForEach != nil {
+			return cty.DynamicVal.Mark(marks.Ephemeral)
+		}
+		return cty.UnknownVal(ty).Mark(marks.Ephemeral)
+	}
+
+	return resourceValueFromInstances(config, instances, ty).Mark(marks.Ephemeral)
 }
 
 func (d *evaluationStateData) getResourceSchema(addr addrs.Resource, providerAddr addrs.Provider) *configschema.Block {
@@ -201,6 +201,8 @@ func (d *evaluationStateData) staticValidateResourceReference(modCfg *configs.Co
 		modeAdjective = "managed"
 	case addrs.DataResourceMode:
 		modeAdjective = "data"
+	case addrs.EphemeralResourceMode:
+		modeAdjective = "ephemeral"
 	default:
 		// should never happen
 		modeAdjective = "<invalid-mode>"
@@ -314,9 +316,37 @@ func (d *evaluationStateData) staticValidateModuleCallReference(modCfg *configs.
 		return diags
 	}
 
+	// Every other walk is preceded by a validate walk, so we only need to
+	// warn about reading a deprecated output once.
+	if d.Operation == walkValidate && len(remain) > 0 {
+		diags = diags.Append(staticValidateModuleOutputDeprecation(modCfg.Children[addr.Name], addr, remain[0], rng))
+	}
+
 	return diags
 }
 
+// staticValidateModuleOutputDeprecation returns a warning if the given
+// traversal step refers to a deprecated output of the given module call.
+func staticValidateModuleOutputDeprecation(callCfg *configs.Config, addr addrs.ModuleCall, step hcl.Traverser, rng tfdiags.SourceRange) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	attr, ok := step.(hcl.TraverseAttr)
+	if !ok || callCfg == nil {
+		return diags
+	}
+	output, exists := callCfg.Module.Outputs[attr.Name]
+	if !exists || output.Deprecated == "" {
+		return diags
+	}
+
+	return diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Deprecated output",
+		Detail:   fmt.Sprintf("The output %q of module %q is deprecated: %s", attr.Name, addr.Name, output.Deprecated),
+		Subject:  rng.ToHCL().Ptr(),
+	})
+}
+
 // moduleConfigDisplayAddr returns a string describing the given module
 // address that is appropriate for returning to users in situations where the
 // root module is possible. Specifically, it returns "the root module" if the
@@ -109,6 +109,11 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 			Config:   b.Config,
 		},
 
+		// Creates all the ephemeral resources represented in the config,
+		// which are opened again during apply since they are never saved in
+		// the plan.
+		&ephemeralResourceTransformer{Config: b.Config},
+
 		// Add dynamic values
 		&RootVariableTransformer{Config: b.Config, RawValues: b.RootVariableValues},
 		&ModuleVariableTransformer{Config: b.Config},
@@ -203,6 +208,9 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 		// Close opened plugin connections
 		&CloseProviderTransformer{},
 
+		// Close opened ephemeral resources
+		&ephemeralResourceCloseTransformer{},
+
 		// close the root module
 		&CloseRootModuleTransformer{
 			RootConfig: b.Config,
@@ -11,6 +11,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -86,9 +87,9 @@ type PlanGraphBuilder struct {
 	// ImportTargets are the list of resources to import.
 	ImportTargets []*ImportTarget
 
-	// EndpointsToRemove are the list of resources and modules to forget from
-	// the state.
-	EndpointsToRemove []addrs.ConfigRemovable
+	// EndpointsToRemove are the removed statements declaring resources and
+	// modules to forget from the state or destroy.
+	EndpointsToRemove []*refactoring.RemoveStatement
 
 	// GenerateConfig tells OpenTofu where to write and generated config for
 	// any import targets that do not already have configuration.
@@ -123,6 +124,13 @@ func (b *PlanGraphBuilder) Steps() []GraphTransformer {
 		panic("invalid plan operation: " + b.Operation.String())
 	}
 
+	// Ephemeral resources are only validated during the validate walk, and
+	// are otherwise opened for use by the rest of the graph.
+	var concreteEphemeral ConcreteResourceNodeFunc
+	if b.Operation == walkValidate {
+		concreteEphemeral = b.ConcreteResource
+	}
+
 	steps := []GraphTransformer{
 		// Creates all the resources represented in the config
 		&ConfigTransformer{
@@ -138,6 +146,12 @@ func (b *PlanGraphBuilder) Steps() []GraphTransformer {
 			generateConfigPathForImportTargets: b.GenerateConfigPath,
 		},
 
+		// Creates all the ephemeral resources represented in the config
+		&ephemeralResourceTransformer{
+			Concrete: concreteEphemeral,
+			Config:   b.Config,
+		},
+
 		// Add dynamic values
 		&RootVariableTransformer{Config: b.Config, RawValues: b.RootVariableValues},
 		&ModuleVariableTransformer{Config: b.Config},
@@ -248,6 +262,9 @@ func (b *PlanGraphBuilder) Steps() []GraphTransformer {
 		// Close opened plugin connections
 		&CloseProviderTransformer{},
 
+		// Close opened ephemeral resources
+		&ephemeralResourceCloseTransformer{},
+
 		// Close the root module
 		&CloseRootModuleTransformer{
 			RootConfig: b.Config,
@@ -64,6 +64,8 @@ type ContextGraphWalker struct {
 
 	provisionerLock  sync.Mutex
 	provisionerCache map[string]provisioners.Interface
+
+	ephemeralResources *ephemeralResources
 }
 
 func (w *ContextGraphWalker) EnterPath(path addrs.ModuleInstance) EvalContext {
@@ -97,6 +99,7 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		VariableValues:     w.variableValues,
 		VariableValuesLock: &w.variableValuesLock,
 		PlanTimestamp:      w.PlanTimestamp,
+		EphemeralResources: w.ephemeralResources,
 	}
 
 	ctx := &BuiltinEvalContext{
@@ -114,6 +117,7 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		ProvisionerLock:         &w.provisionerLock,
 		ChangesValue:            w.Changes,
 		ChecksValue:             w.Checks,
+		EphemeralResourcesValue: w.ephemeralResources,
 		StateValue:              w.State,
 		RefreshStateValue:       w.RefreshState,
 		PrevRunStateValue:       w.PrevRunState,
@@ -132,6 +136,7 @@ func (w *ContextGraphWalker) init() {
 	w.providerCache = make(map[string]map[addrs.InstanceKey]providers.Interface)
 	w.provisionerCache = make(map[string]provisioners.Interface)
 	w.variableValues = make(map[string]map[string]cty.Value)
+	w.ephemeralResources = newEphemeralResources()
 
 	// Populate root module variable values. Other modules will be populated
 	// during the graph walk.
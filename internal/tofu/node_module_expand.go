@@ -54,7 +54,7 @@ func (n *nodeExpandModule) References() []*addrs.Reference {
 
 	refs = append(refs, n.DependsOn()...)
 
-	// Expansion only uses the count and for_each expressions, so this
+	// Expansion only uses the count, for_each and enabled expressions, so this
 	// particular graph node only refers to those.
 	// Individual variable values in the module call definition might also
 	// refer to other objects, but that's handled by
@@ -73,6 +73,10 @@ func (n *nodeExpandModule) References() []*addrs.Reference {
 		forEachRefs, _ := lang.ReferencesInExpr(addrs.ParseRef, n.ModuleCall.ForEach)
 		refs = append(refs, forEachRefs...)
 	}
+	if n.ModuleCall.Enabled != nil {
+		enabledRefs, _ := lang.ReferencesInExpr(addrs.ParseRef, n.ModuleCall.Enabled)
+		refs = append(refs, enabledRefs...)
+	}
 
 	for _, passed := range n.ModuleCall.Providers {
 		if passed.InParent.KeyExpression != nil {
@@ -138,6 +142,14 @@ func (n *nodeExpandModule) Execute(ctx EvalContext, op walkOperation) (diags tfd
 			}
 			expander.SetModuleForEach(module, call, forEach)
 
+		case n.ModuleCall.Enabled != nil:
+			enabled, enDiags := evaluateEnabledExpression(n.ModuleCall.Enabled, ctx)
+			diags = diags.Append(enDiags)
+			if diags.HasErrors() {
+				return diags
+			}
+			expander.SetModuleEnabled(module, call, enabled)
+
 		default:
 			expander.SetModuleSingle(module, call)
 		}
@@ -257,7 +269,7 @@ func (n *nodeValidateModule) Execute(ctx EvalContext, op walkOperation) (diags t
 	for _, module := range expander.ExpandModule(n.Addr.Parent()) {
 		ctx = ctx.WithPath(module)
 
-		// Validate our for_each and count expressions at a basic level
+		// Validate our for_each, count and enabled expressions at a basic level
 		// We skip validation on known, because there will be unknown values before
 		// a full expansion, presuming these errors will be caught in later steps
 		switch {
@@ -270,6 +282,10 @@ func (n *nodeValidateModule) Execute(ctx EvalContext, op walkOperation) (diags t
 			const tupleNotAllowed = false
 			_, forEachDiags := evaluateForEachExpressionValue(n.ModuleCall.ForEach, ctx, unknownsAllowed, tupleNotAllowed)
 			diags = diags.Append(forEachDiags)
+
+		case n.ModuleCall.Enabled != nil:
+			_, enabledDiags := evaluateEnabledExpressionValue(n.ModuleCall.Enabled, ctx)
+			diags = diags.Append(enabledDiags)
 		}
 
 		diags = diags.Append(validateDependsOn(ctx, n.ModuleCall.DependsOn))
@@ -191,6 +191,10 @@ func (n *nodeModuleVariable) Execute(ctx EvalContext, op walkOperation) (diags t
 	case walkValidate:
 		val, err = n.evalModuleVariable(ctx, true)
 		diags = diags.Append(err)
+
+		// Every other walk is preceded by a validate walk, so we only need to
+		// warn about setting a deprecated variable here.
+		diags = diags.Append(n.deprecationWarning())
 	default:
 		val, err = n.evalModuleVariable(ctx, false)
 		diags = diags.Append(err)
@@ -204,7 +208,24 @@ func (n *nodeModuleVariable) Execute(ctx EvalContext, op walkOperation) (diags t
 	_, call := n.Addr.Module.CallInstance()
 	ctx.SetModuleCallArgument(call, n.Addr.Variable, val)
 
-	return evalVariableValidations(n.Addr, n.Config, n.Expr, ctx)
+	return diags.Append(evalVariableValidations(n.Addr, n.Config, n.Expr, ctx))
+}
+
+// deprecationWarning returns a warning if the variable is deprecated and the
+// calling module block sets it.
+func (n *nodeModuleVariable) deprecationWarning() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if n.Config == nil || n.Config.Deprecated == "" || n.Expr == nil {
+		return diags
+	}
+
+	_, call := n.Addr.Module.CallInstance()
+	return diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Deprecated variable",
+		Detail:   fmt.Sprintf("The variable %q of module %q is deprecated: %s", n.Addr.Variable.Name, call.Call.Name, n.Config.Deprecated),
+		Subject:  n.Expr.Range().Ptr(),
+	})
 }
 
 // dag.GraphNodeDotter impl.
@@ -385,6 +385,34 @@ If you do intend to export this data, annotate the output value as sensitive by
 					Subject: n.Config.DeclRange.Ptr(),
 				})
 			}
+			// Root module outputs are always persisted in the state, so
+			// they can never be ephemeral.
+			if n.Config.Ephemeral {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Ephemeral output not allowed",
+					Detail:   "Ephemeral outputs are not allowed in the root module, because root module output values are persisted in the state. Remove the ephemeral argument, or declare this output in a child module instead.",
+					Subject:  n.Config.DeclRange.Ptr(),
+				})
+			}
+		}
+		// Similarly to sensitive values above, an output value must be
+		// statically declared as ephemeral in order to return a value
+		// derived from ephemeral values, so that the module's callers can
+		// rely on the declaration to know whether the output can be persisted.
+		if !n.Config.Ephemeral && marks.Contains(val, marks.Ephemeral) {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Output refers to ephemeral values",
+				Detail: `OpenTofu never persists ephemeral values in the state or plan, so any output value containing ephemeral data must be explicitly marked as ephemeral, to confirm your intent.
+
+If you do intend to export this data, annotate the output value as ephemeral by adding the following argument:
+    ephemeral = true`,
+				Subject: n.Config.DeclRange.Ptr(),
+			})
+		}
+		if n.Config.Ephemeral {
+			val = val.Mark(marks.Ephemeral)
 		}
 	}
 
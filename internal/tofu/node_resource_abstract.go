@@ -66,6 +66,11 @@ type NodeAbstractResource struct {
 
 	ProvisionerSchemas map[string]*configschema.Block
 
+	// removedProvisioners are the destroy-time provisioners declared in a
+	// removed block for this resource, which are used in place of those
+	// from Config once the resource block itself has been deleted.
+	removedProvisioners []*configs.Provisioner
+
 	// Set from GraphNodeTargetable
 	Targets []addrs.Targetable
 
@@ -99,6 +104,7 @@ var (
 	_ GraphNodeProvisionerConsumer         = (*NodeAbstractResource)(nil)
 	_ GraphNodeConfigResource              = (*NodeAbstractResource)(nil)
 	_ GraphNodeAttachResourceConfig        = (*NodeAbstractResource)(nil)
+	_ graphNodeAttachRemovedProvisioners   = (*NodeAbstractResource)(nil)
 	_ GraphNodeAttachResourceSchema        = (*NodeAbstractResource)(nil)
 	_ GraphNodeAttachProvisionerSchema     = (*NodeAbstractResource)(nil)
 	_ GraphNodeAttachProviderMetaConfigs   = (*NodeAbstractResource)(nil)
@@ -164,6 +170,8 @@ func (n *NodeAbstractResource) References() []*addrs.Reference {
 		result = append(result, refs...)
 		refs, _ = lang.ReferencesInExpr(addrs.ParseRef, c.ForEach)
 		result = append(result, refs...)
+		refs, _ = lang.ReferencesInExpr(addrs.ParseRef, c.Enabled)
+		result = append(result, refs...)
 
 		for _, expr := range c.TriggersReplacement {
 			refs, _ = lang.ReferencesInExpr(addrs.ParseRef, expr)
@@ -390,21 +398,30 @@ func (n *NodeAbstractResource) Provider() addrs.Provider {
 
 // GraphNodeProvisionerConsumer
 func (n *NodeAbstractResource) ProvisionedBy() []string {
-	// If we have no configuration, then we have no provisioners
-	if n.Config == nil || n.Config.Managed == nil {
-		return nil
-	}
+	provs := n.provisioners()
 
 	// Build the list of provisioners we need based on the configuration.
 	// It is okay to have duplicates here.
-	result := make([]string, len(n.Config.Managed.Provisioners))
-	for i, p := range n.Config.Managed.Provisioners {
+	result := make([]string, len(provs))
+	for i, p := range provs {
 		result[i] = p.Type
 	}
 
 	return result
 }
 
+// provisioners returns the provisioners declared for this resource, either
+// in its resource block or, if that has been deleted, in a removed block.
+func (n *NodeAbstractResource) provisioners() []*configs.Provisioner {
+	if n.Config == nil {
+		return n.removedProvisioners
+	}
+	if n.Config.Managed == nil {
+		return nil
+	}
+	return n.Config.Managed.Provisioners
+}
+
 // GraphNodeProvisionerConsumer
 func (n *NodeAbstractResource) AttachProvisionerSchema(name string, schema *configschema.Block) {
 	if n.ProvisionerSchemas == nil {
@@ -439,6 +456,11 @@ func (n *NodeAbstractResource) AttachResourceConfig(c *configs.Resource) {
 	n.Config = c
 }
 
+// graphNodeAttachRemovedProvisioners
+func (n *NodeAbstractResource) attachRemovedProvisioners(provs []*configs.Provisioner) {
+	n.removedProvisioners = provs
+}
+
 // GraphNodeAttachResourceSchema impl
 func (n *NodeAbstractResource) AttachResourceSchema(schema *configschema.Block, version uint64) {
 	n.Schema = schema
@@ -501,6 +523,16 @@ func (n *NodeAbstractResource) writeResourceState(ctx EvalContext, addr addrs.Ab
 		state.SetResourceProvider(addr, n.ResolvedProvider.ProviderConfig)
 		expander.SetResourceForEach(addr.Module, n.Addr.Resource, forEach)
 
+	case n.Config != nil && n.Config.Enabled != nil:
+		enabled, enabledDiags := evaluateEnabledExpression(n.Config.Enabled, ctx)
+		diags = diags.Append(enabledDiags)
+		if enabledDiags.HasErrors() {
+			return diags
+		}
+
+		state.SetResourceProvider(addr, n.ResolvedProvider.ProviderConfig)
+		expander.SetResourceEnabled(addr.Module, n.Addr.Resource, enabled)
+
 	default:
 		state.SetResourceProvider(addr, n.ResolvedProvider.ProviderConfig)
 		expander.SetResourceSingle(addr.Module, n.Addr.Resource)
@@ -866,6 +866,10 @@ func (n *NodeAbstractResourceInstance) plan(
 	if configDiags.HasErrors() {
 		return nil, nil, keyData, diags
 	}
+	diags = diags.Append(validateResourceForbiddenEphemeralValues(n.Addr.Resource.Resource.Mode, n.Addr.String(), origConfigVal, config.Config))
+	if diags.HasErrors() {
+		return nil, nil, keyData, diags
+	}
 
 	metaConfigVal, metaDiags := n.providerMetas(ctx)
 	diags = diags.Append(metaDiags)
@@ -1761,6 +1765,10 @@ func (n *NodeAbstractResourceInstance) planDataSource(ctx EvalContext, checkRule
 	if configDiags.HasErrors() {
 		return nil, nil, keyData, diags
 	}
+	diags = diags.Append(validateResourceForbiddenEphemeralValues(n.Addr.Resource.Resource.Mode, n.Addr.String(), configVal, config.Config))
+	if diags.HasErrors() {
+		return nil, nil, keyData, diags
+	}
 
 	check, nested := n.nestedInCheckBlock()
 	if nested {
@@ -2115,7 +2123,7 @@ func (n *NodeAbstractResourceInstance) evalApplyProvisioners(ctx EvalContext, st
 		return nil
 	}
 
-	provs := filterProvisioners(n.Config, when)
+	provs := filterProvisioners(n.provisioners(), when)
 	if len(provs) == 0 {
 		// We have no provisioners, so don't do anything
 		return nil
@@ -2145,18 +2153,14 @@ func (n *NodeAbstractResourceInstance) evalApplyProvisioners(ctx EvalContext, st
 
 // filterProvisioners filters the provisioners on the resource to only
 // the provisioners specified by the "when" option.
-func filterProvisioners(config *configs.Resource, when configs.ProvisionerWhen) []*configs.Provisioner {
+func filterProvisioners(provs []*configs.Provisioner, when configs.ProvisionerWhen) []*configs.Provisioner {
 	// Fast path the zero case
-	if config == nil || config.Managed == nil {
-		return nil
-	}
-
-	if len(config.Managed.Provisioners) == 0 {
+	if len(provs) == 0 {
 		return nil
 	}
 
-	result := make([]*configs.Provisioner, 0, len(config.Managed.Provisioners))
-	for _, p := range config.Managed.Provisioners {
+	result := make([]*configs.Provisioner, 0, len(provs))
+	for _, p := range provs {
 		if p.When == when {
 			result = append(result, p)
 		}
@@ -2185,7 +2189,7 @@ func (n *NodeAbstractResourceInstance) applyProvisioners(ctx EvalContext, state
 	// then it'll serve as a base connection configuration for all of the
 	// provisioners.
 	var baseConn hcl.Body
-	if n.Config.Managed != nil && n.Config.Managed.Connection != nil {
+	if n.Config != nil && n.Config.Managed != nil && n.Config.Managed.Connection != nil {
 		baseConn = n.Config.Managed.Connection.Config
 	}
 
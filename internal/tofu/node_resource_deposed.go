@@ -13,6 +13,7 @@ import (
 	"github.com/opentofu/opentofu/internal/dag"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -44,11 +45,12 @@ type NodePlanDeposedResourceInstanceObject struct {
 	// for any instances.
 	skipPlanChanges bool
 
-	// EndpointsToRemove are resource instance addresses where the user wants to
-	// forget from the state. This set isn't pre-filtered, so
-	// it might contain addresses that have nothing to do with the resource
-	// that this node represents, which the node itself must therefore ignore.
-	EndpointsToRemove []addrs.ConfigRemovable
+	// EndpointsToRemove are the removed statements declaring resources and
+	// modules that the user wants to either forget from the state or destroy.
+	// This set isn't pre-filtered, so it might contain statements that have
+	// nothing to do with the resource that this node represents, which the
+	// node itself must therefore ignore.
+	EndpointsToRemove []*refactoring.RemoveStatement
 }
 
 var (
@@ -140,15 +142,7 @@ func (n *NodePlanDeposedResourceInstanceObject) Execute(ctx EvalContext, op walk
 		var change *plans.ResourceInstanceChange
 		var planDiags tfdiags.Diagnostics
 
-		shouldForget := false
-
-		for _, etf := range n.EndpointsToRemove {
-			if etf.TargetContains(n.Addr) {
-				shouldForget = true
-			}
-		}
-
-		if shouldForget {
+		if shouldForget(n.EndpointsToRemove, n.Addr) {
 			change = n.planForget(ctx, state, n.DeposedKey)
 		} else {
 			change, planDiags = n.planDestroy(ctx, state, n.DeposedKey)
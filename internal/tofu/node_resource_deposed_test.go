@@ -12,6 +12,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -20,70 +21,84 @@ func TestNodePlanDeposedResourceInstanceObject_Execute(t *testing.T) {
 	tests := []struct {
 		description           string
 		nodeAddress           string
-		nodeEndpointsToRemove []addrs.ConfigRemovable
+		nodeEndpointsToRemove []*refactoring.RemoveStatement
 		wantAction            plans.Action
 	}{
 		{
 			nodeAddress:           "test_instance.foo",
-			nodeEndpointsToRemove: make([]addrs.ConfigRemovable, 0),
+			nodeEndpointsToRemove: make([]*refactoring.RemoveStatement, 0),
 			wantAction:            plans.Delete,
 		},
 		{
 			nodeAddress: "test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("test_instance.bar")).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("test_instance.bar")},
 			},
 			wantAction: plans.Delete,
 		},
 		{
 			nodeAddress: "test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(addrs.Module{"boop"}).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: addrs.Module{"boop"}},
 			},
 			wantAction: plans.Delete,
 		},
 		{
 			nodeAddress: "test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("test_instance.foo")).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("test_instance.foo")},
 			},
 			wantAction: plans.Forget,
 		},
 		{
 			nodeAddress: "test_instance.foo[1]",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("test_instance.foo")).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("test_instance.foo")},
 			},
 			wantAction: plans.Forget,
 		},
 		{
 			nodeAddress: "module.boop.test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("module.boop.test_instance.foo")).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("module.boop.test_instance.foo")},
 			},
 			wantAction: plans.Forget,
 		},
 		{
 			nodeAddress: "module.boop[1].test_instance.foo[1]",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(mustConfigResourceAddr("module.boop.test_instance.foo")).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("module.boop.test_instance.foo")},
 			},
 			wantAction: plans.Forget,
 		},
 		{
 			nodeAddress: "module.boop.test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(addrs.Module{"boop"}).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: addrs.Module{"boop"}},
 			},
 			wantAction: plans.Forget,
 		},
 		{
 			nodeAddress: "module.boop[1].test_instance.foo",
-			nodeEndpointsToRemove: []addrs.ConfigRemovable{
-				interface{}(addrs.Module{"boop"}).(addrs.ConfigRemovable),
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: addrs.Module{"boop"}},
 			},
 			wantAction: plans.Forget,
 		},
+		{
+			nodeAddress: "test_instance.foo",
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: mustConfigResourceAddr("test_instance.foo"), Destroy: true},
+			},
+			wantAction: plans.Delete,
+		},
+		{
+			nodeAddress: "module.boop.test_instance.foo",
+			nodeEndpointsToRemove: []*refactoring.RemoveStatement{
+				{From: addrs.Module{"boop"}, Destroy: true},
+			},
+			wantAction: plans.Delete,
+		},
 	}
 
 	for _, test := range tests {
@@ -113,13 +113,13 @@ func (n *NodeDestroyResourceInstance) ReferenceableAddrs() []addrs.Referenceable
 
 // GraphNodeReferencer, overriding NodeAbstractResource
 func (n *NodeDestroyResourceInstance) References() []*addrs.Reference {
-	// If we have a config, then we need to include destroy-time dependencies
-	if c := n.Config; c != nil && c.Managed != nil {
+	// If we have provisioners, then we need to include destroy-time dependencies
+	if provs := n.provisioners(); len(provs) != 0 {
 		var result []*addrs.Reference
 
 		// We include conn info and config for destroy time provisioners
 		// as dependencies that we have.
-		for _, p := range c.Managed.Provisioners {
+		for _, p := range provs {
 			schema := n.ProvisionerSchemas[p.Type]
 
 			if p.When == configs.ProvisionerWhenDestroy {
@@ -0,0 +1,388 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// nodeExpandEphemeralResource represents an ephemeral resource in the
+// configuration, and implements DynamicExpand to a subgraph containing one
+// node for each of its instances across all instances of its module.
+//
+// Unlike managed resources and data sources, ephemeral resources never
+// appear in the state or the plan: each graph walk that needs them opens them
+// anew, and closes them again once nothing else needs their value.
+type nodeExpandEphemeralResource struct {
+	*NodeAbstractResource
+}
+
+var (
+	_ GraphNodeDynamicExpandable    = (*nodeExpandEphemeralResource)(nil)
+	_ GraphNodeReferenceable        = (*nodeExpandEphemeralResource)(nil)
+	_ GraphNodeReferencer           = (*nodeExpandEphemeralResource)(nil)
+	_ GraphNodeConfigResource       = (*nodeExpandEphemeralResource)(nil)
+	_ GraphNodeAttachResourceConfig = (*nodeExpandEphemeralResource)(nil)
+	_ GraphNodeTargetable           = (*nodeExpandEphemeralResource)(nil)
+)
+
+func (n *nodeExpandEphemeralResource) Name() string {
+	return n.NodeAbstractResource.Name() + " (expand)"
+}
+
+func (n *nodeExpandEphemeralResource) DynamicExpand(ctx EvalContext) (*Graph, error) {
+	var g Graph
+	var diags tfdiags.Diagnostics
+
+	expander := ctx.InstanceExpander()
+	instAddrs := addrs.MakeSet[addrs.Checkable]()
+	for _, module := range expander.ExpandModule(n.Addr.Module) {
+		resAddr := n.Addr.Resource.Absolute(module)
+		moduleCtx := ctx.WithPath(module)
+
+		// Ephemeral resources have no state to update, so we only need to
+		// record the expansion decision for the instances below and for
+		// expression evaluation.
+		switch {
+		case n.Config.Count != nil:
+			count, countDiags := evaluateCountExpression(n.Config.Count, moduleCtx)
+			diags = diags.Append(countDiags)
+			if countDiags.HasErrors() {
+				continue
+			}
+			expander.SetResourceCount(module, n.Addr.Resource, count)
+
+		case n.Config.ForEach != nil:
+			forEach, forEachDiags := evaluateForEachExpression(n.Config.ForEach, moduleCtx)
+			diags = diags.Append(forEachDiags)
+			if forEachDiags.HasErrors() {
+				continue
+			}
+			expander.SetResourceForEach(module, n.Addr.Resource, forEach)
+
+		default:
+			expander.SetResourceSingle(module, n.Addr.Resource)
+		}
+
+		for _, addr := range expander.ExpandResource(resAddr) {
+			instAddrs.Add(addr)
+
+			abs := NewNodeAbstractResourceInstance(addr)
+			abs.Config = n.Config
+			abs.ResolvedProvider = n.ResolvedProvider
+			abs.Schema = n.Schema
+			abs.SchemaVersion = n.SchemaVersion
+			g.Add(&nodeEphemeralResourceInstance{
+				NodeAbstractResourceInstance: abs,
+			})
+		}
+
+		ctx.EphemeralResources().setExpanded(resAddr)
+	}
+	if diags.HasErrors() {
+		return nil, diags.ErrWithWarnings()
+	}
+
+	// If this is a resource that participates in custom condition checks
+	// (i.e. it has preconditions or postconditions) then the check state
+	// wants to know the addresses of the checkable objects so that it can
+	// treat them as unknown status if we encounter an error before actually
+	// visiting the checks.
+	if checkState := ctx.Checks(); checkState.ConfigHasChecks(n.NodeAbstractResource.Addr) {
+		checkState.ReportCheckableObjects(n.NodeAbstractResource.Addr, instAddrs)
+	}
+
+	addRootNodeToGraph(&g)
+
+	return &g, diags.ErrWithWarnings()
+}
+
+// nodeEphemeralResourceInstance represents a single instance of an ephemeral
+// resource, which it opens when executed.
+type nodeEphemeralResourceInstance struct {
+	*NodeAbstractResourceInstance
+}
+
+var (
+	_ GraphNodeModuleInstance   = (*nodeEphemeralResourceInstance)(nil)
+	_ GraphNodeConfigResource   = (*nodeEphemeralResourceInstance)(nil)
+	_ GraphNodeResourceInstance = (*nodeEphemeralResourceInstance)(nil)
+	_ GraphNodeExecutable       = (*nodeEphemeralResourceInstance)(nil)
+)
+
+// GraphNodeExecutable
+func (n *nodeEphemeralResourceInstance) Execute(ctx EvalContext, op walkOperation) (diags tfdiags.Diagnostics) {
+	addr := n.ResourceInstanceAddr()
+	config := n.Config
+
+	diags = diags.Append(n.resolveProvider(ctx, true))
+	if diags.HasErrors() {
+		return diags
+	}
+
+	provider, providerSchema, err := n.getProvider(ctx)
+	diags = diags.Append(err)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	schema, _ := providerSchema.SchemaForResourceAddr(addr.ContainingResource().Resource)
+	if schema == nil {
+		// Should be caught during validation, so we don't bother with a pretty error here
+		diags = diags.Append(fmt.Errorf("provider %q does not support ephemeral resource %q", n.ResolvedProvider.ProviderConfig.InstanceString(n.ResolvedProviderKey), addr.ContainingResource().Resource.Type))
+		return diags
+	}
+
+	forEach, _ := evaluateForEachExpression(config.ForEach, ctx)
+	keyData := EvalDataForInstanceKey(addr.Resource.Key, forEach)
+
+	diags = diags.Append(evalCheckRules(
+		addrs.ResourcePrecondition,
+		config.Preconditions,
+		ctx, addr, keyData,
+		tfdiags.Error,
+	))
+	if diags.HasErrors() {
+		return diags // failed preconditions prevent further evaluation
+	}
+
+	configVal, _, configDiags := ctx.EvaluateBlock(config.Config, schema, nil, keyData)
+	diags = diags.Append(configDiags)
+	if configDiags.HasErrors() {
+		return diags
+	}
+
+	// Unmark before sending to provider, will re-mark the result below
+	unmarkedConfigVal, _ := configVal.UnmarkDeepWithPaths()
+
+	ephemerals := ctx.EphemeralResources()
+	if !unmarkedConfigVal.IsWhollyKnown() {
+		// We can't open an ephemeral resource until its configuration is
+		// known, so its result is unknown for now. This can only happen
+		// during planning, since all values are known during apply.
+		log.Printf("[TRACE] nodeEphemeralResourceInstance: %s configuration not fully known yet, so deferring opening it", addr)
+		ephemerals.registerUnknown(addr, cty.UnknownVal(schema.ImpliedType()))
+		return diags
+	}
+
+	log.Printf("[TRACE] nodeEphemeralResourceInstance: opening %s", addr)
+	resp := provider.OpenEphemeralResource(providers.OpenEphemeralResourceRequest{
+		TypeName: addr.ContainingResource().Resource.Type,
+		Config:   unmarkedConfigVal,
+	})
+	diags = diags.Append(resp.Diagnostics.InConfigBody(config.Config, addr.String()))
+	if diags.HasErrors() {
+		return diags
+	}
+
+	newVal := resp.Result
+	if newVal == cty.NilVal {
+		// This can happen with incompletely-configured mocks. We'll allow it
+		// and treat it as an alias for a properly-typed null value.
+		newVal = cty.NullVal(schema.ImpliedType())
+	}
+
+	for _, err := range newVal.Type().TestConformance(schema.ImpliedType()) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Provider produced invalid object",
+			fmt.Sprintf(
+				"Provider %q produced an invalid value for %s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.",
+				n.ResolvedProvider.ProviderConfig.InstanceString(n.ResolvedProviderKey), tfdiags.FormatErrorPrefixed(err, addr.String()),
+			),
+		))
+	}
+	if newVal.IsNull() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Provider produced null object",
+			fmt.Sprintf(
+				"Provider %q produced a null value for %s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.",
+				n.ResolvedProvider.ProviderConfig.InstanceString(n.ResolvedProviderKey), addr,
+			),
+		))
+	}
+	if diags.HasErrors() {
+		// The provider did open the resource, so we must still give it the
+		// chance to close it again.
+		closeResp := provider.CloseEphemeralResource(providers.CloseEphemeralResourceRequest{
+			TypeName: addr.ContainingResource().Resource.Type,
+			Private:  resp.Private,
+		})
+		return diags.Append(closeResp.Diagnostics)
+	}
+
+	if schema.ContainsSensitive() {
+		newVal = newVal.MarkWithPaths(schema.ValueMarks(newVal, nil))
+	}
+	ephemerals.open(addr, provider, newVal, resp.Private, resp.RenewAt)
+
+	diags = diags.Append(evalCheckRules(
+		addrs.ResourcePostcondition,
+		config.Postconditions,
+		ctx, addr, keyData,
+		tfdiags.Error,
+	))
+
+	return diags
+}
+
+// nodeEphemeralResourceClose closes all of the instances of an ephemeral
+// resource once everything that refers to it has completed.
+type nodeEphemeralResourceClose struct {
+	expand *nodeExpandEphemeralResource
+}
+
+var (
+	_ GraphNodeExecutable       = (*nodeEphemeralResourceClose)(nil)
+	_ GraphNodeModulePath       = (*nodeEphemeralResourceClose)(nil)
+	_ GraphNodeProviderConsumer = (*nodeEphemeralResourceClose)(nil)
+)
+
+func (n *nodeEphemeralResourceClose) Name() string {
+	return n.expand.Addr.String() + " (close)"
+}
+
+// GraphNodeModulePath
+func (n *nodeEphemeralResourceClose) ModulePath() addrs.Module {
+	return n.expand.Addr.Module
+}
+
+// GraphNodeProviderConsumer
+func (n *nodeEphemeralResourceClose) ProvidedBy() RequestedProvider {
+	return n.expand.ProvidedBy()
+}
+
+// GraphNodeProviderConsumer
+func (n *nodeEphemeralResourceClose) Provider() addrs.Provider {
+	return n.expand.Provider()
+}
+
+// GraphNodeProviderConsumer
+func (n *nodeEphemeralResourceClose) SetProvider(ResolvedProvider) {
+	// The provider was already resolved for the corresponding expand node.
+}
+
+// GraphNodeExecutable
+func (n *nodeEphemeralResourceClose) Execute(ctx EvalContext, op walkOperation) tfdiags.Diagnostics {
+	return ctx.EphemeralResources().closeResource(n.expand.Addr)
+}
+
+// ephemeralResourceTransformer is a GraphTransformer that adds a node for
+// each ephemeral resource in the configuration, including those in child
+// modules.
+//
+// This is separate from ConfigTransformer because ephemeral resources have
+// no state and so don't take part in any of the transformers that deal with
+// orphans, imports, or destroying.
+type ephemeralResourceTransformer struct {
+	Concrete ConcreteResourceNodeFunc
+	Config   *configs.Config
+}
+
+func (t *ephemeralResourceTransformer) Transform(g *Graph) error {
+	return t.transform(g, t.Config)
+}
+
+func (t *ephemeralResourceTransformer) transform(g *Graph, config *configs.Config) error {
+	// If no config, or the module is being overridden, do nothing
+	if config == nil || config.Module.IsOverridden {
+		return nil
+	}
+
+	for _, r := range config.Module.EphemeralResources {
+		abstract := &NodeAbstractResource{
+			Addr: r.Addr().InModule(config.Path),
+		}
+
+		var node dag.Vertex = &nodeExpandEphemeralResource{
+			NodeAbstractResource: abstract,
+		}
+		if f := t.Concrete; f != nil {
+			node = f(abstract)
+		}
+		g.Add(node)
+	}
+
+	for _, c := range config.Children {
+		if err := t.transform(g, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ephemeralResourceCloseTransformer is a GraphTransformer that adds a close
+// node for each ephemeral resource in the graph. The close node depends on
+// everything that depends on the ephemeral resource, so that it is closed
+// only once its value is no longer needed, and providers are closed only
+// after all of their ephemeral resources have been closed.
+//
+// This must run after CloseProviderTransformer, so that providers whose
+// configuration refers to an ephemeral resource are closed before it.
+type ephemeralResourceCloseTransformer struct{}
+
+func (t *ephemeralResourceCloseTransformer) Transform(g *Graph) error {
+	closers := make(map[string]*graphNodeCloseProvider)
+	for _, v := range g.Vertices() {
+		if closer, ok := v.(*graphNodeCloseProvider); ok {
+			closers[closer.Addr.String()] = closer
+		}
+	}
+
+	for _, v := range g.Vertices() {
+		expand, ok := v.(*nodeExpandEphemeralResource)
+		if !ok {
+			continue
+		}
+
+		node := &nodeEphemeralResourceClose{expand: expand}
+		log.Printf("[TRACE] ephemeralResourceCloseTransformer: adding %s", dag.VertexName(node))
+		g.Add(node)
+		g.Connect(dag.BasicEdge(node, expand))
+
+		// The ephemeral resource's own provider must stay open until the
+		// resource has been closed.
+		for _, dep := range g.DownEdges(expand) {
+			if p, ok := dep.(GraphNodeProvider); ok {
+				if closer := closers[p.ProviderAddr().String()]; closer != nil {
+					g.Connect(dag.BasicEdge(closer, node))
+				}
+			}
+		}
+
+		des, err := g.Descendents(expand)
+		if err != nil {
+			return err
+		}
+		for _, d := range des {
+			switch d := d.(type) {
+			case *graphNodeCloseProvider, *nodeEphemeralResourceClose:
+				// Closers are connected explicitly above and below.
+				continue
+			case GraphNodeProvider:
+				// A provider configured using this ephemeral resource must
+				// be closed before the ephemeral resource.
+				if closer := closers[d.ProviderAddr().String()]; closer != nil {
+					g.Connect(dag.BasicEdge(node, closer))
+				}
+			}
+			g.Connect(dag.BasicEdge(node, d))
+		}
+	}
+
+	return nil
+}
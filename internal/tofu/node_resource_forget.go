@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 
 	"github.com/opentofu/opentofu/internal/states"
@@ -73,3 +75,16 @@ func (n *NodeForgetResourceInstance) Execute(ctx EvalContext, op walkOperation)
 
 	return diags
 }
+
+// shouldForget returns true if the given resource instance is matched by a
+// removed statement that only forgets its objects, rather than destroying
+// them. If the instance is matched by several statements then forgetting
+// wins, since that is the safer choice.
+func shouldForget(stmts []*refactoring.RemoveStatement, addr addrs.AbsResourceInstance) bool {
+	for _, rs := range stmts {
+		if rs.From.TargetContains(addr) && !rs.Destroy {
+			return true
+		}
+	}
+	return false
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -27,11 +28,12 @@ type NodePlannableResourceInstanceOrphan struct {
 	// for any instances.
 	skipPlanChanges bool
 
-	// EndpointsToRemove are resource instance addresses where the user wants to
-	// forget from the state. This set isn't pre-filtered, so
-	// it might contain addresses that have nothing to do with the resource
-	// that this node represents, which the node itself must therefore ignore.
-	EndpointsToRemove []addrs.ConfigRemovable
+	// EndpointsToRemove are the removed statements declaring resources and
+	// modules that the user wants to either forget from the state or destroy.
+	// This set isn't pre-filtered, so it might contain statements that have
+	// nothing to do with the resource that this node represents, which the
+	// node itself must therefore ignore.
+	EndpointsToRemove []*refactoring.RemoveStatement
 }
 
 var (
@@ -147,15 +149,7 @@ func (n *NodePlannableResourceInstanceOrphan) managedResourceExecute(ctx EvalCon
 	var change *plans.ResourceInstanceChange
 	var planDiags tfdiags.Diagnostics
 
-	shouldForget := false
-
-	for _, etf := range n.EndpointsToRemove {
-		if etf.TargetContains(n.Addr) {
-			shouldForget = true
-		}
-	}
-
-	if shouldForget {
+	if shouldForget(n.EndpointsToRemove, n.Addr) {
 		change = n.planForget(ctx, oldState, "")
 	} else {
 		change, planDiags = n.planDestroy(ctx, oldState, "")
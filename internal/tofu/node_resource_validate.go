@@ -313,6 +313,13 @@ func (n *NodeValidatableResource) validateResource(ctx EvalContext) tfdiags.Diag
 		// Evaluate the for_each expression here so we can expose the diagnostics
 		forEachDiags := validateForEach(ctx, n.Config.ForEach)
 		diags = diags.Append(forEachDiags)
+
+	case n.Config.Enabled != nil:
+		// Basic type-checking of the enabled argument. More complete
+		// validation of this will happen when we DynamicExpand during the
+		// plan walk.
+		enabledDiags := validateEnabled(ctx, n.Config.Enabled)
+		diags = diags.Append(enabledDiags)
 	}
 
 	diags = diags.Append(validateDependsOn(ctx, n.Config.DependsOn))
@@ -385,6 +392,7 @@ func (n *NodeValidatableResource) validateResource(ctx EvalContext) tfdiags.Diag
 		if valDiags.HasErrors() {
 			return diags
 		}
+		diags = diags.Append(validateResourceForbiddenEphemeralValues(n.Config.Mode, n.Addr.String(), configVal, n.Config.Config))
 
 		if n.Config.Managed != nil { // can be nil only in tests with poorly-configured mocks
 			for _, traversal := range n.Config.Managed.IgnoreChanges {
@@ -459,6 +467,7 @@ func (n *NodeValidatableResource) validateResource(ctx EvalContext) tfdiags.Diag
 		if valDiags.HasErrors() {
 			return diags
 		}
+		diags = diags.Append(validateResourceForbiddenEphemeralValues(n.Config.Mode, n.Addr.String(), configVal, n.Config.Config))
 
 		// Use unmarked value for validate request
 		unmarkedConfigVal, _ := configVal.UnmarkDeep()
@@ -469,6 +478,36 @@ func (n *NodeValidatableResource) validateResource(ctx EvalContext) tfdiags.Diag
 
 		resp := provider.ValidateDataResourceConfig(req)
 		diags = diags.Append(resp.Diagnostics.InConfigBody(n.Config.Config, n.Addr.String()))
+
+	case addrs.EphemeralResourceMode:
+		schema, _ := providerSchema.SchemaForResourceType(n.Config.Mode, n.Config.Type)
+		if schema == nil {
+			var suggestion string
+			if len(providerSchema.EphemeralResources) > 0 {
+				suggestions := make([]string, 0, len(providerSchema.EphemeralResources))
+				for name := range providerSchema.EphemeralResources {
+					suggestions = append(suggestions, name)
+				}
+				if suggestion = didyoumean.NameSuggestion(n.Config.Type, suggestions); suggestion != "" {
+					suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
+				}
+			}
+
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid ephemeral resource type",
+				Detail:   fmt.Sprintf("The provider %s does not support ephemeral resource type %q.%s", n.Provider().ForDisplay(), n.Config.Type, suggestion),
+				Subject:  &n.Config.TypeRange,
+			})
+			return diags
+		}
+
+		// The provider protocol has no separate validation call for
+		// ephemeral resources, so we can only check the configuration
+		// against the schema here. The provider gets its chance to object
+		// when the resource is opened during plan and apply.
+		_, _, valDiags := ctx.EvaluateBlock(n.Config.Config, schema, nil, keyData)
+		diags = diags.Append(valDiags)
 	}
 
 	return diags
@@ -564,6 +603,21 @@ func validateCount(ctx EvalContext, expr hcl.Expression) (diags tfdiags.Diagnost
 	return diags
 }
 
+func validateEnabled(ctx EvalContext, expr hcl.Expression) (diags tfdiags.Diagnostics) {
+	val, enabledDiags := evaluateEnabledExpressionValue(expr, ctx)
+	// If the value isn't known then that's the best we can do for now, but
+	// we'll check more thoroughly during the plan walk
+	if !val.IsKnown() {
+		return diags
+	}
+
+	if enabledDiags.HasErrors() {
+		diags = diags.Append(enabledDiags)
+	}
+
+	return diags
+}
+
 func validateForEach(ctx EvalContext, expr hcl.Expression) (diags tfdiags.Diagnostics) {
 	const unknownsAllowed = true
 	const tupleNotAllowed = false
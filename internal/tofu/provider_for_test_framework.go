@@ -103,6 +103,28 @@ func (p *providerForTest) ReadDataSource(r providers.ReadDataSourceRequest) prov
 	return resp
 }
 
+func (p *providerForTest) OpenEphemeralResource(r providers.OpenEphemeralResourceRequest) providers.OpenEphemeralResourceResponse {
+	resSchema, _ := p.schema.SchemaForResourceType(addrs.EphemeralResourceMode, r.TypeName)
+
+	var resp providers.OpenEphemeralResourceResponse
+
+	resp.Result, resp.Diagnostics = newMockValueComposer(r.TypeName).
+		ComposeBySchema(resSchema, r.Config, nil)
+
+	return resp
+}
+
+// RenewEphemeralResource is irrelevant when provider is mocked or overridden,
+// because the mocked result never expires.
+func (p *providerForTest) RenewEphemeralResource(_ providers.RenewEphemeralResourceRequest) providers.RenewEphemeralResourceResponse {
+	return providers.RenewEphemeralResourceResponse{}
+}
+
+// CloseEphemeralResource is irrelevant when provider is mocked or overridden.
+func (p *providerForTest) CloseEphemeralResource(_ providers.CloseEphemeralResourceRequest) providers.CloseEphemeralResourceResponse {
+	return providers.CloseEphemeralResourceResponse{}
+}
+
 // ValidateProviderConfig is irrelevant when provider is mocked or overridden.
 func (p *providerForTest) ValidateProviderConfig(_ providers.ValidateProviderConfigRequest) providers.ValidateProviderConfigResponse {
 	return providers.ValidateProviderConfigResponse{}
@@ -78,6 +78,9 @@ func simpleMockProvider() *MockProvider {
 			DataSources: map[string]providers.Schema{
 				"test_object": providers.Schema{Block: simpleTestSchema()},
 			},
+			EphemeralResources: map[string]providers.Schema{
+				"test_object": providers.Schema{Block: simpleTestSchema()},
+			},
 		},
 	}
 }
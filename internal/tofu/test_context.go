@@ -139,10 +139,11 @@ func (ctx *TestContext) evaluate(state *states.SyncState, changes *plans.Changes
 	}
 
 	scope := &lang.Scope{
-		Data:          data,
-		BaseDir:       ".",
-		PureOnly:      operation != walkApply,
-		PlanTimestamp: ctx.Plan.Timestamp,
+		Data:            data,
+		BaseDir:         ".",
+		PureOnly:        operation != walkApply,
+		PlanTimestamp:   ctx.Plan.Timestamp,
+		ModuleFunctions: ctx.Config.Module.ScopeFunctions(),
 		ProviderFunctions: func(pf addrs.ProviderFunction, rng tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics) {
 			// This is a simpler flow than what is allowed during normal exection.
 			// We only support non-configured functions here.
@@ -11,6 +11,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/refactoring"
 )
 
 // GraphNodeAttachResourceConfig is an interface that must be implemented by nodes
@@ -22,6 +23,15 @@ type GraphNodeAttachResourceConfig interface {
 	AttachResourceConfig(*configs.Resource)
 }
 
+// graphNodeAttachRemovedProvisioners is an interface implemented by nodes
+// that can run the destroy-time provisioners declared in a removed block for
+// a resource that no longer has a resource block.
+type graphNodeAttachRemovedProvisioners interface {
+	GraphNodeConfigResource
+
+	attachRemovedProvisioners([]*configs.Provisioner)
+}
+
 // AttachResourceConfigTransformer goes through the graph and attaches
 // resource configuration structures to nodes that implement
 // GraphNodeAttachManagedResourceConfig or GraphNodeAttachDataResourceConfig.
@@ -33,6 +43,7 @@ type AttachResourceConfigTransformer struct {
 }
 
 func (t *AttachResourceConfigTransformer) Transform(g *Graph) error {
+	removeStmts := refactoring.FindRemoveStatements(t.Config)
 
 	// Go through and find GraphNodeAttachResource
 	for _, v := range g.Vertices() {
@@ -49,6 +60,7 @@ func (t *AttachResourceConfigTransformer) Transform(g *Graph) error {
 		config := t.Config.Descendent(addr.Module)
 		if config == nil {
 			log.Printf("[TRACE] AttachResourceConfigTransformer: %q (%T) has no configuration available", dag.VertexName(v), v)
+			t.attachRemovedProvisioners(v, removeStmts)
 			continue
 		}
 		var m map[string]*configs.Resource
@@ -56,6 +68,8 @@ func (t *AttachResourceConfigTransformer) Transform(g *Graph) error {
 			m = config.Module.ManagedResources
 		} else if addr.Resource.Mode == addrs.DataResourceMode {
 			m = config.Module.DataResources
+		} else if addr.Resource.Mode == addrs.EphemeralResourceMode {
+			m = config.Module.EphemeralResources
 		} else {
 			panic("unknown resource mode: " + addr.Resource.Mode.String())
 		}
@@ -71,8 +85,30 @@ func (t *AttachResourceConfigTransformer) Transform(g *Graph) error {
 					log.Printf("[TRACE] AttachResourceConfigTransformer: no provider meta configs available to attach to %s", dag.VertexName(v))
 				}
 			}
+		} else {
+			t.attachRemovedProvisioners(v, removeStmts)
 		}
 	}
 
 	return nil
 }
+
+// attachRemovedProvisioners attaches the provisioners from any removed
+// statement that destroys the resource represented by the given vertex.
+func (t *AttachResourceConfigTransformer) attachRemovedProvisioners(v dag.Vertex, removeStmts []*refactoring.RemoveStatement) {
+	arn, ok := v.(graphNodeAttachRemovedProvisioners)
+	if !ok {
+		return
+	}
+	addr := arn.ResourceAddr()
+	for _, rs := range removeStmts {
+		if !rs.Destroy || len(rs.Provisioners) == 0 {
+			continue
+		}
+		if from, ok := rs.From.(addrs.ConfigResource); ok && from.Equal(addr) {
+			log.Printf("[TRACE] AttachResourceConfigTransformer: attaching removed block provisioners to %q (%T) from %s", dag.VertexName(v), v, rs.DeclRange.ToHCL())
+			arn.attachRemovedProvisioners(rs.Provisioners)
+			return
+		}
+	}
+}
@@ -55,6 +55,11 @@ var protocSteps = []protocStep{
 		"internal/tfplugin6",
 		[]string{"--go_out=paths=source_relative,plugins=grpc:.", "./tfplugin6.proto"},
 	},
+	{
+		"tfbackend1 (backend plugin wire protocol version 1)",
+		"internal/tfbackend1",
+		[]string{"--go_out=paths=source_relative,plugins=grpc:.", "./tfbackend1.proto"},
+	},
 	{
 		"tfplan (plan file serialization)",
 		"internal/plans/internal/planproto",
@@ -0,0 +1,8 @@
+terraform {
+  encryption {
+    key_provider "azure_keyvault" "basic" {
+      vault_uri = "https://example.vault.azure.net/"
+      key_name  = "opentofu-state"
+    }
+  }
+}
@@ -0,0 +1,9 @@
+terraform {
+  encryption {
+    # Key provider configuration here
+
+    method "chacha20_poly1305" "yourname" {
+      keys = key_provider.yourkeyprovider.yourname
+    }
+  }
+}
\ No newline at end of file
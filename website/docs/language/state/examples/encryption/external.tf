@@ -0,0 +1,8 @@
+terraform {
+  encryption {
+    key_provider "external" "hsm" {
+      command = ["/usr/local/bin/hsm-cli", "opentofu-key"]
+      timeout = "10s"
+    }
+  }
+}
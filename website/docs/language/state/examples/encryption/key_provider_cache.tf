@@ -0,0 +1,18 @@
+terraform {
+  encryption {
+    key_provider "aws_kms" "basic" {
+      kms_key_id = "a4f791e1-0d46-4c8e-b489-917e0bec05ef"
+      region     = "us-east-1"
+      key_spec   = "AES_256"
+
+      cache {
+        # Forget cached keys after 15 minutes (default: never).
+        ttl = "15m"
+
+        # Request a new encryption key after it was used
+        # 100 times (this is the default).
+        rotation_threshold = 100
+      }
+    }
+  }
+}
@@ -0,0 +1,17 @@
+terraform {
+  encryption {
+    key_provider "pbkdf2" "mykey" {
+      passphrase = var.passphrase
+    }
+
+    method "aes_gcm" "new_method" {
+      keys = key_provider.pbkdf2.mykey
+    }
+
+    state {
+      # Only encrypt sensitive attribute values and sensitive outputs:
+      mode   = "sensitive_attributes"
+      method = method.aes_gcm.new_method
+    }
+  }
+}